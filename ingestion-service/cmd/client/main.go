@@ -8,6 +8,7 @@ import (
 	pb "github.com/nexus-logistics/ingestion-service/pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
@@ -22,14 +23,15 @@ func main() {
 	defer cancel()
 
     // Simulate a ping from "Vehicle-1"
+	var trailer metadata.MD
 	r, err := c.SendPing(ctx, &pb.LocationPing{
 		VehicleId: "vehicle-123",
 		Latitude:  37.7749,
 		Longitude: -122.4194,
 		Timestamp: time.Now().Unix(),
-	})
+	}, grpc.Trailer(&trailer))
 	if err != nil {
 		log.Fatalf("could not ping: %v", err)
 	}
-	log.Printf("Response: %s (Success: %v)", r.Message, r.Success)
+	log.Printf("Response: %s (Success: %v, Correlation ID: %s)", r.Message, r.Success, trailer.Get("x-correlation-id"))
 }
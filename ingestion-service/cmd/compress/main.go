@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/compress"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	pingsIn = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "compress_pings_in_total",
+		Help: "Pings read for compression",
+	})
+	pingsOut = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "compress_pings_out_total",
+		Help: "Pings kept and published, by vehicle class",
+	}, []string{"class"})
+	segmentDeviation = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "compress_segment_deviation_meters",
+		Help:    "Largest distance between a dropped ping and the kept trajectory, per kept segment",
+		Buckets: []float64{0.5, 1, 2, 5, 10, 20, 50, 100, 200, 500},
+	}, []string{"class"})
+	compressionRatio = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "compress_ratio",
+		Help: "Pings read per ping kept since start, by vehicle class",
+	}, []string{"class"})
+	maxDeviation = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "compress_max_deviation_meters",
+		Help: "Largest error introduced since start, by vehicle class",
+	}, []string{"class"})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	outputTopic := getEnv("OUTPUT_TOPIC", "vehicle-locations-compressed")
+	groupID := getEnv("GROUP_ID", "trajectory-compressor")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	// Vehicles silent this long have their last ping published.
+	idleFlush := envDuration("IDLE_FLUSH", 2*time.Minute)
+	reportInterval := envDuration("REPORT_INTERVAL", time.Minute)
+
+	// Tolerances per vehicle class (JSON, see internal/compress).
+	var cfg compress.Config
+	if path := os.Getenv("COMPRESSION_CONFIG_FILE"); path != "" {
+		var err error
+		cfg, err = compress.Load(path)
+		if err != nil {
+			slog.Error("Failed to load compression config", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Loaded compression config", "file", path)
+	}
+	compressor, err := compress.New(cfg)
+	if err != nil {
+		slog.Error("Invalid compression config", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	producer, err := kafka.NewProducer(kafka.Config{
+		Brokers: kafkaBrokers,
+		Topic:   outputTopic,
+		Mode:    kafka.ModeIdempotent,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka producer", "error", err)
+		os.Exit(1)
+	}
+	defer producer.Close()
+
+	// Offsets are stored by hand, no further than the oldest ping not yet
+	// published, so the pings of open trajectories are read again after a
+	// crash rather than never reaching storage.
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers:       kafkaBrokers,
+		GroupID:       groupID,
+		Topics:        []string{inputTopic},
+		ManualOffsets: true,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	// mu guards the compressor, outbox and added. Kept pings wait in the
+	// outbox until published, and added records the last offset given to
+	// the compressor per partition, so a batch retried after a failed
+	// publish is neither compressed twice nor loses its kept pings.
+	var (
+		mu     sync.Mutex
+		outbox []compress.Kept
+		added  = make(map[int32]int64)
+	)
+	publish := func(ctx context.Context) error {
+		if len(outbox) == 0 {
+			return nil
+		}
+		msgs := make([]kafka.Message, len(outbox))
+		for i, k := range outbox {
+			msgs[i] = kafka.Message{Key: k.VehicleID, Value: k.PingPayload, Headers: k.Headers}
+		}
+		if err := producer.ProduceBatch(ctx, msgs); err != nil {
+			return err
+		}
+		for _, k := range outbox {
+			pingsOut.WithLabelValues(k.Class).Inc()
+			if k.Dropped > 0 {
+				segmentDeviation.WithLabelValues(k.Class).Observe(k.Deviation)
+			}
+		}
+		outbox = outbox[:0]
+		return nil
+	}
+	// storeOffsets stores, per partition, the offset of the oldest ping
+	// still in the compressor or the outbox, or the one after the last
+	// ping added when there is none. mu must be held.
+	storeOffsets := func() {
+		next := make(map[int32]int64, len(added))
+		for p, off := range added {
+			next[p] = off + 1
+		}
+		hold := func(pos kafka.Position) {
+			if off, ok := next[pos.Partition]; !ok || pos.Offset < off {
+				next[pos.Partition] = pos.Offset
+			}
+		}
+		compressor.Buffered(hold)
+		for _, k := range outbox {
+			hold(k.Pos)
+		}
+		positions := make([]kafka.Position, 0, len(next))
+		for p, off := range next {
+			positions = append(positions, kafka.Position{Topic: inputTopic, Partition: p, Offset: off})
+		}
+		if err := consumer.StoreOffsets(positions); err != nil {
+			slog.Warn("Failed to store offsets", "error", err)
+		}
+	}
+
+	go func() {
+		flush := time.NewTicker(idleFlush / 4)
+		defer flush.Stop()
+		report := time.NewTicker(reportInterval)
+		defer report.Stop()
+		commit := time.NewTicker(time.Second)
+		defer commit.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-flush.C:
+				mu.Lock()
+				outbox = append(outbox, compressor.Flush(time.Now().Add(-idleFlush).Unix())...)
+				if err := publish(ctx); err != nil {
+					slog.Error("Failed to publish flushed pings", "error", err)
+				}
+				mu.Unlock()
+			case <-commit.C:
+				mu.Lock()
+				storeOffsets()
+				mu.Unlock()
+			case <-report.C:
+				mu.Lock()
+				reports := compressor.Report()
+				vehicles := compressor.Vehicles()
+				mu.Unlock()
+				for _, r := range reports {
+					compressionRatio.WithLabelValues(r.Class).Set(r.Ratio())
+					maxDeviation.WithLabelValues(r.Class).Set(r.MaxDeviation)
+					slog.Info("Compression report", "class", r.Class, "pings_in", r.In, "pings_out", r.Out,
+						"ratio", r.Ratio(), "max_deviation_meters", r.MaxDeviation, "open_vehicles", vehicles)
+				}
+			}
+		}
+	}()
+
+	batch := kafka.BatchConfig{MaxSize: 1000, MaxWait: 100 * time.Millisecond}
+	slog.Info("Trajectory compressor consuming", "topic", inputTopic, "output", outputTopic)
+	err = consumer.RunBatch(ctx, batch, func(ctx context.Context, recs []kafka.Record) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rec := range recs {
+			if last, ok := added[rec.Partition]; ok && rec.Offset <= last {
+				continue
+			}
+			added[rec.Partition] = rec.Offset
+			var ping service.PingPayload
+			if err := json.Unmarshal(rec.Value, &ping); err != nil || ping.VehicleID == "" {
+				continue
+			}
+			// Pings flagged by ingestion anomaly detection are not stored.
+			if ping.Anomaly != "" {
+				continue
+			}
+			pingsIn.Inc()
+			outbox = append(outbox, compressor.Add(ping.Tenant+"/"+ping.VehicleID, ping, rec.Headers, rec.Position())...)
+		}
+		return publish(ctx)
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+
+	// Publish the open trajectories' last pings so a restart need not
+	// read them again. If that fails, their offsets stay uncommitted.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	mu.Lock()
+	defer mu.Unlock()
+	outbox = append(outbox, compressor.FlushAll()...)
+	if err := publish(shutdownCtx); err != nil {
+		slog.Error("Failed to publish open trajectories", "error", err, "pings", len(outbox))
+	}
+	storeOffsets()
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		slog.Error("Invalid configuration", "key", key, "value", v)
+		os.Exit(1)
+	}
+	return d
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
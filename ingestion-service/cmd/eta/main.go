@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/eta"
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/mapmatch"
+	"github.com/nexus-logistics/ingestion-service/internal/roadgraph"
+	"github.com/nexus-logistics/ingestion-service/internal/routing"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	etasPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "eta_published_total",
+		Help: "ETAs published, by status",
+	}, []string{"status"})
+	predictSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "eta_predict_seconds",
+		Help:    "Time to process one ping, including routing when due",
+		Buckets: []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5},
+	})
+	arrivalError = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "eta_arrival_error_seconds",
+		Help:    "Actual minus last predicted arrival time, on arrival",
+		Buckets: []float64{-900, -300, -120, -60, -30, 0, 30, 60, 120, 300, 900},
+	})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	pingTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	routeTopic := getEnv("ROUTE_TOPIC", "route-updates")
+	outputTopic := getEnv("OUTPUT_TOPIC", "vehicle-etas")
+	groupID := getEnv("GROUP_ID", "eta-service")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	snapshotFile := os.Getenv("SPEED_SNAPSHOT_FILE")
+	pbfFile := os.Getenv("OSM_PBF_FILE")
+	if pbfFile == "" {
+		slog.Error("OSM_PBF_FILE must name an OpenStreetMap PBF extract")
+		os.Exit(1)
+	}
+	cfg := eta.DefaultConfig()
+	cfg.MinChangeSeconds = envFloat("ETA_MIN_CHANGE_SECONDS", cfg.MinChangeSeconds)
+	cfg.MinChangeRatio = envFloat("ETA_MIN_CHANGE_RATIO", cfg.MinChangeRatio)
+	cfg.MinInterval = envDuration("ETA_MIN_INTERVAL", cfg.MinInterval)
+	cfg.ArrivalRadiusMeters = envFloat("ARRIVAL_RADIUS_METERS", cfg.ArrivalRadiusMeters)
+	// Vehicles silent for longer than this are forgotten.
+	stateTTL := 6 * time.Hour
+
+	start := time.Now()
+	graph, err := roadgraph.Load(pbfFile)
+	if err != nil {
+		slog.Error("Failed to load road graph", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Loaded road graph", "file", pbfFile, "nodes", len(graph.Nodes), "edges", len(graph.Edges), "duration", time.Since(start))
+
+	speeds := eta.NewSpeedModel(graph)
+	if snapshotFile != "" {
+		n, err := speeds.Load(snapshotFile)
+		if err != nil {
+			slog.Error("Failed to load speed snapshot", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Loaded learned speeds", "file", snapshotFile, "segments", n)
+	}
+	predictor := eta.NewPredictor(
+		routing.NewRouter(graph),
+		speeds,
+		mapmatch.NewMatcher(graph, mapmatch.DefaultConfig()),
+		cfg,
+	)
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "eta_tracked_vehicles",
+		Help: "Number of vehicles with ETA state",
+	}, func() float64 { return float64(predictor.Vehicles()) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "eta_learned_segments",
+		Help: "Number of road segments with learned speeds",
+	}, func() float64 { return float64(speeds.Segments()) })
+
+	producer, err := kafka.NewProducer(kafka.Config{
+		Brokers: kafkaBrokers,
+		Topic:   outputTopic,
+		Mode:    kafka.ModeIdempotent,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka producer", "error", err)
+		os.Exit(1)
+	}
+	defer producer.Close()
+
+	// Each instance needs a vehicle's pings and route updates together.
+	// Both topics are keyed by vehicle ID and produced by Go services, so
+	// with equal partition counts, checked here, the range assignor keeps
+	// them together. The Java route service partitions differently, so its
+	// route-requests consumer must be off where this runs, as in compose.
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers:       kafkaBrokers,
+		GroupID:       groupID,
+		Topics:        []string{routeTopic, pingTopic},
+		CoPartitioned: true,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	save := func() {
+		if snapshotFile == "" {
+			return
+		}
+		if err := speeds.Save(snapshotFile); err != nil {
+			slog.Error("Failed to save learned speeds", "error", err)
+		}
+	}
+	defer save()
+	go func() {
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				predictor.Evict(time.Now().Add(-stateTTL).Unix())
+				save()
+			}
+		}
+	}()
+
+	slog.Info("ETA service consuming", "topics", []string{pingTopic, routeTopic}, "output", outputTopic)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		if rec.Topic == routeTopic {
+			var update routing.RouteUpdate
+			if err := json.Unmarshal(rec.Value, &update); err != nil {
+				return fmt.Errorf("invalid route update: %w", err)
+			}
+			predictor.SetStop(update)
+			return nil
+		}
+
+		var ping service.PingPayload
+		if err := json.Unmarshal(rec.Value, &ping); err != nil {
+			return fmt.Errorf("invalid ping payload: %w", err)
+		}
+		if ping.Anomaly != "" {
+			return nil
+		}
+
+		start := time.Now()
+		e, ok := predictor.Update(ping.VehicleID, ping.Tenant, geo.Point{Lat: ping.Latitude, Lon: ping.Longitude}, ping.Timestamp)
+		predictSeconds.Observe(time.Since(start).Seconds())
+		if !ok {
+			return nil
+		}
+
+		// The consumer skips records whose handler fails, so the publish
+		// is retried here; the predictor only records the ETA once it
+		// succeeds.
+		msg := kafka.Message{Key: e.VehicleID, Value: e}
+		backoff := 100 * time.Millisecond
+		for {
+			err := producer.ProduceBatch(ctx, []kafka.Message{msg})
+			if err == nil {
+				break
+			}
+			slog.ErrorContext(ctx, "Failed to publish ETA, retrying", "error", err, "backoff", backoff)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("failed to publish ETA: %w", err)
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, 30*time.Second)
+		}
+		predictor.Published(e)
+		etasPublished.WithLabelValues(e.Status).Inc()
+		if e.PredictedArrival != 0 {
+			arrivalError.Observe(float64(e.ArrivalTime - e.PredictedArrival))
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envFloat(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		slog.Error("Invalid configuration", "key", key, "error", err)
+		os.Exit(1)
+	}
+	return f
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		slog.Error("Invalid configuration", "key", key, "error", err)
+		os.Exit(1)
+	}
+	return d
+}
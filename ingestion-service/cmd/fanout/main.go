@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/fanout"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/latest"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	httpAddr := getEnv("HTTP_ADDR", ":8090")
+	// Every replica serves the whole fleet, so each needs its own group.
+	hostname, _ := os.Hostname()
+	groupID := getEnv("GROUP_ID", "fanout-gateway-"+hostname)
+	tick := envDuration("FANOUT_TICK", 500*time.Millisecond)
+	ttl := envDuration("VEHICLE_TTL", latest.DefaultTTL)
+	handlerCfg := fanout.HandlerConfig{
+		MaxConnections: envInt("MAX_CONNECTIONS", 10_000),
+		WriteTimeout:   envDuration("WRITE_TIMEOUT", 10*time.Second),
+		KeepAlive:      envDuration("KEEPALIVE_INTERVAL", 15*time.Second),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	broker := fanout.NewBroker(tick)
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "fanout_tracked_vehicles",
+		Help: "Vehicles with a known position",
+	}, func() float64 { return float64(broker.Vehicles()) })
+
+	// Read from just before the bootstrap below, so pings published while
+	// it loads still reach subscribers.
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers: kafkaBrokers,
+		GroupID: groupID,
+		Topics:  []string{inputTopic},
+		StartAt: time.Now(),
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	if host := os.Getenv("REDIS_HOST"); host != "" {
+		n, err := bootstrap(ctx, broker, net.JoinHostPort(host, getEnv("REDIS_PORT", "6379")), ttl)
+		if err != nil {
+			slog.Error("Failed to load latest positions", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Loaded latest positions", "vehicles", n)
+	}
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	srv := &http.Server{
+		Addr:              httpAddr,
+		Handler:           broker.Handler(handlerCfg),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Connections are long-lived and cancelled with the server.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		slog.Info("Fan-out gateway listening", "addr", httpAddr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Failed to serve", "error", err)
+			os.Exit(1)
+		}
+	}()
+	defer srv.Close()
+
+	go broker.Run(ctx)
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				broker.Evict(time.Now().Add(-ttl).Unix())
+			}
+		}
+	}()
+
+	slog.Info("Fan-out gateway consuming", "topic", inputTopic, "group", groupID, "tick", tick)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		var ping service.PingPayload
+		if err := json.Unmarshal(rec.Value, &ping); err != nil {
+			return fmt.Errorf("invalid ping payload: %w", err)
+		}
+		if ping.Anomaly != "" {
+			return nil
+		}
+		broker.Publish(ping)
+		return nil
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// bootstrap loads the latest positions kept in Redis by the latest-position
+// writer, so the first clients after a restart see the whole fleet.
+func bootstrap(ctx context.Context, broker *fanout.Broker, addr string, ttl time.Duration) (int, error) {
+	rdb := redis.NewClient(&redis.Options{Addr: addr, Password: os.Getenv("REDIS_PASSWORD")})
+	defer rdb.Close()
+	pings, err := latest.NewRedisStore(rdb, ttl).Active(ctx, time.Now().Add(-ttl).Unix(), 0)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range pings {
+		broker.Publish(p)
+	}
+	return len(pings), nil
+}
+
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		slog.Error("Invalid configuration", "key", key, "value", v)
+		os.Exit(1)
+	}
+	return n
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		slog.Error("Invalid configuration", "key", key, "value", v)
+		os.Exit(1)
+	}
+	return d
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/fleetsim"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/replay"
+	"github.com/nexus-logistics/ingestion-service/internal/roadgraph"
+	"github.com/nexus-logistics/ingestion-service/internal/routing"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	vehicles   = flag.Int("n", 100, "Number of simulated vehicles")
+	duration   = flag.Duration("d", 0, "How long to run (default until interrupted)")
+	configFile = flag.String("config", "", "Simulator config (JSON, see internal/fleetsim)")
+	seed       = flag.Int64("seed", 0, "Random seed, for a repeatable fleet (default time-based)")
+	prefix     = flag.String("prefix", "sim-", "Vehicle ID prefix")
+
+	// Roads: -osm or -gpx.
+	osmFile = flag.String("osm", "", "OpenStreetMap PBF extract to drive on")
+	gpx     = flag.String("gpx", "", "Comma-separated GPX files or globs of tracks to drive")
+
+	// Targets: -addr (default) or -target-topic.
+	addr        = flag.String("addr", "localhost:50051", "Ingestion Service address to send pings to with SendPing")
+	targetTopic = flag.String("target-topic", "", "Kafka topic to produce pings to instead of using SendPing")
+	brokers     = flag.String("brokers", getEnv("KAFKA_BROKERS", "localhost:9092"), "Kafka brokers")
+	apiKey      = flag.String("api-key", "", "API key for SendPing")
+	tenant      = flag.String("tenant", "", "Tenant of the simulated pings")
+	workers     = flag.Int("workers", 16, "Parallel senders")
+	progress    = flag.Duration("progress", 10*time.Second, "Progress log interval")
+)
+
+func main() {
+	flag.Parse()
+	logging.Setup(os.Stderr, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	var cfg fleetsim.Config
+	if *configFile != "" {
+		var err error
+		if cfg, err = fleetsim.Load(*configFile); err != nil {
+			slog.Error("Failed to load simulator config", "error", err)
+			os.Exit(1)
+		}
+	}
+	planner, err := openPlanner(cfg)
+	if err != nil {
+		slog.Error("Failed to load roads", "error", err)
+		os.Exit(1)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	sim, err := fleetsim.New(cfg, planner, *vehicles, *prefix, *seed)
+	if err != nil {
+		slog.Error("Invalid simulator config", "error", err)
+		os.Exit(1)
+	}
+
+	sink, closeSink, err := openSink()
+	if err != nil {
+		slog.Error("Failed to open target", "error", err)
+		os.Exit(1)
+	}
+	defer closeSink()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+
+	slog.Info("Simulating fleet", "vehicles", *vehicles, "seed", *seed)
+	sim.Run(ctx, sink, fleetsim.RunOptions{Workers: *workers, Tenant: *tenant, Progress: *progress})
+	slog.Info("Simulation stopped")
+}
+
+func openPlanner(cfg fleetsim.Config) (fleetsim.Planner, error) {
+	switch {
+	case *osmFile != "" && *gpx == "":
+		slog.Info("Loading road graph", "file", *osmFile)
+		g, err := roadgraph.Load(*osmFile)
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("Road graph loaded", "nodes", len(g.Nodes), "edges", len(g.Edges))
+		return fleetsim.NewRoadPlanner(routing.NewRouter(g), cfg.TripKM), nil
+
+	case *gpx != "" && *osmFile == "":
+		var tracks []*fleetsim.Path
+		for _, pattern := range strings.Split(*gpx, ",") {
+			files, err := filepath.Glob(strings.TrimSpace(pattern))
+			if err != nil {
+				return nil, err
+			}
+			if len(files) == 0 {
+				return nil, fmt.Errorf("no GPX files match %q", pattern)
+			}
+			for _, f := range files {
+				paths, err := fleetsim.LoadGPX(f)
+				if err != nil {
+					return nil, err
+				}
+				tracks = append(tracks, paths...)
+			}
+		}
+		slog.Info("GPX tracks loaded", "tracks", len(tracks))
+		return fleetsim.NewTrackPlanner(tracks), nil
+	}
+	return nil, fmt.Errorf("exactly one of -osm or -gpx is required")
+}
+
+func openSink() (replay.Sink, func(), error) {
+	if *targetTopic != "" {
+		producer, err := kafka.NewProducer(kafka.Config{
+			Brokers: *brokers,
+			Topic:   *targetTopic,
+			Mode:    kafka.ModeIdempotent,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return replay.NewKafkaSink(producer), producer.Close, nil
+	}
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return replay.NewGRPCSink(pb.NewTrackerServiceClient(conn), *apiKey), func() { conn.Close() }, nil
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
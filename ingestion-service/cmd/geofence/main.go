@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/geofence"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/metrics"
+	"github.com/nexus-logistics/ingestion-service/internal/postgres"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var (
+	geofenceEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "geofence_events_total",
+		Help: "Geofence events produced, by type",
+	}, []string{"type"})
+	evaluationSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "geofence_evaluation_seconds",
+		Help:    "Time to evaluate one ping against all geofences",
+		Buckets: []float64{0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01},
+	})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	outputTopic := getEnv("OUTPUT_TOPIC", "geofence-events")
+	groupID := getEnv("GROUP_ID", "geofence-service")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	grpcAddr := getEnv("GRPC_ADDR", ":50052")
+	// Vehicles silent for longer than this lose their inside/outside state.
+	stateTTL := 24 * time.Hour
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Geofences are stored in Postgres when it is configured, so that they
+	// survive restarts and every instance sees the same set.
+	var store geofence.Store = geofence.NewMemoryStore()
+	if os.Getenv("POSTGRES_HOST") != "" {
+		dsn := postgres.DSNFromEnv()
+		db, err := postgres.Open(ctx, dsn)
+		if err != nil {
+			slog.Error("Failed to connect to Postgres", "error", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+		store = geofence.NewPostgresStore(db, dsn)
+	}
+	// GEOFENCES_FILE seeds an empty store only, so restarts never undo
+	// changes made through the API.
+	if path := os.Getenv("GEOFENCES_FILE"); path != "" {
+		empty, err := store.Empty(ctx)
+		if err != nil {
+			slog.Error("Failed to check geofence store", "error", err)
+			os.Exit(1)
+		}
+		if !empty {
+			slog.Info("Geofence store already populated, skipping import", "file", path)
+		} else {
+			n, err := importGeofences(ctx, store, path)
+			if err != nil {
+				slog.Error("Failed to import geofences", "error", err)
+				os.Exit(1)
+			}
+			slog.Info("Imported geofences", "file", path, "count", n)
+		}
+	}
+
+	engine := geofence.NewEngine()
+	if err := reload(ctx, engine, store); err != nil {
+		slog.Error("Failed to load geofences", "error", err)
+		os.Exit(1)
+	}
+	// Changes made through the API reach the engine through the store's
+	// watch; the periodic reload covers missed notifications.
+	go store.Watch(ctx, func() {
+		if err := reload(ctx, engine, store); err != nil {
+			slog.Error("Failed to reload geofences", "error", err)
+		}
+	})
+	go func() {
+		ticker := time.NewTicker(5 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := reload(ctx, engine, store); err != nil {
+					slog.Error("Failed to reload geofences", "error", err)
+				}
+			}
+		}
+	}()
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "geofence_active_geofences",
+		Help: "Number of geofences being evaluated",
+	}, func() float64 { return float64(engine.Index().Len()) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "geofence_tracked_vehicles",
+		Help: "Number of vehicles with inside/outside state",
+	}, func() float64 { return float64(engine.Vehicles()) })
+
+	producer, err := kafka.NewProducer(kafka.Config{
+		Brokers: kafkaBrokers,
+		Topic:   outputTopic,
+		Mode:    kafka.ModeIdempotent,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka producer", "error", err)
+		os.Exit(1)
+	}
+	defer producer.Close()
+
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers: kafkaBrokers,
+		GroupID: groupID,
+		Topics:  []string{inputTopic},
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		slog.Error("Failed to listen", "error", err)
+		os.Exit(1)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterGeofenceServiceServer(s, geofence.NewServer(store))
+	reflection.Register(s)
+	go func() {
+		slog.Info("Geofence API listening", "addr", grpcAddr)
+		if err := s.Serve(lis); err != nil {
+			slog.Error("Failed to serve", "error", err)
+		}
+	}()
+	defer s.GracefulStop()
+
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n := engine.Evict(time.Now().Add(-stateTTL).Unix()); n > 0 {
+					slog.Info("Evicted idle vehicle state", "vehicles", n)
+				}
+			}
+		}
+	}()
+
+	slog.Info("Geofence service consuming", "topic", inputTopic, "output", outputTopic)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		var ping service.PingPayload
+		if err := json.Unmarshal(rec.Value, &ping); err != nil {
+			return fmt.Errorf("invalid ping payload: %w", err)
+		}
+
+		start := time.Now()
+		t := engine.Evaluate(ping.VehicleID, ping.Tenant, geo.Point{Lat: ping.Latitude, Lon: ping.Longitude}, ping.Timestamp)
+		evaluationSeconds.Observe(time.Since(start).Seconds())
+		if len(t.Events) == 0 {
+			engine.Apply(t)
+			return nil
+		}
+
+		msgs := make([]kafka.Message, len(t.Events))
+		for i, ev := range t.Events {
+			msgs[i] = kafka.Message{Key: ev.VehicleID, Value: ev}
+		}
+		// The consumer skips records whose handler fails, so the publish
+		// is retried here; the state only changes once it succeeds.
+		backoff := 100 * time.Millisecond
+		for {
+			err := producer.ProduceBatch(ctx, msgs)
+			if err == nil {
+				break
+			}
+			slog.ErrorContext(ctx, "Failed to publish geofence events, retrying", "error", err, "backoff", backoff)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("failed to publish geofence events: %w", err)
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, 30*time.Second)
+		}
+		engine.Apply(t)
+		for _, ev := range t.Events {
+			geofenceEvents.WithLabelValues(string(ev.Type)).Inc()
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// importGeofences stores every feature of a GeoJSON file in one import.
+func importGeofences(ctx context.Context, store geofence.Store, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	fences, err := geofence.ParseFeatureCollection(data)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := store.Import(ctx, fences); err != nil {
+		return 0, err
+	}
+	return len(fences), nil
+}
+
+// reload replaces the engine's geofences with the current contents of the
+// store. Vehicle inside/outside state is kept across reloads.
+func reload(ctx context.Context, engine *geofence.Engine, store geofence.Store) error {
+	versions, err := store.List(ctx, "", 0)
+	if err != nil {
+		return err
+	}
+	fences := make([]geofence.Geofence, len(versions))
+	for i, v := range versions {
+		fences[i] = v.Geofence
+	}
+	engine.Set(fences)
+	slog.Info("Loaded geofences", "count", len(fences))
+	return nil
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
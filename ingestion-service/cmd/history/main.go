@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nexus-logistics/ingestion-service/internal/history"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/metrics"
+	"github.com/nexus-logistics/ingestion-service/internal/postgres"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	grpcAddr := getEnv("GRPC_ADDR", ":50057")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := postgres.Open(ctx, postgres.DSNFromEnv())
+	if err != nil {
+		slog.Error("Failed to connect to PostgreSQL", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		slog.Error("Failed to listen", "error", err)
+		os.Exit(1)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterHistoryServiceServer(s, history.NewServer(history.NewPostgresStore(db)))
+	reflection.Register(s)
+	go func() {
+		slog.Info("History API listening", "addr", grpcAddr)
+		if err := s.Serve(lis); err != nil {
+			slog.Error("Failed to serve", "error", err)
+		}
+	}()
+
+	<-ctx.Done()
+	slog.Info("Shutting down")
+	s.GracefulStop()
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
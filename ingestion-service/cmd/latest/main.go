@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/latest"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	updates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "latest_updates_total",
+		Help: "Pings processed, by result: applied, stale (older than the stored position) or superseded (a newer ping for the vehicle was in the same batch)",
+	}, []string{"result"})
+	batchSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "latest_batch_seconds",
+		Help:    "Time to apply one batch of updates",
+		Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+	})
+	prunedVehicles = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "latest_pruned_vehicles_total",
+		Help: "Vehicles dropped from the active index after going silent",
+	})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	groupID := getEnv("GROUP_ID", "latest-writer")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	redisAddr := net.JoinHostPort(getEnv("REDIS_HOST", "localhost"), getEnv("REDIS_PORT", "6379"))
+	ttl := latest.DefaultTTL
+	if v := os.Getenv("LATEST_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Second {
+			slog.Error("Invalid configuration", "key", "LATEST_TTL", "value", v)
+			os.Exit(1)
+		}
+		ttl = d
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	rdb := redis.NewClient(&redis.Options{Addr: redisAddr, Password: os.Getenv("REDIS_PASSWORD")})
+	defer rdb.Close()
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		slog.Error("Failed to connect to Redis", "addr", redisAddr, "error", err)
+		os.Exit(1)
+	}
+	store := latest.NewRedisStore(rdb, ttl)
+	if err := store.LoadScripts(ctx); err != nil {
+		slog.Error("Failed to prepare Redis", "error", err)
+		os.Exit(1)
+	}
+
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers: kafkaBrokers,
+		GroupID: groupID,
+		Topics:  []string{inputTopic},
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	// Positions expire on their own; the index needs pruning.
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n, err := store.Prune(ctx, time.Now().Add(-ttl).Unix())
+				if err != nil {
+					slog.Error("Failed to prune active vehicles", "error", err)
+					continue
+				}
+				prunedVehicles.Add(float64(n))
+			}
+		}
+	}()
+
+	batch := kafka.BatchConfig{MaxSize: 1000, MaxWait: 50 * time.Millisecond}
+	slog.Info("Latest position writer consuming", "topic", inputTopic, "redis", redisAddr)
+	err = consumer.RunBatch(ctx, batch, func(ctx context.Context, recs []kafka.Record) error {
+		// Only the newest ping per vehicle in a batch needs writing. Pings
+		// older than one before them in the batch are stale already.
+		newest := make(map[string]int)
+		var pings []service.PingPayload
+		stale, superseded := 0, 0
+		for _, rec := range recs {
+			var ping service.PingPayload
+			if err := json.Unmarshal(rec.Value, &ping); err != nil || ping.VehicleID == "" {
+				continue
+			}
+			if ping.Anomaly != "" {
+				continue
+			}
+			i, ok := newest[ping.VehicleID]
+			switch {
+			case !ok:
+				newest[ping.VehicleID] = len(pings)
+				pings = append(pings, ping)
+			case ping.Timestamp <= pings[i].Timestamp:
+				stale++
+			default:
+				pings[i] = ping
+				superseded++
+			}
+		}
+		if len(pings) == 0 {
+			return nil
+		}
+
+		start := time.Now()
+		applied, err := store.Update(ctx, pings)
+		if err != nil {
+			return err
+		}
+		batchSeconds.Observe(time.Since(start).Seconds())
+		written := 0
+		for _, ok := range applied {
+			if ok {
+				written++
+			} else {
+				stale++
+			}
+		}
+		updates.WithLabelValues("applied").Add(float64(written))
+		updates.WithLabelValues("stale").Add(float64(stale))
+		updates.WithLabelValues("superseded").Add(float64(superseded))
+		return nil
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
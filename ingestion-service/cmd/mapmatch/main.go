@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/mapmatch"
+	"github.com/nexus-logistics/ingestion-service/internal/roadgraph"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	matchedPings = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "mapmatch_pings_total",
+		Help: "Pings processed, by whether a road was matched",
+	}, []string{"matched"})
+	matchSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "mapmatch_match_seconds",
+		Help:    "Time to match one ping",
+		Buckets: []float64{0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05},
+	})
+	roadDistance = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "mapmatch_distance_from_road_meters",
+		Help:    "Distance between matched pings and their road",
+		Buckets: []float64{1, 2, 5, 10, 20, 30, 50},
+	})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	outputTopic := getEnv("OUTPUT_TOPIC", "vehicle-locations-matched")
+	groupID := getEnv("GROUP_ID", "mapmatch-service")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	pbfFile := os.Getenv("OSM_PBF_FILE")
+	if pbfFile == "" {
+		slog.Error("OSM_PBF_FILE must name an OpenStreetMap PBF extract")
+		os.Exit(1)
+	}
+	cfg := mapmatch.DefaultConfig()
+	if v, err := strconv.ParseFloat(os.Getenv("MATCH_SEARCH_RADIUS"), 64); err == nil && v > 0 {
+		cfg.SearchRadius = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("MATCH_LAG")); err == nil && v >= 0 {
+		cfg.Lag = v
+	}
+	// Vehicles silent for longer than this start a new matching session.
+	sessionTTL := time.Hour
+	// Pings of vehicles silent for this long are settled without waiting
+	// for later pings.
+	idleFlush := 30 * time.Second
+
+	start := time.Now()
+	graph, err := roadgraph.Load(pbfFile)
+	if err != nil {
+		slog.Error("Failed to load road graph", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Loaded road graph", "file", pbfFile, "nodes", len(graph.Nodes), "edges", len(graph.Edges), "duration", time.Since(start))
+	tracker := mapmatch.NewTracker(mapmatch.NewMatcher(graph, cfg))
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "mapmatch_tracked_vehicles",
+		Help: "Number of vehicles with a matching session",
+	}, func() float64 { return float64(tracker.Vehicles()) })
+
+	producer, err := kafka.NewProducer(kafka.Config{
+		Brokers: kafkaBrokers,
+		Topic:   outputTopic,
+		Mode:    kafka.ModeIdempotent,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka producer", "error", err)
+		os.Exit(1)
+	}
+	defer producer.Close()
+
+	// Offsets are stored by hand, no further than the oldest ping whose
+	// match has not been published, so pings waiting on the lag window at
+	// a crash are read again.
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers:       kafkaBrokers,
+		GroupID:       groupID,
+		Topics:        []string{inputTopic},
+		ManualOffsets: true,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// mu makes settling matches and producing them one step, so the flush
+	// loop and the consumer cannot reorder a vehicle's pings. held tracks
+	// the pings whose matches are not yet published.
+	var mu sync.Mutex
+	held := kafka.NewHeld()
+	publish := func(ctx context.Context, settled []mapmatch.Settled) error {
+		msgs := make([]kafka.Message, len(settled))
+		for i, st := range settled {
+			msgs[i] = kafka.Message{Key: st.Ping.VehicleID, Value: st.Ping, Headers: st.Headers}
+		}
+		// The tracker has already let go of these pings, so the publish is
+		// retried rather than failing the record.
+		backoff := 100 * time.Millisecond
+		for len(msgs) > 0 {
+			err := producer.ProduceBatch(ctx, msgs)
+			if err == nil {
+				break
+			}
+			slog.ErrorContext(ctx, "Failed to publish matched pings, retrying", "error", err, "backoff", backoff)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("failed to publish matched pings: %w", err)
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, 30*time.Second)
+		}
+		for _, st := range settled {
+			held.Release(st.Pos)
+			matchedPings.WithLabelValues(strconv.FormatBool(st.Ping.Matched)).Inc()
+			if st.Ping.Matched {
+				roadDistance.Observe(st.Ping.DistanceFromRoad)
+			}
+		}
+		return nil
+	}
+
+	go func() {
+		flush := time.NewTicker(idleFlush / 4)
+		defer flush.Stop()
+		commit := time.NewTicker(time.Second)
+		defer commit.Stop()
+		evict := time.NewTicker(10 * time.Minute)
+		defer evict.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-flush.C:
+				mu.Lock()
+				if err := publish(ctx, tracker.Flush(now.Add(-idleFlush))); err != nil {
+					slog.Error("Failed to publish flushed matches", "error", err)
+				}
+				mu.Unlock()
+			case <-commit.C:
+				if err := consumer.StoreOffsets(held.Offsets()); err != nil {
+					slog.Warn("Failed to store offsets", "error", err)
+				}
+			case <-evict.C:
+				tracker.Evict(time.Now().Add(-sessionTTL).Unix())
+			}
+		}
+	}()
+
+	slog.Info("Map matcher consuming", "topic", inputTopic, "output", outputTopic, "lag", cfg.Lag)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		pos := rec.Position()
+		defer held.Seen(pos)
+		var ping service.PingPayload
+		if err := json.Unmarshal(rec.Value, &ping); err != nil {
+			return fmt.Errorf("invalid ping payload: %w", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		held.Hold(pos)
+		start := time.Now()
+		settled := tracker.Add(ping, rec.Headers, pos, start)
+		matchSeconds.Observe(time.Since(start).Seconds())
+		return publish(ctx, settled)
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+	if err := consumer.StoreOffsets(held.Offsets()); err != nil {
+		slog.Warn("Failed to store offsets", "error", err)
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
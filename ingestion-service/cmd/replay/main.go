@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/postgres"
+	"github.com/nexus-logistics/ingestion-service/internal/replay"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var (
+	// Sources: exactly one of -file, -source-topic or -start (PostgreSQL).
+	file        = flag.String("file", "", "NDJSON file of pings to replay (- for stdin)")
+	sourceTopic = flag.String("source-topic", "", "Kafka topic to replay from; requires -partitions")
+	partitions  = flag.String("partitions", "", "Offset ranges to replay as partition:start-end[,...], end exclusive")
+	start       = flag.String("start", "", "Replay vehicle_locations from this time (RFC 3339)")
+	end         = flag.String("end", "", "Replay vehicle_locations up to this time (RFC 3339, default now)")
+	vehicles    = flag.String("vehicles", "", "Comma-separated vehicle IDs to replay from vehicle_locations (default all)")
+
+	// Targets: -target-topic or -addr.
+	targetTopic = flag.String("target-topic", "", "Kafka topic to produce pings to")
+	addr        = flag.String("addr", "", "Ingestion Service address to send pings to with SendPing")
+	apiKey      = flag.String("api-key", "", "API key for SendPing (default: send each ping's recorded tenant)")
+	brokers     = flag.String("brokers", getEnv("KAFKA_BROKERS", "localhost:9092"), "Kafka brokers")
+
+	speed     = flag.Float64("speed", 1, "Speed multiplier; 0 replays as fast as possible")
+	vehicleID = flag.String("vehicle-id", "", `Rewrite vehicle IDs, with {id} for the original (e.g. "replay-{id}")`)
+	shiftTime = flag.Bool("shift-time", false, "Move timestamps so the replay starts now")
+	progress  = flag.Duration("progress", 10*time.Second, "Progress log interval")
+)
+
+func main() {
+	flag.Parse()
+	logging.Setup(os.Stderr, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *speed < 0 {
+		slog.Error("Invalid configuration", "key", "speed", "value", *speed)
+		os.Exit(1)
+	}
+	src, closeSrc, err := openSource(ctx)
+	if err != nil {
+		slog.Error("Failed to open replay source", "error", err)
+		os.Exit(1)
+	}
+	defer closeSrc()
+	sink, closeSink, err := openSink()
+	if err != nil {
+		slog.Error("Failed to open replay target", "error", err)
+		os.Exit(1)
+	}
+	defer closeSink()
+
+	slog.Info("Replaying", "speed", *speed, "vehicle_id", *vehicleID, "shift_time", *shiftTime)
+	began := time.Now()
+	stats, err := replay.Run(ctx, src, sink, replay.Options{
+		Speed:     *speed,
+		VehicleID: *vehicleID,
+		ShiftTime: *shiftTime,
+		Progress:  *progress,
+	})
+	slog.Info("Replay finished", "sent", stats.Sent, "elapsed", time.Since(began), "max_lag", stats.MaxLag)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		slog.Error("Replay stopped", "error", err)
+		closeSink()
+		closeSrc()
+		os.Exit(1)
+	}
+}
+
+func openSource(ctx context.Context) (replay.Source, func(), error) {
+	switch {
+	case *file != "" && *sourceTopic == "" && *start == "":
+		if *file == "-" {
+			return replay.NewNDJSONSource(os.Stdin), func() {}, nil
+		}
+		f, err := os.Open(*file)
+		if err != nil {
+			return nil, nil, err
+		}
+		return replay.NewNDJSONSource(f), func() { f.Close() }, nil
+
+	case *sourceTopic != "" && *file == "" && *start == "":
+		ranges, err := parseRanges(*partitions)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Records are reordered by arrival across partitions, so the
+		// ranges are read up front.
+		var recs []kafka.Record
+		err = kafka.ReadRange(ctx, kafka.RangeConfig{Brokers: *brokers, Topic: *sourceTopic, Ranges: ranges},
+			func(rec kafka.Record) error {
+				recs = append(recs, rec)
+				return nil
+			})
+		if err != nil {
+			return nil, nil, err
+		}
+		src := replay.NewRecordSource(recs)
+		slog.Info("Read Kafka records", "topic", *sourceTopic, "records", len(recs), "pings", src.Len())
+		return src, func() {}, nil
+
+	case *start != "" && *file == "" && *sourceTopic == "":
+		q, err := parseQuery()
+		if err != nil {
+			return nil, nil, err
+		}
+		db, err := postgres.Open(ctx, postgres.DSNFromEnv())
+		if err != nil {
+			return nil, nil, err
+		}
+		src := replay.NewPostgresSource(db, q)
+		return src, func() { src.Close(); db.Close() }, nil
+	}
+	return nil, nil, fmt.Errorf("exactly one of -file, -source-topic or -start is required")
+}
+
+func openSink() (replay.Sink, func(), error) {
+	switch {
+	case *targetTopic != "" && *addr == "":
+		producer, err := kafka.NewProducer(kafka.Config{
+			Brokers: *brokers,
+			Topic:   *targetTopic,
+			Mode:    kafka.ModeIdempotent,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return replay.NewKafkaSink(producer), producer.Close, nil
+
+	case *addr != "" && *targetTopic == "":
+		conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, nil, err
+		}
+		return replay.NewGRPCSink(pb.NewTrackerServiceClient(conn), *apiKey), func() { conn.Close() }, nil
+	}
+	return nil, nil, fmt.Errorf("exactly one of -target-topic or -addr is required")
+}
+
+// parseRanges parses "partition:start-end[,...]".
+func parseRanges(s string) (map[int32]kafka.OffsetRange, error) {
+	if s == "" {
+		return nil, fmt.Errorf("-partitions is required with -source-topic")
+	}
+	ranges := make(map[int32]kafka.OffsetRange)
+	for _, part := range strings.Split(s, ",") {
+		p, offsets, ok1 := strings.Cut(strings.TrimSpace(part), ":")
+		from, to, ok2 := strings.Cut(offsets, "-")
+		partition, err1 := strconv.ParseInt(p, 10, 32)
+		startOffset, err2 := strconv.ParseInt(from, 10, 64)
+		endOffset, err3 := strconv.ParseInt(to, 10, 64)
+		if !ok1 || !ok2 || err1 != nil || err2 != nil || err3 != nil || startOffset < 0 || endOffset <= startOffset {
+			return nil, fmt.Errorf("invalid offset range %q, want partition:start-end", part)
+		}
+		ranges[int32(partition)] = kafka.OffsetRange{Start: startOffset, End: endOffset}
+	}
+	return ranges, nil
+}
+
+func parseQuery() (replay.PostgresQuery, error) {
+	from, err := time.Parse(time.RFC3339, *start)
+	if err != nil {
+		return replay.PostgresQuery{}, fmt.Errorf("invalid -start: %w", err)
+	}
+	to := time.Now()
+	if *end != "" {
+		if to, err = time.Parse(time.RFC3339, *end); err != nil {
+			return replay.PostgresQuery{}, fmt.Errorf("invalid -end: %w", err)
+		}
+	}
+	if !to.After(from) {
+		return replay.PostgresQuery{}, fmt.Errorf("-end must be after -start")
+	}
+	q := replay.PostgresQuery{Start: from.Unix(), End: to.Unix()}
+	if *vehicles != "" {
+		q.VehicleIDs = strings.Split(*vehicles, ",")
+	}
+	return q, nil
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
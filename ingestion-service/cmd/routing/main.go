@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/metrics"
+	"github.com/nexus-logistics/ingestion-service/internal/roadgraph"
+	"github.com/nexus-logistics/ingestion-service/internal/routing"
+	"github.com/nexus-logistics/ingestion-service/internal/vrp"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var (
+	routeRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "routing_requests_total",
+		Help: "Route requests answered, by status",
+	}, []string{"status"})
+	routeSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "routing_query_seconds",
+		Help:    "Time to answer one route request",
+		Buckets: []float64{0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1},
+	})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "route-requests")
+	outputTopic := getEnv("OUTPUT_TOPIC", "route-updates")
+	groupID := getEnv("GROUP_ID", "routing-service")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	grpcAddr := getEnv("GRPC_ADDR", ":50054")
+	pbfFile := os.Getenv("OSM_PBF_FILE")
+	if pbfFile == "" {
+		slog.Error("OSM_PBF_FILE must name an OpenStreetMap PBF extract")
+		os.Exit(1)
+	}
+
+	// Requests without a destination are routed to the nearest of these.
+	var destinations []routing.Destination
+	if path := os.Getenv("ROUTE_DESTINATIONS_FILE"); path != "" {
+		var err error
+		destinations, err = routing.LoadDestinations(path)
+		if err != nil {
+			slog.Error("Failed to load destinations", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Loaded destinations", "file", path, "count", len(destinations))
+	}
+
+	start := time.Now()
+	graph, err := roadgraph.Load(pbfFile)
+	if err != nil {
+		slog.Error("Failed to load road graph", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Loaded road graph", "file", pbfFile, "nodes", len(graph.Nodes), "edges", len(graph.Edges), "duration", time.Since(start))
+	router := routing.NewRouter(graph)
+
+	producer, err := kafka.NewProducer(kafka.Config{
+		Brokers: kafkaBrokers,
+		Topic:   outputTopic,
+		Mode:    kafka.ModeIdempotent,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka producer", "error", err)
+		os.Exit(1)
+	}
+	defer producer.Close()
+
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers: kafkaBrokers,
+		GroupID: groupID,
+		Topics:  []string{inputTopic},
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		slog.Error("Failed to listen", "error", err)
+		os.Exit(1)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			logging.StreamServerInterceptor(),
+			metrics.StreamServerInterceptor(),
+		),
+	)
+	pb.RegisterMatrixServiceServer(s, routing.NewMatrixServer(router))
+	pb.RegisterIsochroneServiceServer(s, routing.NewIsochroneServer(router))
+	pb.RegisterPlanningServiceServer(s, vrp.NewServer(func(ctx context.Context, points []geo.Point) ([][]float64, [][]float64, error) {
+		durations := make([][]float64, len(points))
+		distances := make([][]float64, len(points))
+		err := router.MatrixRows(ctx, points, points, func(i int, dur, dist []float64) error {
+			durations[i], distances[i] = dur, dist
+			return nil
+		})
+		return durations, distances, err
+	}))
+	reflection.Register(s)
+	go func() {
+		slog.Info("Routing API listening", "addr", grpcAddr)
+		if err := s.Serve(lis); err != nil {
+			slog.Error("Failed to serve", "error", err)
+		}
+	}()
+	defer s.GracefulStop()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	slog.Info("Routing engine consuming", "topic", inputTopic, "output", outputTopic)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		var req routing.RouteRequest
+		if err := json.Unmarshal(rec.Value, &req); err != nil {
+			return fmt.Errorf("invalid route request: %w", err)
+		}
+
+		start := time.Now()
+		update := router.Handle(req, destinations)
+		routeSeconds.Observe(time.Since(start).Seconds())
+		routeRequests.WithLabelValues(update.Status).Inc()
+		if update.Error != "" {
+			slog.WarnContext(ctx, "No route", "vehicle_id", req.VehicleID, "error", update.Error)
+		}
+
+		msg := kafka.Message{Key: req.VehicleID, Value: update}
+		if err := producer.ProduceBatch(ctx, []kafka.Message{msg}); err != nil {
+			return fmt.Errorf("failed to publish route update: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/latest"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/metrics"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/nexus-logistics/ingestion-service/internal/spatial"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var indexUpdates = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "spatial_updates_total",
+	Help: "Pings processed, by result: applied, or stale when older than the vehicle's indexed position",
+}, []string{"result"})
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	grpcAddr := getEnv("GRPC_ADDR", ":50055")
+	// Every replica indexes the whole fleet, so each needs its own group.
+	hostname, _ := os.Hostname()
+	groupID := getEnv("GROUP_ID", "spatial-service-"+hostname)
+	ttl := latest.DefaultTTL
+	if v := os.Getenv("VEHICLE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			slog.Error("Invalid configuration", "key", "VEHICLE_TTL", "value", v)
+			os.Exit(1)
+		}
+		ttl = d
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	index := spatial.NewIndex()
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "spatial_indexed_vehicles",
+		Help: "Number of vehicles in the spatial index",
+	}, func() float64 { return float64(index.Len()) })
+
+	// Start from pings published since just before the latest positions
+	// are loaded, so none published while loading are missed.
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers: kafkaBrokers,
+		GroupID: groupID,
+		Topics:  []string{inputTopic},
+		StartAt: time.Now(),
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	if host := os.Getenv("REDIS_HOST"); host != "" {
+		n, err := bootstrap(ctx, index, net.JoinHostPort(host, getEnv("REDIS_PORT", "6379")), ttl)
+		if err != nil {
+			slog.Error("Failed to load latest positions", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Loaded latest positions", "vehicles", n)
+	} else {
+		slog.Info("REDIS_HOST not set, index fills as pings arrive")
+	}
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		slog.Error("Failed to listen", "error", err)
+		os.Exit(1)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterSpatialIndexServiceServer(s, spatial.NewServer(index))
+	reflection.Register(s)
+	go func() {
+		slog.Info("Spatial index API listening", "addr", grpcAddr)
+		if err := s.Serve(lis); err != nil {
+			slog.Error("Failed to serve", "error", err)
+		}
+	}()
+	defer s.GracefulStop()
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				index.Evict(time.Now().Add(-ttl).Unix())
+			}
+		}
+	}()
+
+	slog.Info("Spatial index consuming", "topic", inputTopic, "group", groupID)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		var ping service.PingPayload
+		if err := json.Unmarshal(rec.Value, &ping); err != nil {
+			return fmt.Errorf("invalid ping payload: %w", err)
+		}
+		if ping.Anomaly != "" {
+			return nil
+		}
+		result := "applied"
+		if !index.Update(ping) {
+			result = "stale"
+		}
+		indexUpdates.WithLabelValues(result).Inc()
+		return nil
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+// bootstrap fills the index from the latest positions kept in Redis by the
+// latest-position writer.
+func bootstrap(ctx context.Context, index *spatial.Index, addr string, ttl time.Duration) (int, error) {
+	rdb := redis.NewClient(&redis.Options{Addr: addr, Password: os.Getenv("REDIS_PASSWORD")})
+	defer rdb.Close()
+	pings, err := latest.NewRedisStore(rdb, ttl).Active(ctx, time.Now().Add(-ttl).Unix(), 0)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range pings {
+		index.Update(p)
+	}
+	return len(pings), nil
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
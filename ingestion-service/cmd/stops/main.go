@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/nexus-logistics/ingestion-service/internal/stops"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	stopEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stops_events_total",
+		Help: "Stop events produced, by type",
+	}, []string{"type"})
+	stopDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "stops_duration_seconds",
+		Help:    "Duration of completed stops",
+		Buckets: []float64{120, 300, 600, 1800, 3600, 3 * 3600, 8 * 3600, 24 * 3600},
+	})
+	latePings = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stops_late_pings_total",
+		Help: "Pings dropped because they arrived after the reorder window",
+	})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	outputTopic := getEnv("OUTPUT_TOPIC", "vehicle-stops")
+	groupID := getEnv("GROUP_ID", "stops-service")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	// Vehicles silent for longer than this lose their state.
+	stateTTL := 7 * 24 * time.Hour
+
+	cfg := stops.DefaultConfig()
+	cfg.RadiusMeters = envFloat("STOP_RADIUS_METERS", cfg.RadiusMeters)
+	cfg.MaxSpeedKPH = envFloat("STOP_MAX_SPEED_KPH", cfg.MaxSpeedKPH)
+	cfg.JitterMeters = envFloat("STOP_JITTER_METERS", cfg.JitterMeters)
+	cfg.MinDuration = envDuration("STOP_MIN_DURATION", cfg.MinDuration)
+	cfg.ReorderWindow = envDuration("REORDER_WINDOW", cfg.ReorderWindow)
+	detector := stops.NewDetector(cfg)
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "stops_tracked_vehicles",
+		Help: "Number of vehicles with stop detection state",
+	}, func() float64 { return float64(detector.Vehicles()) })
+
+	producer, err := kafka.NewProducer(kafka.Config{
+		Brokers: kafkaBrokers,
+		Topic:   outputTopic,
+		Mode:    kafka.ModeIdempotent,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka producer", "error", err)
+		os.Exit(1)
+	}
+	defer producer.Close()
+
+	// Offsets are stored by hand, no further than the oldest ping still in
+	// the reorder window, so pings buffered at a crash are read again.
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers:       kafkaBrokers,
+		GroupID:       groupID,
+		Topics:        []string{inputTopic},
+		ManualOffsets: true,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// mu makes computing a transition, producing its events and applying
+	// it one step, so the flush loop and the consumer cannot reorder a
+	// vehicle's stop_started and stop_ended events. held tracks the pings
+	// in the reorder window.
+	var mu sync.Mutex
+	held := kafka.NewHeld()
+	publish := func(ctx context.Context, t *stops.Transition) error {
+		msgs := make([]kafka.Message, len(t.Events))
+		for i, ev := range t.Events {
+			msgs[i] = kafka.Message{Key: ev.VehicleID, Value: ev}
+		}
+		// The consumer skips records whose handler fails, so the publish
+		// is retried here; the state only changes once it succeeds.
+		backoff := 100 * time.Millisecond
+		for len(msgs) > 0 {
+			err := producer.ProduceBatch(ctx, msgs)
+			if err == nil {
+				break
+			}
+			slog.ErrorContext(ctx, "Failed to publish stop events, retrying", "error", err, "backoff", backoff)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("failed to publish stop events: %w", err)
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, 30*time.Second)
+		}
+		detector.Apply(t)
+		for _, pos := range t.Released {
+			held.Release(pos)
+		}
+		for _, ev := range t.Events {
+			stopEvents.WithLabelValues(string(ev.Type)).Inc()
+			if ev.Type == stops.EventStopEnded {
+				stopDuration.Observe(float64(ev.DurationSeconds))
+			}
+		}
+		return nil
+	}
+
+	go func() {
+		flush := time.NewTicker(5 * time.Second)
+		defer flush.Stop()
+		commit := time.NewTicker(time.Second)
+		defer commit.Stop()
+		evict := time.NewTicker(time.Hour)
+		defer evict.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-flush.C:
+				mu.Lock()
+				if err := publish(ctx, detector.Flush(now)); err != nil {
+					slog.Error("Failed to flush stop events", "error", err)
+				}
+				mu.Unlock()
+			case <-commit.C:
+				if err := consumer.StoreOffsets(held.Offsets()); err != nil {
+					slog.Warn("Failed to store offsets", "error", err)
+				}
+			case <-evict.C:
+				mu.Lock()
+				n := detector.Evict(time.Now().Add(-stateTTL).Unix())
+				mu.Unlock()
+				if n > 0 {
+					slog.Info("Evicted idle vehicle state", "vehicles", n)
+				}
+			}
+		}
+	}()
+
+	slog.Info("Stop detector consuming", "topic", inputTopic, "output", outputTopic,
+		"radius_meters", cfg.RadiusMeters, "min_duration", cfg.MinDuration, "reorder_window", cfg.ReorderWindow)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		pos := rec.Position()
+		defer held.Seen(pos)
+		var ping service.PingPayload
+		if err := json.Unmarshal(rec.Value, &ping); err != nil {
+			return fmt.Errorf("invalid ping payload: %w", err)
+		}
+		// Pings flagged by ingestion anomaly detection would split stops.
+		if ping.Anomaly != "" {
+			return nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		held.Hold(pos)
+		t, ok := detector.Add(ping.VehicleID, ping.Tenant, geo.Point{Lat: ping.Latitude, Lon: ping.Longitude}, ping.Timestamp, pos, time.Now())
+		if !ok {
+			held.Release(pos)
+			latePings.Inc()
+			return nil
+		}
+		return publish(ctx, t)
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+	if err := consumer.StoreOffsets(held.Offsets()); err != nil {
+		slog.Warn("Failed to store offsets", "error", err)
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envFloat(key string, def float64) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		slog.Error("Invalid configuration", "key", key, "error", err)
+		os.Exit(1)
+	}
+	return f
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		slog.Error("Invalid configuration", "key", key, "error", err)
+		os.Exit(1)
+	}
+	return d
+}
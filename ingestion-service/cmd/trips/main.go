@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/metrics"
+	"github.com/nexus-logistics/ingestion-service/internal/postgres"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/nexus-logistics/ingestion-service/internal/trips"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var (
+	tripsCompleted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "trips_completed_total",
+		Help: "Trips completed, by end reason",
+	}, []string{"reason"})
+	tripDistance = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "trips_distance_meters",
+		Help:    "Distance of completed trips",
+		Buckets: prometheus.ExponentialBuckets(500, 2, 10),
+	})
+	outOfOrderPings = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "trips_out_of_order_pings_total",
+		Help: "Pings ignored because they were older than the vehicle's last ping",
+	})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	outputTopic := getEnv("OUTPUT_TOPIC", "trips")
+	groupID := getEnv("GROUP_ID", "trips-service")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	grpcAddr := getEnv("GRPC_ADDR", ":50053")
+	// Vehicles silent for longer than this lose their state.
+	stateTTL := 7 * 24 * time.Hour
+
+	cfg := trips.DefaultConfig()
+	cfg.LongStop = envDuration("TRIP_LONG_STOP", cfg.LongStop)
+	cfg.MaxGap = envDuration("TRIP_MAX_GAP", cfg.MaxGap)
+	segmenter := trips.NewSegmenter(cfg)
+
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "trips_open",
+		Help: "Number of trips in progress",
+	}, func() float64 { return float64(segmenter.OpenTrips()) })
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "trips_tracked_vehicles",
+		Help: "Number of vehicles with trip segmentation state",
+	}, func() float64 { return float64(segmenter.Vehicles()) })
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Trips are stored in Postgres when it is configured; otherwise only
+	// the last trips per vehicle since startup can be queried.
+	var store trips.Store = trips.NewMemoryStore(100)
+	if os.Getenv("POSTGRES_HOST") != "" {
+		db, err := postgres.Open(ctx, postgres.DSNFromEnv())
+		if err != nil {
+			slog.Error("Failed to connect to Postgres", "error", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+		store = trips.NewPostgresStore(db)
+	}
+
+	producer, err := kafka.NewProducer(kafka.Config{
+		Brokers: kafkaBrokers,
+		Topic:   outputTopic,
+		Mode:    kafka.ModeIdempotent,
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka producer", "error", err)
+		os.Exit(1)
+	}
+	defer producer.Close()
+
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers: kafkaBrokers,
+		GroupID: groupID,
+		Topics:  []string{inputTopic},
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		slog.Error("Failed to listen", "error", err)
+		os.Exit(1)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+		),
+	)
+	pb.RegisterTripServiceServer(s, trips.NewServer(store))
+	reflection.Register(s)
+	go func() {
+		slog.Info("Trip API listening", "addr", grpcAddr)
+		if err := s.Serve(lis); err != nil {
+			slog.Error("Failed to serve", "error", err)
+		}
+	}()
+	defer s.GracefulStop()
+
+	// mu makes computing a transition, saving its trips and applying it
+	// one step, so the expiry loop and the consumer cannot both end a
+	// vehicle's trip.
+	var mu sync.Mutex
+	publish := func(ctx context.Context, t *trips.Transition) error {
+		msgs := make([]kafka.Message, len(t.Trips))
+		for i, trip := range t.Trips {
+			msgs[i] = kafka.Message{Key: trip.VehicleID, Value: trip}
+		}
+		// The consumer skips records whose handler fails, so saving is
+		// retried here; the state only changes once every trip is
+		// published and stored. A retry does not publish again trips
+		// that only failed to store.
+		published, stored := len(msgs) == 0, 0
+		save := func() error {
+			if !published {
+				if err := producer.ProduceBatch(ctx, msgs); err != nil {
+					return fmt.Errorf("failed to publish trips: %w", err)
+				}
+				published = true
+			}
+			for ; stored < len(t.Trips); stored++ {
+				if err := store.Add(ctx, t.Trips[stored]); err != nil {
+					return fmt.Errorf("failed to store trip %s: %w", t.Trips[stored].TripID, err)
+				}
+			}
+			return nil
+		}
+		backoff := 100 * time.Millisecond
+		for {
+			err := save()
+			if err == nil {
+				break
+			}
+			slog.ErrorContext(ctx, "Failed to save completed trips, retrying", "error", err, "backoff", backoff)
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, 30*time.Second)
+		}
+		segmenter.Apply(t)
+		for _, trip := range t.Trips {
+			tripsCompleted.WithLabelValues(string(trip.EndReason)).Inc()
+			tripDistance.Observe(trip.DistanceMeters)
+		}
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				mu.Lock()
+				if err := publish(ctx, segmenter.Expire(now, now.Add(-stateTTL).Unix())); err != nil {
+					slog.Error("Failed to publish expired trips", "error", err)
+				}
+				mu.Unlock()
+			}
+		}
+	}()
+
+	slog.Info("Trip segmenter consuming", "topic", inputTopic, "output", outputTopic)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		var ping service.PingPayload
+		if err := json.Unmarshal(rec.Value, &ping); err != nil {
+			return fmt.Errorf("invalid ping payload: %w", err)
+		}
+		// Pings flagged by ingestion anomaly detection would distort
+		// distances and speeds.
+		if ping.Anomaly != "" {
+			return nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		t, ok := segmenter.Add(ping.VehicleID, ping.Tenant, geo.Point{Lat: ping.Latitude, Lon: ping.Longitude}, ping.Timestamp, ping.Attributes, rec.Partition, time.Now())
+		if !ok {
+			outOfOrderPings.Inc()
+			return nil
+		}
+		return publish(ctx, t)
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		slog.Error("Invalid configuration", "key", key, "error", err)
+		os.Exit(1)
+	}
+	return d
+}
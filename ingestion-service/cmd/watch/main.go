@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/metrics"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/nexus-logistics/ingestion-service/internal/watch"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// shutdownGrace is how long open streams get to finish on shutdown before
+// they are cut off.
+const shutdownGrace = 5 * time.Second
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	grpcAddr := getEnv("GRPC_ADDR", ":50056")
+	// Every replica serves the whole fleet, so each needs its own group.
+	hostname, _ := os.Hostname()
+	groupID := getEnv("GROUP_ID", "watch-service-"+hostname)
+	policy, err := watch.ParsePolicy(getEnv("SLOW_CONSUMER_POLICY", "drop"))
+	if err != nil {
+		slog.Error("Invalid configuration", "key", "SLOW_CONSUMER_POLICY", "error", err)
+		os.Exit(1)
+	}
+	hub := watch.NewHub(watch.Config{
+		BufferSize: envInt("WATCH_BUFFER_SIZE", 256),
+		Retain:     envInt("WATCH_RETAIN", 10_000),
+		Policy:     policy,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers: kafkaBrokers,
+		GroupID: groupID,
+		Topics:  []string{inputTopic},
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	lis, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		slog.Error("Failed to listen", "error", err)
+		os.Exit(1)
+	}
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			logging.StreamServerInterceptor(),
+			metrics.StreamServerInterceptor(),
+		),
+	)
+	pb.RegisterVehicleWatchServiceServer(s, watch.NewServer(hub))
+	reflection.Register(s)
+	go func() {
+		slog.Info("Watch API listening", "addr", grpcAddr)
+		if err := s.Serve(lis); err != nil {
+			slog.Error("Failed to serve", "error", err)
+		}
+	}()
+	// Watch streams only end when clients cancel, so a graceful stop is
+	// given a deadline.
+	defer func() {
+		t := time.AfterFunc(shutdownGrace, s.Stop)
+		defer t.Stop()
+		s.GracefulStop()
+	}()
+
+	slog.Info("Watch service consuming", "topic", inputTopic, "group", groupID)
+	err = consumer.Run(ctx, func(ctx context.Context, rec kafka.Record) error {
+		var ping service.PingPayload
+		if err := json.Unmarshal(rec.Value, &ping); err != nil {
+			return fmt.Errorf("invalid ping payload: %w", err)
+		}
+		if ping.Anomaly != "" {
+			return nil
+		}
+		hub.Publish(watch.Update{PingPayload: ping, Partition: rec.Partition, Offset: rec.Offset})
+		return nil
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		slog.Error("Invalid configuration", "key", key, "value", v)
+		os.Exit(1)
+	}
+	return n
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/locations"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/postgres"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	rowsWritten = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "writer_rows_written_total",
+		Help: "Location rows committed to PostgreSQL",
+	})
+	recordsSkipped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "writer_records_skipped_total",
+		Help: "Records not written, by reason",
+	}, []string{"reason"})
+	batchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "writer_batch_size",
+		Help:    "Rows per COPY batch",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	})
+	batchSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "writer_batch_seconds",
+		Help:    "Time to COPY and commit one batch",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	})
+	pingAge = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "writer_ping_age_seconds",
+		Help:    "Time from a ping's timestamp until its row is committed",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300},
+	})
+)
+
+func main() {
+	logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
+	// Configuration
+	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
+	inputTopic := getEnv("INPUT_TOPIC", "vehicle-locations")
+	groupID := getEnv("GROUP_ID", "location-writer")
+	metricsAddr := getEnv("METRICS_ADDR", ":9090")
+	batch := kafka.BatchConfig{MaxSize: 5000, MaxWait: 500 * time.Millisecond}
+	if v := os.Getenv("BATCH_SIZE"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			slog.Error("Invalid configuration", "key", "BATCH_SIZE", "value", v)
+			os.Exit(1)
+		}
+		batch.MaxSize = n
+	}
+	if v := os.Getenv("BATCH_WAIT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			slog.Error("Invalid configuration", "key", "BATCH_WAIT", "value", v)
+			os.Exit(1)
+		}
+		batch.MaxWait = d
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := postgres.Open(ctx, postgres.DSNFromEnv())
+	if err != nil {
+		slog.Error("Failed to connect to PostgreSQL", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+	writer := locations.NewWriter(db)
+
+	// A new group starts from the beginning of the topic so no history is
+	// lost when the writer is first deployed.
+	consumer, err := kafka.NewConsumer(kafka.ConsumerConfig{
+		Brokers:     kafkaBrokers,
+		GroupID:     groupID,
+		Topics:      []string{inputTopic},
+		OffsetReset: "earliest",
+	})
+	if err != nil {
+		slog.Error("Failed to initialize Kafka consumer", "error", err)
+		os.Exit(1)
+	}
+	defer consumer.Close()
+
+	// Start Metrics Server (Prometheus)
+	go func() {
+		http.Handle("/metrics", promhttp.Handler())
+		slog.Info("Metrics server listening", "addr", metricsAddr)
+		if err := http.ListenAndServe(metricsAddr, nil); err != nil {
+			slog.Error("Failed to start metrics server", "error", err)
+		}
+	}()
+
+	slog.Info("Location writer consuming", "topic", inputTopic, "batch_size", batch.MaxSize, "batch_wait", batch.MaxWait)
+	err = consumer.RunBatch(ctx, batch, func(ctx context.Context, recs []kafka.Record) error {
+		pings := make([]service.PingPayload, 0, len(recs))
+		for _, rec := range recs {
+			var ping service.PingPayload
+			if err := json.Unmarshal(rec.Value, &ping); err != nil || !locations.Valid(ping) {
+				recordsSkipped.WithLabelValues("invalid").Inc()
+				continue
+			}
+			if ping.Anomaly != "" {
+				recordsSkipped.WithLabelValues("anomaly").Inc()
+				continue
+			}
+			pings = append(pings, ping)
+		}
+		if len(pings) == 0 {
+			return nil
+		}
+
+		start := time.Now()
+		rejected, err := writer.Write(ctx, pings)
+		if err != nil {
+			return err
+		}
+		if rejected > 0 {
+			recordsSkipped.WithLabelValues("rejected").Add(float64(rejected))
+			slog.WarnContext(ctx, "Database rejected rows, skipped them", "rows", rejected)
+		}
+		batchSeconds.Observe(time.Since(start).Seconds())
+		batchSize.Observe(float64(len(pings)))
+		rowsWritten.Add(float64(len(pings) - rejected))
+		now := time.Now().Unix()
+		for _, p := range pings {
+			pingAge.Observe(float64(now - p.Timestamp))
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("Consumer stopped", "error", err)
+		os.Exit(1)
+	}
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,294 @@
+// Package anomaly flags pings that cannot be real vehicle movement: jumps
+// implying impossible speeds, null-island fixes and receivers stuck on one
+// coordinate.
+package anomaly
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// Reason explains why a ping was considered suspicious.
+type Reason string
+
+const (
+	ReasonNone                Reason = ""
+	ReasonInvalidCoordinates  Reason = "invalid_coordinates"
+	ReasonNullIsland          Reason = "null_island"
+	ReasonImpossibleSpeed     Reason = "impossible_speed"
+	ReasonRepeatedCoordinates Reason = "repeated_coordinates"
+)
+
+// Action is what the ingestion service does with suspicious pings.
+type Action string
+
+const (
+	// ActionQuarantine sends suspicious pings only to the quarantine topic.
+	ActionQuarantine Action = "quarantine"
+	// ActionFlag routes suspicious pings normally with the reason attached.
+	ActionFlag Action = "flag"
+)
+
+const (
+	defaultQuarantineTopic = "vehicle-locations-quarantine"
+	defaultMaxSpeedKPH     = 250
+	defaultMaxRepeats      = 30
+
+	// jitterMeters is never treated as movement, so GPS noise between pings
+	// a second apart cannot trip the speed check.
+	jitterMeters = 50
+	// rebaseAfter consecutive speed rejections that agree with each other
+	// are accepted as the new position. This recovers when the previously
+	// accepted fix was the bad one.
+	rebaseAfter = 3
+	// nullIslandDegrees is how close to (0,0) a fix must be to count as a
+	// receiver reporting no position.
+	nullIslandDegrees = 1e-4
+
+	shards = 64
+)
+
+// Config is the JSON anomaly configuration. Vehicles get the limits of the
+// class named by their vehicle_class attribute, else of the first class
+// with a matching vehicle prefix, else the defaults.
+//
+//	{
+//	  "action": "quarantine",
+//	  "quarantine_topic": "vehicle-locations-quarantine",
+//	  "default_max_speed_kph": 200,
+//	  "max_repeats": 30,
+//	  "classes": [
+//	    {"name": "van", "vehicle_prefixes": ["van-"], "max_speed_kph": 160},
+//	    {"name": "aircraft", "max_speed_kph": 1000}
+//	  ]
+//	}
+type Config struct {
+	Action             Action  `json:"action"`
+	QuarantineTopic    string  `json:"quarantine_topic"`
+	DefaultMaxSpeedKPH float64 `json:"default_max_speed_kph"`
+	// MaxRepeats is how many consecutive pings with exactly the same
+	// coordinates are accepted. A parked vehicle still shows GPS noise, so
+	// identical fixes usually mean a stuck receiver or a replayed position.
+	// A negative value disables the check.
+	MaxRepeats int     `json:"max_repeats"`
+	Classes    []Class `json:"classes"`
+}
+
+type Class struct {
+	Name            string   `json:"name"`
+	VehiclePrefixes []string `json:"vehicle_prefixes,omitempty"`
+	MaxSpeedKPH     float64  `json:"max_speed_kph"`
+}
+
+// ClassAttribute is the ping attribute naming the vehicle class.
+const ClassAttribute = "vehicle_class"
+
+// Detector keeps the last accepted position of every vehicle. It is safe
+// for concurrent use. State is per process, so with several ingestion
+// replicas a vehicle is only checked against the pings each replica saw.
+type Detector struct {
+	cfg    Config
+	shards [shards]shard
+}
+
+type shard struct {
+	mu       sync.Mutex
+	vehicles map[string]*vehicleState
+}
+
+type vehicleState struct {
+	last     geo.Point
+	lastTS   int64
+	repeats  int
+	lastSeen int64
+	// rejected is the last ping rejected for speed, and agreeing how many
+	// consecutive rejected pings were consistent with each other.
+	rejected   geo.Point
+	rejectedTS int64
+	agreeing   int
+}
+
+func NewDetector(cfg Config) (*Detector, error) {
+	switch cfg.Action {
+	case "":
+		cfg.Action = ActionQuarantine
+	case ActionQuarantine, ActionFlag:
+	default:
+		return nil, fmt.Errorf("unknown anomaly action %q", cfg.Action)
+	}
+	if cfg.QuarantineTopic == "" {
+		cfg.QuarantineTopic = defaultQuarantineTopic
+	}
+	if cfg.DefaultMaxSpeedKPH == 0 {
+		cfg.DefaultMaxSpeedKPH = defaultMaxSpeedKPH
+	}
+	if cfg.MaxRepeats == 0 {
+		cfg.MaxRepeats = defaultMaxRepeats
+	}
+	for _, c := range cfg.Classes {
+		if c.Name == "" || c.MaxSpeedKPH <= 0 {
+			return nil, fmt.Errorf("vehicle class %q needs a name and a positive max_speed_kph", c.Name)
+		}
+	}
+	d := &Detector{cfg: cfg}
+	for i := range d.shards {
+		d.shards[i].vehicles = make(map[string]*vehicleState)
+	}
+	return d, nil
+}
+
+// Load reads a JSON Config from path.
+func Load(path string) (*Detector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read anomaly config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse anomaly config: %w", err)
+	}
+	return NewDetector(cfg)
+}
+
+func (d *Detector) Action() Action          { return d.cfg.Action }
+func (d *Detector) QuarantineTopic() string { return d.cfg.QuarantineTopic }
+
+// maxSpeed returns the speed limit in m/s for a vehicle.
+func (d *Detector) maxSpeed(vehicleID string, attrs map[string]string) float64 {
+	kph := d.cfg.DefaultMaxSpeedKPH
+	if name, ok := attrs[ClassAttribute]; ok {
+		for _, c := range d.cfg.Classes {
+			if c.Name == name {
+				return c.MaxSpeedKPH / 3.6
+			}
+		}
+	}
+	for _, c := range d.cfg.Classes {
+		for _, prefix := range c.VehiclePrefixes {
+			if strings.HasPrefix(vehicleID, prefix) {
+				return c.MaxSpeedKPH / 3.6
+			}
+		}
+	}
+	return kph / 3.6
+}
+
+// Check evaluates a ping and records it as the vehicle's last position when
+// it is not suspicious. key identifies the vehicle across tenants.
+func (d *Detector) Check(key, vehicleID string, p geo.Point, ts int64, attrs map[string]string) Reason {
+	if !p.Valid() {
+		return ReasonInvalidCoordinates
+	}
+	if math.Abs(p.Lat) < nullIslandDegrees && math.Abs(p.Lon) < nullIslandDegrees {
+		return ReasonNullIsland
+	}
+
+	s := &d.shards[shardOf(key)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.vehicles[key]
+	if !ok {
+		s.vehicles[key] = &vehicleState{last: p, lastTS: ts, lastSeen: ts}
+		return ReasonNone
+	}
+	if ts > st.lastSeen {
+		st.lastSeen = ts
+	}
+
+	if p == st.last {
+		// A retransmission of the same fix is not a repeat.
+		if ts == st.lastTS {
+			return ReasonNone
+		}
+		st.repeats++
+		if d.cfg.MaxRepeats > 0 && st.repeats > d.cfg.MaxRepeats {
+			return ReasonRepeatedCoordinates
+		}
+		if ts > st.lastTS {
+			st.lastTS = ts
+		}
+		return ReasonNone
+	}
+
+	limit := d.maxSpeed(vehicleID, attrs)
+	if !plausible(st.last, st.lastTS, p, ts, limit) {
+		if st.agreeing > 0 && plausible(st.rejected, st.rejectedTS, p, ts, limit) {
+			st.agreeing++
+		} else {
+			st.agreeing = 1
+		}
+		st.rejected, st.rejectedTS = p, ts
+		if st.agreeing < rebaseAfter {
+			return ReasonImpossibleSpeed
+		}
+	}
+
+	// Out-of-order pings are checked but do not move the baseline back.
+	if ts >= st.lastTS {
+		st.last, st.lastTS = p, ts
+		st.repeats = 0
+	}
+	st.agreeing = 0
+	return ReasonNone
+}
+
+// plausible reports whether moving from a to b between the two timestamps
+// stays within maxSpeed (m/s).
+func plausible(a geo.Point, aTS int64, b geo.Point, bTS int64, maxSpeed float64) bool {
+	dist := geo.Distance(a, b)
+	if dist <= jitterMeters {
+		return true
+	}
+	dt := bTS - aTS
+	if dt < 0 {
+		dt = -dt
+	}
+	if dt == 0 {
+		dt = 1
+	}
+	return (dist-jitterMeters)/float64(dt) <= maxSpeed
+}
+
+// Evict forgets vehicles whose newest ping is older than before (Unix
+// seconds) and returns how many were removed.
+func (d *Detector) Evict(before int64) int {
+	n := 0
+	for i := range d.shards {
+		s := &d.shards[i]
+		s.mu.Lock()
+		for key, st := range s.vehicles {
+			if st.lastSeen < before {
+				delete(s.vehicles, key)
+				n++
+			}
+		}
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// Vehicles returns the number of vehicles with tracked state.
+func (d *Detector) Vehicles() int {
+	n := 0
+	for i := range d.shards {
+		s := &d.shards[i]
+		s.mu.Lock()
+		n += len(s.vehicles)
+		s.mu.Unlock()
+	}
+	return n
+}
+
+func shardOf(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32() % shards
+}
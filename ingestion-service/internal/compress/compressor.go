@@ -0,0 +1,259 @@
+// Package compress thins vehicle trajectories before they are stored. It
+// drops pings that lie, within a tolerance, where the vehicle would be if
+// it had moved in a straight line at constant speed between the pings kept
+// around them, so straight-line driving costs a few rows instead of one
+// per second.
+package compress
+
+import (
+	"maps"
+	"sort"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+// maxBuffer bounds the pings held per vehicle, and so the work per ping,
+// when MaxIntervalSeconds is disabled.
+const maxBuffer = 3600
+
+// Kept is a ping that passed compression.
+type Kept struct {
+	service.PingPayload
+	// Headers are those of the record the ping came in, such as trace
+	// context, and Pos its position.
+	Headers map[string]string
+	Pos     kafka.Position
+	Class   string
+	// Dropped is how many pings before this one were dropped, and
+	// Deviation the largest distance in meters between one of them and
+	// the kept trajectory.
+	Dropped   int
+	Deviation float64
+}
+
+// ClassReport summarises compression for a vehicle class since start.
+type ClassReport struct {
+	Class string
+	In    int64
+	Out   int64
+	// MaxDeviation is the largest error introduced, in meters.
+	MaxDeviation float64
+}
+
+// Ratio is how many pings came in per ping kept.
+func (r ClassReport) Ratio() float64 {
+	if r.Out == 0 {
+		return 0
+	}
+	return float64(r.In) / float64(r.Out)
+}
+
+// Compressor is an online opening-window compressor using the
+// time-synchronised distance: a ping is dropped while every ping since the
+// last kept one is within tolerance of the position interpolated by time
+// on the line from that ping to the newest. When that fails, the previous
+// ping closes the segment and is kept. Each vehicle therefore holds back at
+// most the pings since its last kept one.
+//
+// It is not safe for concurrent use.
+type Compressor struct {
+	cfg      Config
+	vehicles map[string]*track
+	reports  map[string]*ClassReport
+}
+
+type track struct {
+	class     string
+	tolerance float64
+	anchor    entry
+	// buf holds the pings after anchor; all but the last are droppable
+	// given the segment from anchor to the last, with the largest error
+	// deviation.
+	buf       []entry
+	deviation float64
+}
+
+// entry is a ping and the headers and position of its record.
+type entry struct {
+	service.PingPayload
+	headers map[string]string
+	pos     kafka.Position
+}
+
+func (e entry) kept(class string) Kept {
+	return Kept{PingPayload: e.PingPayload, Headers: e.headers, Pos: e.pos, Class: class}
+}
+
+func New(cfg Config) (*Compressor, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &Compressor{
+		cfg:      cfg,
+		vehicles: make(map[string]*track),
+		reports:  make(map[string]*ClassReport),
+	}, nil
+}
+
+// Add takes the next ping of the vehicle identified by key, with the
+// headers and position of its record, and returns the pings, if any, that
+// are now known to be kept.
+func (c *Compressor) Add(key string, p service.PingPayload, headers map[string]string, pos kafka.Position) []Kept {
+	e := entry{PingPayload: p, headers: headers, pos: pos}
+	t, ok := c.vehicles[key]
+	if !ok {
+		class, tol := c.cfg.class(p.VehicleID, p.Attributes)
+		t = &track{class: class, tolerance: tol}
+		c.vehicles[key] = t
+		c.report(t.class).In++
+		return c.emit(nil, t.restart(e))
+	}
+	c.report(t.class).In++
+
+	last := t.last()
+	switch {
+	case p.Timestamp == last.Timestamp:
+		// A retransmission adds nothing.
+		return nil
+	case p.Timestamp < last.Timestamp:
+		// Late pings are passed through rather than rewriting the
+		// trajectory already emitted.
+		return c.emit(nil, e.kept(t.class))
+	}
+
+	var out []Kept
+	if !maps.Equal(p.Attributes, last.Attributes) {
+		// Attribute changes, such as ignition or status, are kept exactly.
+		if k, ok := t.close(); ok {
+			out = c.emit(out, k)
+		}
+		return c.emit(out, t.restart(e))
+	}
+
+	if dev := t.deviationTo(p); dev > t.tolerance {
+		if k, ok := t.close(); ok {
+			out = c.emit(out, k)
+		}
+		t.buf = append(t.buf, e)
+	} else {
+		t.buf = append(t.buf, e)
+		t.deviation = dev
+	}
+	interval := *c.cfg.MaxIntervalSeconds
+	if (interval > 0 && p.Timestamp-t.anchor.Timestamp >= interval) || len(t.buf) >= maxBuffer {
+		if k, ok := t.close(); ok {
+			out = c.emit(out, k)
+		}
+	}
+	return out
+}
+
+// Flush ends the trajectories of vehicles without a ping since before
+// (Unix seconds), returning their last pings, and forgets them.
+func (c *Compressor) Flush(before int64) []Kept {
+	var out []Kept
+	for key, t := range c.vehicles {
+		if t.last().Timestamp < before {
+			if k, ok := t.close(); ok {
+				out = c.emit(out, k)
+			}
+			delete(c.vehicles, key)
+		}
+	}
+	return out
+}
+
+// FlushAll ends every trajectory, as on shutdown.
+func (c *Compressor) FlushAll() []Kept {
+	var out []Kept
+	for key, t := range c.vehicles {
+		if k, ok := t.close(); ok {
+			out = c.emit(out, k)
+		}
+		delete(c.vehicles, key)
+	}
+	return out
+}
+
+// Buffered calls fn with the record position of every ping held back. Until
+// they are emitted, their offsets must not be committed: pings lost with
+// the compressor's state would never reach storage.
+func (c *Compressor) Buffered(fn func(kafka.Position)) {
+	for _, t := range c.vehicles {
+		for _, e := range t.buf {
+			fn(e.pos)
+		}
+	}
+}
+
+// Vehicles returns the number of vehicles with an open trajectory.
+func (c *Compressor) Vehicles() int {
+	return len(c.vehicles)
+}
+
+// Report returns per-class totals ordered by class name.
+func (c *Compressor) Report() []ClassReport {
+	out := make([]ClassReport, 0, len(c.reports))
+	for _, r := range c.reports {
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Class < out[j].Class })
+	return out
+}
+
+func (c *Compressor) report(class string) *ClassReport {
+	r, ok := c.reports[class]
+	if !ok {
+		r = &ClassReport{Class: class}
+		c.reports[class] = r
+	}
+	return r
+}
+
+func (c *Compressor) emit(out []Kept, k Kept) []Kept {
+	r := c.report(k.Class)
+	r.Out++
+	r.MaxDeviation = max(r.MaxDeviation, k.Deviation)
+	return append(out, k)
+}
+
+func (t *track) last() entry {
+	if len(t.buf) > 0 {
+		return t.buf[len(t.buf)-1]
+	}
+	return t.anchor
+}
+
+// restart makes p the start of a new segment.
+func (t *track) restart(e entry) Kept {
+	t.anchor, t.buf, t.deviation = e, t.buf[:0], 0
+	return e.kept(t.class)
+}
+
+// close keeps the last buffered ping, dropping the others.
+func (t *track) close() (Kept, bool) {
+	if len(t.buf) == 0 {
+		return Kept{}, false
+	}
+	last := t.last()
+	k := last.kept(t.class)
+	k.Dropped, k.Deviation = len(t.buf)-1, t.deviation
+	t.anchor, t.buf, t.deviation = last, t.buf[:0], 0
+	return k, true
+}
+
+// deviationTo returns the largest time-synchronised distance of the
+// buffered pings from the segment between anchor and p.
+func (t *track) deviationTo(p service.PingPayload) float64 {
+	a := geo.Point{Lat: t.anchor.Latitude, Lon: t.anchor.Longitude}
+	b := geo.Point{Lat: p.Latitude, Lon: p.Longitude}
+	span := float64(p.Timestamp - t.anchor.Timestamp)
+	worst := 0.0
+	for _, q := range t.buf {
+		at := geo.Interpolate(a, b, float64(q.Timestamp-t.anchor.Timestamp)/span)
+		worst = max(worst, geo.Distance(geo.Point{Lat: q.Latitude, Lon: q.Longitude}, at))
+	}
+	return worst
+}
@@ -0,0 +1,99 @@
+package compress
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/anomaly"
+)
+
+const (
+	defaultToleranceMeters = 10
+	defaultMaxInterval     = 5 * time.Minute
+	// defaultClass names vehicles that match no configured class in
+	// reports.
+	defaultClass = "default"
+)
+
+// Config is the JSON compression configuration. Vehicles get the tolerance
+// of the class named by their vehicle_class attribute, else of the first
+// class with a matching vehicle prefix, else the default.
+//
+//	{
+//	  "default_tolerance_meters": 10,
+//	  "max_interval_seconds": 300,
+//	  "classes": [
+//	    {"name": "van", "vehicle_prefixes": ["van-"], "tolerance_meters": 15},
+//	    {"name": "aircraft", "tolerance_meters": 200}
+//	  ]
+//	}
+type Config struct {
+	// DefaultToleranceMeters is the largest distance between a dropped
+	// ping and where the kept trajectory places the vehicle at that time.
+	DefaultToleranceMeters float64 `json:"default_tolerance_meters"`
+	// MaxIntervalSeconds keeps at least one ping per interval while a
+	// vehicle is reporting, however straight its path. 0 disables it; when
+	// omitted it is 300.
+	MaxIntervalSeconds *int64  `json:"max_interval_seconds"`
+	Classes            []Class `json:"classes"`
+}
+
+type Class struct {
+	Name            string   `json:"name"`
+	VehiclePrefixes []string `json:"vehicle_prefixes,omitempty"`
+	ToleranceMeters float64  `json:"tolerance_meters"`
+}
+
+func (cfg *Config) validate() error {
+	if cfg.DefaultToleranceMeters == 0 {
+		cfg.DefaultToleranceMeters = defaultToleranceMeters
+	}
+	if cfg.MaxIntervalSeconds == nil {
+		interval := int64(defaultMaxInterval / time.Second)
+		cfg.MaxIntervalSeconds = &interval
+	}
+	if cfg.DefaultToleranceMeters < 0 || *cfg.MaxIntervalSeconds < 0 {
+		return fmt.Errorf("default_tolerance_meters and max_interval_seconds must not be negative")
+	}
+	for _, c := range cfg.Classes {
+		if c.Name == "" || c.Name == defaultClass || c.ToleranceMeters <= 0 {
+			return fmt.Errorf("vehicle class %q needs a name other than %q and a positive tolerance_meters", c.Name, defaultClass)
+		}
+	}
+	return nil
+}
+
+// Load reads a JSON Config from path.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read compression config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse compression config: %w", err)
+	}
+	return cfg, nil
+}
+
+// class returns a vehicle's class name and tolerance in meters.
+func (cfg *Config) class(vehicleID string, attrs map[string]string) (string, float64) {
+	if name, ok := attrs[anomaly.ClassAttribute]; ok {
+		for _, c := range cfg.Classes {
+			if c.Name == name {
+				return c.Name, c.ToleranceMeters
+			}
+		}
+	}
+	for _, c := range cfg.Classes {
+		for _, prefix := range c.VehiclePrefixes {
+			if strings.HasPrefix(vehicleID, prefix) {
+				return c.Name, c.ToleranceMeters
+			}
+		}
+	}
+	return defaultClass, cfg.DefaultToleranceMeters
+}
@@ -0,0 +1,293 @@
+package eta
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/mapmatch"
+	"github.com/nexus-logistics/ingestion-service/internal/routing"
+)
+
+const (
+	StatusEnRoute = "EN_ROUTE"
+	StatusArrived = "ARRIVED"
+	StatusNoRoute = "NO_ROUTE"
+)
+
+// z80 is the normal quantile for an 80% two-sided confidence interval.
+const z80 = 1.2816
+
+// ETA is published to the vehicle-etas topic.
+type ETA struct {
+	VehicleID       string    `json:"vehicle_id"`
+	Tenant          string    `json:"tenant,omitempty"`
+	Status          string    `json:"status"`
+	NextStop        string    `json:"next_stop,omitempty"`
+	Destination     geo.Point `json:"destination"`
+	RemainingMeters float64   `json:"remaining_meters"`
+	ETASeconds      int64     `json:"eta_seconds"`
+	// ETALowSeconds and ETAHighSeconds bound the 80% confidence interval.
+	ETALowSeconds  int64 `json:"eta_low_seconds"`
+	ETAHighSeconds int64 `json:"eta_high_seconds"`
+	// ArrivalTime is the predicted arrival, or the actual one once
+	// arrived, in Unix seconds.
+	ArrivalTime int64 `json:"arrival_time"`
+	// PredictedArrival is set on arrival to the last arrival time that
+	// was predicted en route, to measure accuracy.
+	PredictedArrival int64 `json:"predicted_arrival,omitempty"`
+	// ComputedAt is the timestamp of the ping the ETA is based on.
+	ComputedAt int64 `json:"computed_at"`
+}
+
+// Config controls when ETAs are recomputed and re-emitted.
+type Config struct {
+	// MinChangeSeconds and MinChangeRatio: an ETA is re-emitted only when
+	// the predicted arrival moves by more than the larger of the two,
+	// the ratio applying to the remaining time.
+	MinChangeSeconds float64
+	MinChangeRatio   float64
+	// MinInterval is the least time between route computations for one
+	// vehicle, in ping time. Speeds are still learned from every ping.
+	MinInterval time.Duration
+	// ArrivalRadiusMeters is how close to the stop counts as arrived.
+	ArrivalRadiusMeters float64
+	// MaxSampleGap is the longest gap between pings used to learn speeds.
+	MaxSampleGap time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MinChangeSeconds:    60,
+		MinChangeRatio:      0.1,
+		MinInterval:         15 * time.Second,
+		ArrivalRadiusMeters: 75,
+		MaxSampleGap:        2 * time.Minute,
+	}
+}
+
+// Predictor keeps each vehicle's next stop and last emitted ETA, learns
+// segment speeds from its pings and predicts its arrival. It is safe for
+// concurrent use.
+type Predictor struct {
+	router  *routing.Router
+	speeds  *SpeedModel
+	matcher *mapmatch.Matcher
+	cfg     Config
+
+	mu       sync.Mutex
+	vehicles map[string]*vehicle
+}
+
+type vehicle struct {
+	// lastSeen is the latest ping or route update time, for eviction.
+	lastSeen int64
+	// pingTS is the latest ping time.
+	pingTS int64
+	// session matches the vehicle's pings; matchTS holds the times of the
+	// pings whose matches it has not yet settled.
+	session *mapmatch.Session
+	matchTS []int64
+	// The previous settled match, for speed samples.
+	prev   mapmatch.Match
+	prevTS int64
+
+	stop     *stop
+	computed int64
+	last     *ETA
+}
+
+type stop struct {
+	name  string
+	point geo.Point
+}
+
+func NewPredictor(router *routing.Router, speeds *SpeedModel, matcher *mapmatch.Matcher, cfg Config) *Predictor {
+	return &Predictor{
+		router:   router,
+		speeds:   speeds,
+		matcher:  matcher,
+		cfg:      cfg,
+		vehicles: make(map[string]*vehicle),
+	}
+}
+
+func (p *Predictor) vehicle(id string) *vehicle {
+	v, ok := p.vehicles[id]
+	if !ok {
+		v = &vehicle{session: p.matcher.NewSession()}
+		p.vehicles[id] = v
+	}
+	return v
+}
+
+// SetStop records a vehicle's next stop from a route update. Updates
+// without a destination, such as NO_ROUTE or the Java route service's, are
+// ignored and the current stop kept.
+func (p *Predictor) SetStop(u routing.RouteUpdate) {
+	if u.Status != routing.StatusOptimized || u.Destination == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	v := p.vehicle(u.VehicleID)
+	s := &stop{name: u.NextStop, point: *u.Destination}
+	if v.stop == nil || *v.stop != *s {
+		v.stop = s
+		v.computed = 0
+	}
+	v.lastSeen = max(v.lastSeen, u.ComputedAt)
+}
+
+// Update processes a ping. It learns speeds from the pings whose matches it
+// settles and, when the vehicle has a next stop, predicts its arrival. The
+// ETA is returned with true when it should be published: the first for a
+// stop, on arrival, or when the predicted arrival has moved by more than
+// the configured threshold. The vehicle's last ETA and stop are left as
+// they were until Published is called, so an ETA that fails to publish is
+// returned again by a later ping.
+func (p *Predictor) Update(vehicleID, tenant string, pos geo.Point, ts int64) (ETA, bool) {
+	p.mu.Lock()
+	v := p.vehicle(vehicleID)
+	if ts < v.pingTS {
+		p.mu.Unlock()
+		return ETA{}, false
+	}
+	v.pingTS = ts
+	v.matchTS = append(v.matchTS, ts)
+	for _, m := range v.session.Step(pos, ts) {
+		p.learn(v, m, v.matchTS[0])
+		v.matchTS = v.matchTS[1:]
+	}
+	v.lastSeen = max(v.lastSeen, ts)
+	if v.stop == nil || (v.computed != 0 && ts-v.computed < int64(p.cfg.MinInterval.Seconds())) {
+		p.mu.Unlock()
+		return ETA{}, false
+	}
+	s := *v.stop
+	v.computed = ts
+	p.mu.Unlock()
+
+	eta := p.predict(vehicleID, tenant, s, pos, ts)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if v.stop == nil || *v.stop != s {
+		// The stop changed while routing.
+		return ETA{}, false
+	}
+	if !p.changed(v.last, &eta) {
+		return ETA{}, false
+	}
+	if eta.Status == StatusArrived && v.last != nil && v.last.Status == StatusEnRoute {
+		eta.PredictedArrival = v.last.ArrivalTime
+	}
+	return eta, true
+}
+
+// Published records an ETA returned by Update as sent: later ETAs are
+// compared with it, and on arrival the stop is done with. An ETA for a
+// stop that has since changed is ignored.
+func (p *Predictor) Published(eta ETA) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	v := p.vehicle(eta.VehicleID)
+	if v.stop == nil || v.stop.name != eta.NextStop || v.stop.point != eta.Destination {
+		return
+	}
+	v.last = &eta
+	if eta.Status == StatusArrived {
+		v.stop = nil
+	}
+}
+
+// learn records the speed between two consecutive matched pings on the same
+// or adjacent edges.
+func (p *Predictor) learn(v *vehicle, m mapmatch.Match, ts int64) {
+	prev, prevTS := v.prev, v.prevTS
+	v.prev, v.prevTS = m, ts
+	dt := ts - prevTS
+	if !m.Matched || !prev.Matched || dt <= 0 || dt > int64(p.cfg.MaxSampleGap.Seconds()) {
+		return
+	}
+	g := p.router.Graph()
+	if prev.Edge != m.Edge && g.Edges[prev.Edge].To != g.Edges[m.Edge].From {
+		return
+	}
+	speed := geo.Distance(prev.Point, m.Point) / float64(dt)
+	// Standing still, at lights or a delivery, says nothing about the
+	// road, and jumps faster than any vehicle are matching errors.
+	if speed < 1 || speed > 60 {
+		return
+	}
+	p.speeds.Observe(m.Edge, time.Unix(ts, 0).Hour(), speed)
+}
+
+func (p *Predictor) predict(vehicleID, tenant string, s stop, pos geo.Point, ts int64) ETA {
+	eta := ETA{
+		VehicleID:   vehicleID,
+		Tenant:      tenant,
+		NextStop:    s.name,
+		Destination: s.point,
+		ComputedAt:  ts,
+	}
+	if geo.Distance(pos, s.point) <= p.cfg.ArrivalRadiusMeters {
+		eta.Status = StatusArrived
+		eta.ArrivalTime = ts
+		return eta
+	}
+	route, err := p.router.Route(pos, s.point)
+	if err != nil {
+		eta.Status = StatusNoRoute
+		return eta
+	}
+
+	// Edge times are strongly correlated, since congestion affects the
+	// whole trip, so their deviations add up rather than averaging out.
+	hour := time.Unix(ts, 0).Hour()
+	var mean, std float64
+	for i, edge := range route.Edges {
+		meters := route.Fractions[i] * p.router.Graph().Edges[edge].Length
+		m, sd := p.speeds.TravelTime(edge, hour, meters)
+		mean += m
+		std += sd
+	}
+	eta.Status = StatusEnRoute
+	eta.RemainingMeters = math.Round(route.DistanceMeters)
+	eta.ETASeconds = int64(math.Round(mean))
+	eta.ETALowSeconds = int64(math.Round(math.Max(0, mean-z80*std)))
+	eta.ETAHighSeconds = int64(math.Round(mean + z80*std))
+	eta.ArrivalTime = ts + eta.ETASeconds
+	return eta
+}
+
+func (p *Predictor) changed(last, eta *ETA) bool {
+	if last == nil || last.Status != eta.Status || last.NextStop != eta.NextStop || last.Destination != eta.Destination {
+		return true
+	}
+	threshold := math.Max(p.cfg.MinChangeSeconds, p.cfg.MinChangeRatio*float64(eta.ETASeconds))
+	return math.Abs(float64(eta.ArrivalTime-last.ArrivalTime)) > threshold
+}
+
+// Evict forgets vehicles not seen since before (Unix seconds) and returns
+// how many were removed.
+func (p *Predictor) Evict(before int64) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for id, v := range p.vehicles {
+		if v.lastSeen < before {
+			delete(p.vehicles, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Vehicles returns the number of tracked vehicles.
+func (p *Predictor) Vehicles() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.vehicles)
+}
@@ -0,0 +1,174 @@
+// Package eta predicts arrival times at each vehicle's next stop from the
+// remaining road route and segment speeds learned from live pings.
+package eta
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+
+	"github.com/nexus-logistics/ingestion-service/internal/roadgraph"
+)
+
+const (
+	// allHours is the bucket that aggregates every hour of the day.
+	allHours = 24
+	// minAlpha is the weight of a new sample once a bucket has enough
+	// history, so speeds follow gradual changes such as roadworks.
+	minAlpha = 0.05
+	// priorWeight is how many samples the free-flow speed counts for when
+	// blended with learned speeds.
+	priorWeight = 5.0
+	// priorCV is the assumed spread of travel times on roads without
+	// history, as a fraction of the mean.
+	priorCV = 0.3
+)
+
+// paceStat tracks the exponentially weighted mean and variance of the
+// pace (seconds per meter) observed on one edge. Pace, unlike speed,
+// averages correctly over distance.
+type paceStat struct {
+	Mean float64 `json:"mean"`
+	Var  float64 `json:"var"`
+	N    int     `json:"n"`
+}
+
+func (s *paceStat) add(x float64) {
+	s.N++
+	alpha := math.Max(1/float64(s.N), minAlpha)
+	diff := x - s.Mean
+	s.Mean += alpha * diff
+	s.Var = (1 - alpha) * (s.Var + alpha*diff*diff)
+}
+
+// SpeedModel learns travel speeds per directed edge and hour of day. It is
+// safe for concurrent use.
+type SpeedModel struct {
+	g *roadgraph.Graph
+
+	mu    sync.RWMutex
+	stats map[int32]*[allHours + 1]paceStat
+}
+
+func NewSpeedModel(g *roadgraph.Graph) *SpeedModel {
+	return &SpeedModel{g: g, stats: make(map[int32]*[allHours + 1]paceStat)}
+}
+
+// Observe records a speed in meters per second measured on an edge during
+// the given hour of day.
+func (m *SpeedModel) Observe(edge int32, hour int, speed float64) {
+	if speed <= 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st, ok := m.stats[edge]
+	if !ok {
+		st = new([allHours + 1]paceStat)
+		m.stats[edge] = st
+	}
+	st[hour].add(1 / speed)
+	st[allHours].add(1 / speed)
+}
+
+// TravelTime returns the expected time and its standard deviation, both in
+// seconds, to drive meters along an edge starting in the given hour. The
+// learned pace for that hour, or for the whole day when the hour has too
+// little history, is blended with the free-flow speed by sample count.
+func (m *SpeedModel) TravelTime(edge int32, hour int, meters float64) (float64, float64) {
+	e := &m.g.Edges[edge]
+	mean := 1 / (e.SpeedKPH / 3.6)
+	variance := (priorCV * mean) * (priorCV * mean)
+
+	m.mu.RLock()
+	if st, ok := m.stats[edge]; ok {
+		s := st[hour]
+		if s.N < 3 {
+			s = st[allHours]
+		}
+		w := float64(s.N) / (float64(s.N) + priorWeight)
+		mean = w*s.Mean + (1-w)*mean
+		variance = w*s.Var + (1-w)*variance
+	}
+	m.mu.RUnlock()
+	return meters * mean, meters * math.Sqrt(variance)
+}
+
+// Segments returns the number of edges with learned speeds.
+func (m *SpeedModel) Segments() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.stats)
+}
+
+// snapshotEntry is one edge in a saved model. Edges are identified by
+// segment and direction, which survive rebuilding the graph from the same
+// extract.
+type snapshotEntry struct {
+	Segment string                 `json:"segment"`
+	Forward bool                   `json:"forward"`
+	Hours   [allHours + 1]paceStat `json:"hours"`
+}
+
+// Save writes the learned speeds to path, replacing it atomically.
+func (m *SpeedModel) Save(path string) error {
+	m.mu.RLock()
+	entries := make([]snapshotEntry, 0, len(m.stats))
+	for edge, st := range m.stats {
+		e := &m.g.Edges[edge]
+		entries = append(entries, snapshotEntry{Segment: e.SegmentID(), Forward: e.Forward, Hours: *st})
+	}
+	m.mu.RUnlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write speed snapshot: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load restores speeds saved by Save, skipping segments the graph no
+// longer has. A missing file is not an error.
+func (m *SpeedModel) Load(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read speed snapshot: %w", err)
+	}
+	var entries []snapshotEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return 0, fmt.Errorf("failed to parse speed snapshot: %w", err)
+	}
+
+	type key struct {
+		segment string
+		forward bool
+	}
+	index := make(map[key]int32, len(m.g.Edges))
+	for i := range m.g.Edges {
+		e := &m.g.Edges[i]
+		index[key{e.SegmentID(), e.Forward}] = int32(i)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, en := range entries {
+		edge, ok := index[key{en.Segment, en.Forward}]
+		if !ok {
+			continue
+		}
+		hours := en.Hours
+		m.stats[edge] = &hours
+		n++
+	}
+	return n, nil
+}
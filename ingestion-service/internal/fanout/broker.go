@@ -0,0 +1,197 @@
+// Package fanout pushes live vehicle positions to browser clients over
+// WebSocket and Server-Sent Events. Pings are coalesced per vehicle and
+// sent to each client once per tick, limited to the client's viewport.
+package fanout
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/nexus-logistics/ingestion-service/internal/spatial"
+)
+
+var (
+	connections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fanout_connections",
+		Help: "Open client connections, by transport",
+	}, []string{"transport"})
+	rejectedConnections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fanout_rejected_connections_total",
+		Help: "Connections refused, by reason",
+	}, []string{"reason"})
+	messagesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fanout_messages_sent_total",
+		Help: "Messages written to clients, by transport",
+	}, []string{"transport"})
+	bytesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fanout_bytes_sent_total",
+		Help: "Message bytes written to clients, by transport",
+	}, []string{"transport"})
+	sendSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "fanout_send_seconds",
+		Help:    "Time to write one message to a client, by transport",
+		Buckets: []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+	}, []string{"transport"})
+	slowDisconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "fanout_slow_disconnects_total",
+		Help: "Clients disconnected after a write timed out, by transport",
+	}, []string{"transport"})
+	coalescedUpdates = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "fanout_coalesced_updates_total",
+		Help: "Vehicle updates replaced by a newer one before being sent",
+	})
+	backlogVehicles = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "fanout_backlog_vehicles",
+		Help: "Vehicle updates queued for clients but not yet written, after the last tick",
+	})
+	tickSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "fanout_tick_seconds",
+		Help:    "Time to distribute one tick of updates to every client",
+		Buckets: []float64{0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+	})
+)
+
+// Position is a vehicle as sent to clients.
+type Position struct {
+	VehicleID  string            `json:"vehicle_id"`
+	Tenant     string            `json:"tenant,omitempty"`
+	Latitude   float64           `json:"latitude"`
+	Longitude  float64           `json:"longitude"`
+	Timestamp  int64             `json:"timestamp"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// entry is a position marshalled once and shared by every client it is
+// sent to.
+type entry struct {
+	ping service.PingPayload
+	raw  json.RawMessage
+}
+
+func newEntry(p service.PingPayload) entry {
+	raw, _ := json.Marshal(Position{
+		VehicleID:  p.VehicleID,
+		Tenant:     p.Tenant,
+		Latitude:   p.Latitude,
+		Longitude:  p.Longitude,
+		Timestamp:  p.Timestamp,
+		Attributes: p.Attributes,
+	})
+	return entry{ping: p, raw: raw}
+}
+
+// Broker holds the latest position of every vehicle and distributes
+// changes to clients once per tick. It is safe for concurrent use.
+type Broker struct {
+	tick  time.Duration
+	index *spatial.Index
+
+	mu      sync.Mutex
+	pending map[string]service.PingPayload
+
+	clientsMu sync.RWMutex
+	clients   map[*Client]struct{}
+}
+
+func NewBroker(tick time.Duration) *Broker {
+	return &Broker{
+		tick:    tick,
+		index:   spatial.NewIndex(),
+		pending: make(map[string]service.PingPayload),
+		clients: make(map[*Client]struct{}),
+	}
+}
+
+// Publish records a ping for the next tick. Pings older than the vehicle's
+// known position are ignored, and a newer ping in the same tick replaces
+// an earlier one.
+func (b *Broker) Publish(p service.PingPayload) {
+	if !b.index.Update(p) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.pending[p.VehicleID]; ok {
+		coalescedUpdates.Inc()
+	}
+	b.pending[p.VehicleID] = p
+}
+
+// Evict forgets vehicles whose last ping is older than before (Unix
+// seconds), so new clients are not sent them.
+func (b *Broker) Evict(before int64) int {
+	return b.index.Evict(before)
+}
+
+// Vehicles returns the number of vehicles with a known position.
+func (b *Broker) Vehicles() int {
+	return b.index.Len()
+}
+
+// Run distributes pending updates every tick until ctx is cancelled.
+func (b *Broker) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.flush()
+		}
+	}
+}
+
+func (b *Broker) flush() {
+	b.mu.Lock()
+	pending := b.pending
+	if len(pending) == 0 {
+		b.mu.Unlock()
+		return
+	}
+	b.pending = make(map[string]service.PingPayload, len(pending))
+	b.mu.Unlock()
+
+	start := time.Now()
+	entries := make([]entry, 0, len(pending))
+	for _, p := range pending {
+		entries = append(entries, newEntry(p))
+	}
+	backlog := 0
+	b.clientsMu.RLock()
+	for c := range b.clients {
+		backlog += c.offer(entries)
+	}
+	b.clientsMu.RUnlock()
+	backlogVehicles.Set(float64(backlog))
+	tickSeconds.Observe(time.Since(start).Seconds())
+}
+
+func (b *Broker) register(c *Client) {
+	b.clientsMu.Lock()
+	defer b.clientsMu.Unlock()
+	b.clients[c] = struct{}{}
+	connections.WithLabelValues(c.transport).Inc()
+}
+
+func (b *Broker) unregister(c *Client) {
+	b.clientsMu.Lock()
+	defer b.clientsMu.Unlock()
+	if _, ok := b.clients[c]; ok {
+		delete(b.clients, c)
+		connections.WithLabelValues(c.transport).Dec()
+	}
+}
+
+// Clients returns the number of connected clients.
+func (b *Broker) Clients() int {
+	b.clientsMu.RLock()
+	defer b.clientsMu.RUnlock()
+	return len(b.clients)
+}
@@ -0,0 +1,202 @@
+package fanout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/spatial"
+)
+
+// world is the viewport of a client that has not set one.
+var world = geo.BBox{MinLat: -90, MinLon: -180, MaxLat: 90, MaxLon: 180}
+
+// View is the part of the fleet a client is shown.
+type View struct {
+	Bounds geo.BBox `json:"bounds"`
+	Tenant string   `json:"tenant,omitempty"`
+}
+
+func (v *View) match(e *entry) bool {
+	if v.Tenant != "" && e.ping.Tenant != v.Tenant {
+		return false
+	}
+	return v.Bounds.Contains(geo.Point{Lat: e.ping.Latitude, Lon: e.ping.Longitude})
+}
+
+// Message is what clients receive: vehicles to add or move, and vehicles
+// that left the view and should be removed.
+type Message struct {
+	Vehicles []json.RawMessage `json:"vehicles"`
+	Removed  []string          `json:"removed,omitempty"`
+}
+
+// Client is one connection's view of the fleet. Updates not yet written
+// are held per vehicle, so a slow client receives fewer, coalesced
+// messages rather than a growing backlog.
+type Client struct {
+	broker    *Broker
+	transport string
+	wake      chan struct{}
+
+	mu   sync.Mutex
+	view View
+	// inside holds the vehicles the client was last told are in view.
+	inside  map[string]bool
+	pending map[string]json.RawMessage
+	removed map[string]bool
+}
+
+// Connect registers a client showing view and queues the vehicles already
+// in it.
+func (b *Broker) Connect(transport string, view View) *Client {
+	c := &Client{
+		broker:    b,
+		transport: transport,
+		wake:      make(chan struct{}, 1),
+		inside:    make(map[string]bool),
+		pending:   make(map[string]json.RawMessage),
+		removed:   make(map[string]bool),
+		view:      view,
+	}
+	// Registering before taking the snapshot means no tick falls between
+	// the two.
+	b.register(c)
+	c.SetView(view)
+	return c
+}
+
+// Close unregisters the client.
+func (c *Client) Close() {
+	c.broker.unregister(c)
+}
+
+// SetView changes the client's view. Vehicles in the new view are queued
+// and those only in the old one are queued for removal.
+func (c *Client) SetView(view View) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.view = view
+	// The index is read under the client lock so a tick cannot queue a
+	// newer position that this snapshot then overwrites.
+	results := c.broker.index.InBox(view.Bounds, 0, spatial.Filter{Tenant: view.Tenant})
+	inside := make(map[string]bool, len(results))
+	clear(c.pending)
+	for _, r := range results {
+		inside[r.VehicleID] = true
+		c.pending[r.VehicleID] = newEntry(r.PingPayload).raw
+		delete(c.removed, r.VehicleID)
+	}
+	for id := range c.inside {
+		if !inside[id] {
+			c.removed[id] = true
+		}
+	}
+	c.inside = inside
+	c.signal()
+}
+
+// offer queues the entries in the client's view and returns how many
+// vehicles are queued.
+func (c *Client) offer(entries []entry) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	changed := false
+	for i := range entries {
+		e := &entries[i]
+		id := e.ping.VehicleID
+		switch {
+		case c.view.match(e):
+			if _, ok := c.pending[id]; ok {
+				coalescedUpdates.Inc()
+			}
+			c.pending[id] = e.raw
+			c.inside[id] = true
+			delete(c.removed, id)
+			changed = true
+		case c.inside[id]:
+			delete(c.inside, id)
+			delete(c.pending, id)
+			c.removed[id] = true
+			changed = true
+		}
+	}
+	if changed {
+		c.signal()
+	}
+	return len(c.pending) + len(c.removed)
+}
+
+func (c *Client) signal() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// take returns the queued changes and clears them.
+func (c *Client) take() (Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.pending) == 0 && len(c.removed) == 0 {
+		return Message{}, false
+	}
+	msg := Message{Vehicles: make([]json.RawMessage, 0, len(c.pending))}
+	for id, raw := range c.pending {
+		msg.Vehicles = append(msg.Vehicles, raw)
+		delete(c.pending, id)
+	}
+	for id := range c.removed {
+		msg.Removed = append(msg.Removed, id)
+		delete(c.removed, id)
+	}
+	return msg, true
+}
+
+// serve writes queued changes with send until ctx is cancelled or a write
+// fails. keepalive is called when nothing has been sent for idle, to stop
+// proxies closing the connection.
+func (c *Client) serve(ctx context.Context, idle time.Duration, send func([]byte) error, keepalive func() error) error {
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+			if err := keepalive(); err != nil {
+				return c.writeFailed(err)
+			}
+			timer.Reset(idle)
+			continue
+		case <-c.wake:
+		}
+		msg, ok := c.take()
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		start := time.Now()
+		if err := send(data); err != nil {
+			return c.writeFailed(err)
+		}
+		sendSeconds.WithLabelValues(c.transport).Observe(time.Since(start).Seconds())
+		messagesSent.WithLabelValues(c.transport).Inc()
+		bytesSent.WithLabelValues(c.transport).Add(float64(len(data)))
+		timer.Reset(idle)
+	}
+}
+
+func (c *Client) writeFailed(err error) error {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		slowDisconnects.WithLabelValues(c.transport).Inc()
+	}
+	return err
+}
@@ -0,0 +1,186 @@
+package fanout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// maxViewMessageBytes bounds the messages clients send.
+const maxViewMessageBytes = 4096
+
+// HandlerConfig configures the client endpoints.
+type HandlerConfig struct {
+	// MaxConnections caps open connections across both transports.
+	MaxConnections int
+	// WriteTimeout disconnects clients that cannot take a message in time.
+	WriteTimeout time.Duration
+	// KeepAlive is how long a connection may go without a write before a
+	// keepalive is sent.
+	KeepAlive time.Duration
+}
+
+// Handler returns the client endpoints:
+//
+//	GET /ws   WebSocket. Clients send {"bounds": {...}, "tenant": "..."}
+//	          at any time to change their view.
+//	GET /sse  Server-Sent Events. The view is set with the min_lat,
+//	          min_lon, max_lat, max_lon and tenant query parameters.
+//
+// Without bounds a client is shown the whole fleet. Every message is a
+// JSON Message.
+func (b *Broker) Handler(cfg HandlerConfig) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /ws", websocket.Server{
+		// Browsers enforce nothing for WebSockets, so the origin check
+		// only rejects non-browser clients; the feed is public like the
+		// polling API it replaces.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			b.serveWebSocket(conn, cfg)
+		},
+	})
+	mux.HandleFunc("GET /sse", func(w http.ResponseWriter, r *http.Request) {
+		b.serveSSE(w, r, cfg)
+	})
+	return b.limit(cfg.MaxConnections, mux)
+}
+
+// limit refuses connections beyond max.
+func (b *Broker) limit(max int, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if max > 0 && b.Clients() >= max {
+			rejectedConnections.WithLabelValues("capacity").Inc()
+			http.Error(w, "too many connections", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (b *Broker) serveWebSocket(conn *websocket.Conn, cfg HandlerConfig) {
+	const transport = "websocket"
+	defer conn.Close()
+	conn.MaxPayloadBytes = maxViewMessageBytes
+	ctx, cancel := context.WithCancel(conn.Request().Context())
+	defer cancel()
+
+	c := b.Connect(transport, View{Bounds: world})
+	defer c.Close()
+
+	// Reads only carry view changes; a failed read means the client went
+	// away.
+	go func() {
+		defer cancel()
+		for {
+			var data string
+			if err := websocket.Message.Receive(conn, &data); err != nil {
+				return
+			}
+			var view View
+			if err := json.Unmarshal([]byte(data), &view); err != nil {
+				slog.DebugContext(ctx, "Ignoring invalid view", "error", err)
+				continue
+			}
+			if view.Bounds == (geo.BBox{}) {
+				view.Bounds = world
+			}
+			if err := validBounds(view.Bounds); err != nil {
+				slog.DebugContext(ctx, "Ignoring invalid view", "error", err)
+				continue
+			}
+			c.SetView(view)
+		}
+	}()
+
+	write := func(data []byte) error {
+		conn.SetWriteDeadline(time.Now().Add(cfg.WriteTimeout))
+		return websocket.Message.Send(conn, string(data))
+	}
+	keepalive := func() error {
+		conn.SetWriteDeadline(time.Now().Add(cfg.WriteTimeout))
+		return websocket.Message.Send(conn, `{"vehicles":[]}`)
+	}
+	if err := c.serve(ctx, cfg.KeepAlive, write, keepalive); err != nil {
+		slog.DebugContext(ctx, "WebSocket client disconnected", "error", err)
+	}
+}
+
+func (b *Broker) serveSSE(w http.ResponseWriter, r *http.Request, cfg HandlerConfig) {
+	const transport = "sse"
+	view, err := viewFromQuery(r)
+	if err != nil {
+		rejectedConnections.WithLabelValues("invalid_view").Inc()
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Stops nginx buffering the stream.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	c := b.Connect(transport, view)
+	defer c.Close()
+
+	flush := func(format string, args ...any) error {
+		rc.SetWriteDeadline(time.Now().Add(cfg.WriteTimeout))
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	write := func(data []byte) error {
+		return flush("data: %s\n\n", data)
+	}
+	keepalive := func() error {
+		return flush(": keepalive\n\n")
+	}
+	if err := c.serve(r.Context(), cfg.KeepAlive, write, keepalive); err != nil {
+		slog.DebugContext(r.Context(), "SSE client disconnected", "error", err)
+	}
+}
+
+func viewFromQuery(r *http.Request) (View, error) {
+	q := r.URL.Query()
+	view := View{Bounds: world, Tenant: q.Get("tenant")}
+	if q.Get("min_lat") == "" && q.Get("min_lon") == "" && q.Get("max_lat") == "" && q.Get("max_lon") == "" {
+		return view, nil
+	}
+	for _, f := range []struct {
+		key string
+		dst *float64
+	}{
+		{"min_lat", &view.Bounds.MinLat},
+		{"min_lon", &view.Bounds.MinLon},
+		{"max_lat", &view.Bounds.MaxLat},
+		{"max_lon", &view.Bounds.MaxLon},
+	} {
+		v, err := strconv.ParseFloat(q.Get(f.key), 64)
+		if err != nil {
+			return view, fmt.Errorf("invalid %s", f.key)
+		}
+		*f.dst = v
+	}
+	return view, validBounds(view.Bounds)
+}
+
+func validBounds(b geo.BBox) error {
+	lo, hi := geo.Point{Lat: b.MinLat, Lon: b.MinLon}, geo.Point{Lat: b.MaxLat, Lon: b.MaxLon}
+	if !lo.Valid() || !hi.Valid() {
+		return fmt.Errorf("bounds need valid coordinates")
+	}
+	if lo.Lat > hi.Lat || lo.Lon > hi.Lon {
+		return fmt.Errorf("bounds min must be south-west of max")
+	}
+	return nil
+}
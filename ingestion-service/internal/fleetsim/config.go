@@ -0,0 +1,174 @@
+package fleetsim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config is the JSON simulator configuration. Omitted values take the
+// defaults shown, and without profiles the fleet is the one shown.
+//
+//	{
+//	  "ping_interval_seconds": 5,
+//	  "gps_noise_meters": 5,
+//	  "trip_km": {"min": 2, "max": 20},
+//	  "dropouts": {"per_hour": 1, "seconds": {"min": 10, "max": 120}, "store_and_forward": false},
+//	  "profiles": [
+//	    {"name": "vehicle", "share": 1, "speed_factor": 0.9, "cruise_kph": 50,
+//	     "max_speed_kph": 110, "accel_mps2": 1.5, "stops_per_km": 0.5,
+//	     "stop_seconds": {"min": 5, "max": 45}, "dwell_seconds": {"min": 60, "max": 300}}
+//	  ]
+//	}
+type Config struct {
+	// PingIntervalSeconds is how often a vehicle with signal reports. Each
+	// interval is jittered by up to 10%.
+	PingIntervalSeconds float64 `json:"ping_interval_seconds"`
+	// GPSNoiseMeters is the standard deviation of the error added to each
+	// reported position, north and east. 0 reports exact positions; when
+	// omitted it is 5.
+	GPSNoiseMeters *float64 `json:"gps_noise_meters"`
+	// TripKM bounds the straight-line length of trips planned on the road
+	// graph.
+	TripKM   Range     `json:"trip_km"`
+	Dropouts Dropouts  `json:"dropouts"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// Range is an interval sampled uniformly.
+type Range struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Dropouts are periods without signal, during which no pings are sent.
+type Dropouts struct {
+	// PerHour is the average number of dropouts per vehicle-hour. 0 turns
+	// dropouts off.
+	PerHour *float64 `json:"per_hour"`
+	Seconds Range    `json:"seconds"`
+	// StoreAndForward sends the pings recorded during a dropout when the
+	// signal returns, as buffering trackers do, instead of losing them.
+	StoreAndForward bool `json:"store_and_forward"`
+}
+
+// Profile describes how one class of vehicle drives. Its name is sent as
+// the vehicle_class attribute and prefixes its vehicles' IDs.
+type Profile struct {
+	Name string `json:"name"`
+	// Share is the profile's weight in the fleet mix.
+	Share float64 `json:"share"`
+	// SpeedFactor scales road speed limits, and CruiseKPH is the speed on
+	// paths without limits, such as GPX tracks. MaxSpeedKPH caps both; 0
+	// leaves them uncapped.
+	SpeedFactor float64  `json:"speed_factor"`
+	CruiseKPH   float64  `json:"cruise_kph"`
+	MaxSpeedKPH *float64 `json:"max_speed_kph"`
+	// AccelMPS2 limits acceleration; braking is half as hard again.
+	AccelMPS2 float64 `json:"accel_mps2"`
+	// StopsPerKM places brief stops, such as at lights, along each trip. 0
+	// drives each trip without stopping.
+	StopsPerKM  *float64 `json:"stops_per_km"`
+	StopSeconds Range    `json:"stop_seconds"`
+	// DwellSeconds is the time spent at each trip's destination.
+	DwellSeconds Range `json:"dwell_seconds"`
+}
+
+var defaultProfile = Profile{Name: "vehicle"}
+
+func (cfg *Config) validate() error {
+	if cfg.PingIntervalSeconds == 0 {
+		cfg.PingIntervalSeconds = 5
+	}
+	if cfg.GPSNoiseMeters == nil {
+		noise := 5.0
+		cfg.GPSNoiseMeters = &noise
+	}
+	if cfg.TripKM == (Range{}) {
+		cfg.TripKM = Range{Min: 2, Max: 20}
+	}
+	if cfg.Dropouts.PerHour == nil {
+		perHour := 1.0
+		cfg.Dropouts.PerHour = &perHour
+	}
+	if cfg.Dropouts.Seconds == (Range{}) {
+		cfg.Dropouts.Seconds = Range{Min: 10, Max: 120}
+	}
+	if cfg.PingIntervalSeconds < 1 || *cfg.GPSNoiseMeters < 0 || *cfg.Dropouts.PerHour < 0 {
+		return fmt.Errorf("ping_interval_seconds must be at least 1 and gps_noise_meters and dropouts.per_hour not negative")
+	}
+	if err := cfg.TripKM.validate("trip_km"); err != nil {
+		return err
+	}
+	if err := cfg.Dropouts.Seconds.validate("dropouts.seconds"); err != nil {
+		return err
+	}
+	if len(cfg.Profiles) == 0 {
+		cfg.Profiles = []Profile{defaultProfile}
+	}
+	for i := range cfg.Profiles {
+		if err := cfg.Profiles[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Profile) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("vehicle profile needs a name")
+	}
+	if p.Share == 0 {
+		p.Share = 1
+	}
+	if p.SpeedFactor == 0 {
+		p.SpeedFactor = 0.9
+	}
+	if p.CruiseKPH == 0 {
+		p.CruiseKPH = 50
+	}
+	if p.MaxSpeedKPH == nil {
+		maxSpeed := 110.0
+		p.MaxSpeedKPH = &maxSpeed
+	}
+	if p.AccelMPS2 == 0 {
+		p.AccelMPS2 = 1.5
+	}
+	if p.StopsPerKM == nil {
+		stops := 0.5
+		p.StopsPerKM = &stops
+	}
+	if p.StopSeconds == (Range{}) {
+		p.StopSeconds = Range{Min: 5, Max: 45}
+	}
+	if p.DwellSeconds == (Range{}) {
+		p.DwellSeconds = Range{Min: 60, Max: 300}
+	}
+	if p.Share < 0 || p.SpeedFactor < 0 || p.CruiseKPH < 0 || *p.MaxSpeedKPH < 0 || p.AccelMPS2 < 0 || *p.StopsPerKM < 0 {
+		return fmt.Errorf("vehicle profile %q has a negative value", p.Name)
+	}
+	if err := p.StopSeconds.validate(p.Name + ".stop_seconds"); err != nil {
+		return err
+	}
+	return p.DwellSeconds.validate(p.Name + ".dwell_seconds")
+}
+
+func (r Range) validate(name string) error {
+	if r.Min < 0 || r.Max < r.Min {
+		return fmt.Errorf("%s must have 0 <= min <= max", name)
+	}
+	return nil
+}
+
+// Load reads a JSON Config from path.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read simulator config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse simulator config: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,189 @@
+package fleetsim
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/routing"
+)
+
+// planTries is how many destinations a RoadPlanner tries before giving up
+// on a trip.
+const planTries = 20
+
+// Path is a trip's polyline. SpeedKPH[i] is the limit between Points[i]
+// and Points[i+1], or 0 where unknown.
+type Path struct {
+	Points   []geo.Point
+	SpeedKPH []float64
+	// cum[i] is the distance in meters from Points[0] to Points[i].
+	cum []float64
+}
+
+func newPath(points []geo.Point, speeds []float64) *Path {
+	p := &Path{Points: points, SpeedKPH: speeds, cum: make([]float64, len(points))}
+	for i := 1; i < len(points); i++ {
+		p.cum[i] = p.cum[i-1] + geo.Distance(points[i-1], points[i])
+	}
+	return p
+}
+
+// Length is the path's length in meters.
+func (p *Path) Length() float64 {
+	return p.cum[len(p.cum)-1]
+}
+
+// Reverse returns the path driven the other way.
+func (p *Path) Reverse() *Path {
+	n := len(p.Points)
+	points := make([]geo.Point, n)
+	speeds := make([]float64, n-1)
+	for i := range p.Points {
+		points[i] = p.Points[n-1-i]
+	}
+	for i := range speeds {
+		speeds[i] = p.SpeedKPH[n-2-i]
+	}
+	return newPath(points, speeds)
+}
+
+// at returns the position d meters along the path and the speed limit
+// there, starting the search at segment seg, which it returns updated.
+func (p *Path) at(d float64, seg int) (geo.Point, float64, int) {
+	for seg < len(p.Points)-2 && p.cum[seg+1] <= d {
+		seg++
+	}
+	span := p.cum[seg+1] - p.cum[seg]
+	f := 1.0
+	if span > 0 {
+		f = min(1, (d-p.cum[seg])/span)
+	}
+	return geo.Interpolate(p.Points[seg], p.Points[seg+1], f), p.SpeedKPH[seg], seg
+}
+
+// Planner chooses trips.
+type Planner interface {
+	// Next returns the trip after prev, or a vehicle's first trip when
+	// prev is nil.
+	Next(rng *rand.Rand, prev *Path) (*Path, error)
+}
+
+// RoadPlanner drives shortest-time routes on the road graph between random
+// points, each trip starting where the last ended.
+type RoadPlanner struct {
+	router *routing.Router
+	tripKM Range
+}
+
+func NewRoadPlanner(r *routing.Router, tripKM Range) *RoadPlanner {
+	return &RoadPlanner{router: r, tripKM: tripKM}
+}
+
+func (p *RoadPlanner) Next(rng *rand.Rand, prev *Path) (*Path, error) {
+	nodes := p.router.Graph().Nodes
+	if len(nodes) == 0 {
+		return nil, errors.New("road graph is empty")
+	}
+	var from geo.Point
+	if prev != nil {
+		from = prev.Points[len(prev.Points)-1]
+	} else {
+		from = nodes[rng.Intn(len(nodes))].Point
+	}
+	for i := 0; i < planTries; i++ {
+		km := p.tripKM.Min + rng.Float64()*(p.tripKM.Max-p.tripKM.Min)
+		to := geo.Destination(from, rng.Float64()*360, km*1000)
+		route, err := p.router.Route(from, to)
+		if err != nil || len(route.Path) < 2 || route.DistanceMeters < 1 {
+			// Destinations off the road network or in another
+			// component are tried again elsewhere.
+			continue
+		}
+		speeds := make([]float64, len(route.Edges))
+		for j, e := range route.Edges {
+			speeds[j] = p.router.Graph().Edges[e].SpeedKPH
+		}
+		return newPath(route.Path, speeds), nil
+	}
+	return nil, fmt.Errorf("no route found from %.5f,%.5f in %d tries", from.Lat, from.Lon, planTries)
+}
+
+// TrackPlanner drives recorded tracks, such as GPX files, back and forth.
+type TrackPlanner struct {
+	tracks []*Path
+}
+
+func NewTrackPlanner(tracks []*Path) *TrackPlanner {
+	return &TrackPlanner{tracks: tracks}
+}
+
+func (p *TrackPlanner) Next(rng *rand.Rand, prev *Path) (*Path, error) {
+	if prev != nil {
+		return prev.Reverse(), nil
+	}
+	if len(p.tracks) == 0 {
+		return nil, errors.New("no tracks to drive")
+	}
+	return p.tracks[rng.Intn(len(p.tracks))], nil
+}
+
+type gpxFile struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+}
+
+type gpxPoint struct {
+	Lat float64 `xml:"lat,attr"`
+	Lon float64 `xml:"lon,attr"`
+}
+
+// LoadGPX reads every track segment and route of a GPX file as a path
+// without speed limits.
+func LoadGPX(path string) ([]*Path, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GPX file: %w", err)
+	}
+	var f gpxFile
+	if err := xml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse GPX file %s: %w", path, err)
+	}
+	var lines [][]gpxPoint
+	for _, t := range f.Tracks {
+		for _, s := range t.Segments {
+			lines = append(lines, s.Points)
+		}
+	}
+	for _, r := range f.Routes {
+		lines = append(lines, r.Points)
+	}
+
+	var paths []*Path
+	for _, line := range lines {
+		var points []geo.Point
+		for _, pt := range line {
+			p := geo.Point{Lat: pt.Lat, Lon: pt.Lon}
+			if !p.Valid() || (len(points) > 0 && points[len(points)-1] == p) {
+				continue
+			}
+			points = append(points, p)
+		}
+		if len(points) >= 2 {
+			paths = append(paths, newPath(points, make([]float64, len(points)-1)))
+		}
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("GPX file %s has no track with two or more points", path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,179 @@
+// Package fleetsim simulates a fleet driving along roads or recorded
+// tracks, producing the pings its trackers would send: speed profiles
+// with acceleration limits, stops, GPS noise and signal dropouts.
+package fleetsim
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/replay"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+// Simulator moves every vehicle one second at a time. It is not safe for
+// concurrent use.
+type Simulator struct {
+	cfg      Config
+	planner  Planner
+	rng      *rand.Rand
+	vehicles []*vehicle
+}
+
+// New creates n vehicles, assigning profiles by share. IDs are prefix, the
+// profile name and a number, e.g. "sim-van-0001".
+func New(cfg Config, planner Planner, n int, prefix string, seed int64) (*Simulator, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	s := &Simulator{cfg: cfg, planner: planner, rng: rand.New(rand.NewSource(seed))}
+
+	var total float64
+	for _, p := range cfg.Profiles {
+		total += p.Share
+	}
+	counts := make([]int, len(cfg.Profiles))
+	for i := 0; i < n; i++ {
+		r := s.rng.Float64() * total
+		pi := 0
+		for ; pi < len(cfg.Profiles)-1 && r >= cfg.Profiles[pi].Share; pi++ {
+			r -= cfg.Profiles[pi].Share
+		}
+		counts[pi]++
+		p := &s.cfg.Profiles[pi]
+		s.vehicles = append(s.vehicles, &vehicle{
+			id:      fmt.Sprintf("%s%s-%04d", prefix, p.Name, counts[pi]),
+			profile: p,
+			// Trackers are not synchronised.
+			nextPing: s.rng.Float64() * cfg.PingIntervalSeconds,
+		})
+	}
+	return s, nil
+}
+
+// Step advances the fleet by one second to Unix time now and returns the
+// pings sent in that second.
+func (s *Simulator) Step(now int64) []service.PingPayload {
+	var out []service.PingPayload
+	dropoutRate := *s.cfg.Dropouts.PerHour / 3600
+	for _, v := range s.vehicles {
+		v.step(s.rng, s.planner, 1)
+
+		wasOffline := v.offline > 0
+		if wasOffline {
+			v.offline--
+		} else if s.rng.Float64() < dropoutRate {
+			v.offline = sample(s.rng, s.cfg.Dropouts.Seconds)
+		}
+		if wasOffline && v.offline <= 0 {
+			out = append(out, v.stored...)
+			v.stored = v.stored[:0]
+		}
+
+		v.nextPing--
+		if v.nextPing > 0 {
+			continue
+		}
+		v.nextPing += s.cfg.PingIntervalSeconds * (0.9 + 0.2*s.rng.Float64())
+		p, ok := v.ping(s.rng, &s.cfg, now)
+		switch {
+		case !ok:
+		case v.offline <= 0:
+			out = append(out, p)
+		case s.cfg.Dropouts.StoreAndForward && len(v.stored) < maxStored:
+			v.stored = append(v.stored, p)
+		}
+	}
+	return out
+}
+
+// States counts vehicles by what they are doing.
+func (s *Simulator) States() map[string]int {
+	states := make(map[string]int)
+	for _, v := range s.vehicles {
+		states[v.state()]++
+	}
+	return states
+}
+
+// RunOptions configure Run.
+type RunOptions struct {
+	// Workers send in parallel; each vehicle's pings always go through the
+	// same worker, so they stay in order.
+	Workers int
+	// Tenant is set on every ping.
+	Tenant   string
+	Progress time.Duration
+}
+
+// Run steps the simulation in real time, sending each second's pings to
+// sink, until ctx is cancelled. When sending falls behind, the simulation
+// catches up with correctly timestamped pings. Send errors are logged and
+// the pings dropped.
+func (s *Simulator) Run(ctx context.Context, sink replay.Sink, opts RunOptions) {
+	workers := max(1, opts.Workers)
+	var sent, failed atomic.Int64
+	errLog := logging.Sampled(slog.Default(), time.Second, 1, 0)
+
+	send := func(pings []service.PingPayload) {
+		shards := make([][]service.PingPayload, workers)
+		for _, p := range pings {
+			p.Tenant = opts.Tenant
+			h := fnv.New32a()
+			h.Write([]byte(p.VehicleID))
+			i := h.Sum32() % uint32(workers)
+			shards[i] = append(shards[i], p)
+		}
+		var wg sync.WaitGroup
+		for _, shard := range shards {
+			if len(shard) == 0 {
+				continue
+			}
+			wg.Add(1)
+			go func(shard []service.PingPayload) {
+				defer wg.Done()
+				if err := sink.Send(ctx, shard); err != nil {
+					failed.Add(int64(len(shard)))
+					if ctx.Err() == nil {
+						errLog.Error("Failed to send pings", "error", err)
+					}
+					return
+				}
+				sent.Add(int64(len(shard)))
+			}(shard)
+		}
+		wg.Wait()
+	}
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	clock := time.Now().Unix()
+	lastProgress := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		var pings []service.PingPayload
+		for now := time.Now().Unix(); clock < now; {
+			clock++
+			pings = append(pings, s.Step(clock)...)
+		}
+		send(pings)
+
+		if opts.Progress > 0 && time.Since(lastProgress) >= opts.Progress {
+			lastProgress = time.Now()
+			states := s.States()
+			slog.Info("Simulation progress", "sent", sent.Load(), "failed", failed.Load(),
+				"moving", states["moving"], "stopped", states["stopped"], "offline", states["offline"])
+		}
+	}
+}
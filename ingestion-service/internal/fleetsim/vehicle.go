@@ -0,0 +1,161 @@
+package fleetsim
+
+import (
+	"log/slog"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/nexus-logistics/ingestion-service/internal/anomaly"
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+const (
+	// replanSeconds is how long a vehicle waits before retrying a trip the
+	// planner could not find.
+	replanSeconds = 60
+	// maxStored bounds the pings a store-and-forward tracker buffers.
+	maxStored = 720
+	// metersPerDegree converts noise offsets to degrees of latitude.
+	metersPerDegree = 111320
+)
+
+type vehicle struct {
+	id      string
+	profile *Profile
+
+	path *Path
+	// pos is the distance driven along path and seg the segment it is on.
+	pos   float64
+	seg   int
+	speed float64 // m/s
+	// stops are the distances along path to stop at, ascending; the last
+	// is the destination.
+	stops []float64
+	// wait is the seconds left stopped, or until the next planning attempt
+	// when path is nil.
+	wait float64
+
+	nextPing float64
+	offline  float64
+	stored   []service.PingPayload
+}
+
+// state names what the vehicle is doing, for progress logs.
+func (v *vehicle) state() string {
+	switch {
+	case v.offline > 0:
+		return "offline"
+	case v.path == nil || v.wait > 0:
+		return "stopped"
+	}
+	return "moving"
+}
+
+// plan starts the vehicle's next trip. The first trip starts at a random
+// point along it, so a fleet does not set off in convoy.
+func (v *vehicle) plan(rng *rand.Rand, planner Planner) {
+	first := v.path == nil
+	path, err := planner.Next(rng, v.path)
+	if err != nil {
+		slog.Warn("Failed to plan trip", "vehicle_id", v.id, "error", err)
+		v.path, v.wait = nil, replanSeconds
+		return
+	}
+	v.path, v.pos, v.seg, v.speed = path, 0, 0, 0
+	if first {
+		v.pos = rng.Float64() * path.Length()
+	}
+
+	// Stops along the trip are a Poisson process over distance.
+	v.stops = v.stops[:0]
+	if rate := *v.profile.StopsPerKM / 1000; rate > 0 {
+		for d := v.pos + rng.ExpFloat64()/rate; d < path.Length(); d += rng.ExpFloat64() / rate {
+			v.stops = append(v.stops, d)
+		}
+	}
+	v.stops = append(v.stops, path.Length())
+	sort.Float64s(v.stops)
+}
+
+// step advances the vehicle by dt seconds.
+func (v *vehicle) step(rng *rand.Rand, planner Planner, dt float64) {
+	if v.wait > 0 {
+		v.wait -= dt
+		if v.wait > 0 {
+			return
+		}
+		v.wait = 0
+		if v.path == nil || v.pos >= v.path.Length() {
+			v.plan(rng, planner)
+		}
+		return
+	}
+	if v.path == nil {
+		v.plan(rng, planner)
+		return
+	}
+
+	p := v.profile
+	_, limit, _ := v.path.at(v.pos, v.seg)
+	target := p.CruiseKPH
+	if limit > 0 {
+		target = limit * p.SpeedFactor
+	}
+	if *p.MaxSpeedKPH > 0 {
+		target = min(target, *p.MaxSpeedKPH)
+	}
+	target /= 3.6
+
+	// Brake in time for the next stop.
+	brake := 1.5 * p.AccelMPS2
+	toStop := v.stops[0] - v.pos
+	target = min(target, math.Sqrt(2*brake*toStop))
+	if v.speed < target {
+		v.speed = min(target, v.speed+p.AccelMPS2*dt)
+	} else {
+		v.speed = max(target, v.speed-brake*dt)
+	}
+
+	// Braking curves never quite reach the stop, so the last meter is
+	// covered at once.
+	if d := v.speed * dt; d >= toStop || toStop < 1 {
+		v.pos, v.speed = v.stops[0], 0
+		v.stops = v.stops[1:]
+		if len(v.stops) == 0 {
+			v.wait = sample(rng, p.DwellSeconds)
+		} else {
+			v.wait = sample(rng, p.StopSeconds)
+		}
+	} else {
+		v.pos += d
+	}
+	_, _, v.seg = v.path.at(v.pos, v.seg)
+}
+
+// ping returns the vehicle's reported position at Unix time now, with GPS
+// noise.
+func (v *vehicle) ping(rng *rand.Rand, cfg *Config, now int64) (service.PingPayload, bool) {
+	if v.path == nil {
+		return service.PingPayload{}, false
+	}
+	at, _, _ := v.path.at(v.pos, v.seg)
+	if n := *cfg.GPSNoiseMeters; n > 0 {
+		at = geo.Point{
+			Lat: at.Lat + rng.NormFloat64()*n/metersPerDegree,
+			Lon: at.Lon + rng.NormFloat64()*n/(metersPerDegree*math.Cos(at.Lat*math.Pi/180)),
+		}
+	}
+	return service.PingPayload{
+		VehicleID:  v.id,
+		Latitude:   at.Lat,
+		Longitude:  at.Lon,
+		Timestamp:  now,
+		Attributes: map[string]string{anomaly.ClassAttribute: v.profile.Name},
+	}, true
+}
+
+func sample(rng *rand.Rand, r Range) float64 {
+	return r.Min + rng.Float64()*(r.Max-r.Min)
+}
@@ -0,0 +1,166 @@
+// Package geo holds the spherical geometry shared by the location
+// processors: distances, bearings and point-in-shape tests on WGS84
+// coordinates.
+package geo
+
+import "math"
+
+// EarthRadiusMeters is the mean Earth radius used by all distance math.
+const EarthRadiusMeters = 6371008.8
+
+// metersPerDegreeLat is the length of one degree of latitude.
+const metersPerDegreeLat = math.Pi * EarthRadiusMeters / 180
+
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// IsZero reports whether p is exactly (0, 0), the "null island" fix that
+// broken GPS units emit.
+func (p Point) IsZero() bool {
+	return p.Lat == 0 && p.Lon == 0
+}
+
+// Valid reports whether p is a finite coordinate within WGS84 bounds.
+func (p Point) Valid() bool {
+	return !math.IsNaN(p.Lat) && !math.IsNaN(p.Lon) &&
+		p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+func radians(deg float64) float64 { return deg * math.Pi / 180 }
+func degrees(rad float64) float64 { return rad * 180 / math.Pi }
+
+// Distance returns the great-circle (haversine) distance in meters.
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(b.Lon - a.Lon)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Bearing returns the initial bearing from a to b in degrees clockwise from
+// north, in [0, 360).
+func Bearing(a, b Point) float64 {
+	lat1, lat2 := radians(a.Lat), radians(b.Lat)
+	dLon := radians(b.Lon - a.Lon)
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// Destination returns the point reached by travelling distance meters from
+// p along the given bearing.
+func Destination(p Point, bearing, distance float64) Point {
+	lat1, lon1 := radians(p.Lat), radians(p.Lon)
+	brng := radians(bearing)
+	d := distance / EarthRadiusMeters
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(brng))
+	lon2 := lon1 + math.Atan2(math.Sin(brng)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+	return Point{Lat: degrees(lat2), Lon: math.Mod(degrees(lon2)+540, 360) - 180}
+}
+
+// Interpolate returns the point a fraction f of the way from a to b using
+// linear interpolation, which is accurate for the short segments of a road
+// network.
+func Interpolate(a, b Point, f float64) Point {
+	return Point{Lat: a.Lat + (b.Lat-a.Lat)*f, Lon: a.Lon + (b.Lon-a.Lon)*f}
+}
+
+// BBox is a latitude/longitude rectangle.
+type BBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+// EmptyBBox returns a box that contains nothing and grows with Extend.
+func EmptyBBox() BBox {
+	return BBox{MinLat: math.Inf(1), MinLon: math.Inf(1), MaxLat: math.Inf(-1), MaxLon: math.Inf(-1)}
+}
+
+// Extend grows b to include p.
+func (b *BBox) Extend(p Point) {
+	b.MinLat = math.Min(b.MinLat, p.Lat)
+	b.MinLon = math.Min(b.MinLon, p.Lon)
+	b.MaxLat = math.Max(b.MaxLat, p.Lat)
+	b.MaxLon = math.Max(b.MaxLon, p.Lon)
+}
+
+func (b BBox) Contains(p Point) bool {
+	return p.Lat >= b.MinLat && p.Lat <= b.MaxLat && p.Lon >= b.MinLon && p.Lon <= b.MaxLon
+}
+
+func (b BBox) Intersects(o BBox) bool {
+	return b.MinLat <= o.MaxLat && o.MinLat <= b.MaxLat && b.MinLon <= o.MaxLon && o.MinLon <= b.MaxLon
+}
+
+// Around returns the bounding box of the circle of radius meters around p.
+func Around(p Point, radius float64) BBox {
+	dLat := radius / metersPerDegreeLat
+	dLon := radius / (metersPerDegreeLat * math.Max(math.Cos(radians(p.Lat)), 1e-6))
+	return BBox{MinLat: p.Lat - dLat, MinLon: p.Lon - dLon, MaxLat: p.Lat + dLat, MaxLon: p.Lon + dLon}
+}
+
+// Polygon is an outer ring with optional holes. Rings need not be closed.
+type Polygon struct {
+	Outer []Point   `json:"outer"`
+	Holes [][]Point `json:"holes,omitempty"`
+}
+
+// Contains reports whether p lies inside the outer ring and outside every
+// hole.
+func (poly Polygon) Contains(p Point) bool {
+	if !ringContains(poly.Outer, p) {
+		return false
+	}
+	for _, h := range poly.Holes {
+		if ringContains(h, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// Bounds returns the bounding box of the outer ring.
+func (poly Polygon) Bounds() BBox {
+	b := EmptyBBox()
+	for _, p := range poly.Outer {
+		b.Extend(p)
+	}
+	return b
+}
+
+// ringContains is the even-odd ray casting test, treating coordinates as
+// planar, which is accurate for geofence-sized shapes.
+func ringContains(ring []Point, p Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lon < (b.Lon-a.Lon)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Project returns the closest point to p on segment ab, and how far along
+// the segment it lies (0 at a, 1 at b). It uses an equirectangular
+// projection around p, which is accurate for short segments.
+func Project(p, a, b Point) (Point, float64) {
+	k := math.Cos(radians(p.Lat))
+	ax, ay := (a.Lon-p.Lon)*k, a.Lat-p.Lat
+	bx, by := (b.Lon-p.Lon)*k, b.Lat-p.Lat
+	dx, dy := bx-ax, by-ay
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		return a, 0
+	}
+	t := -(ax*dx + ay*dy) / l2
+	t = math.Max(0, math.Min(1, t))
+	return Interpolate(a, b, t), t
+}
@@ -0,0 +1,258 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// Segment is a straight line between two points.
+type Segment struct {
+	A, B Point
+}
+
+// minHoleCells is the size, in cells, below which holes in a hull are
+// filled.
+const minHoleCells = 64
+
+// SegmentHull returns polygons covering every segment with a margin of
+// about one cell. The segments are rasterized onto a grid of cell meters,
+// and the boundary of the covered cells is traced into rings, so the
+// result is a concave hull that follows the shape of the input, keeps
+// separate areas apart and has holes where no segment passes. Holes under
+// minHoleCells are filled so the gaps between nearby segments do not show,
+// and outlines are simplified to within a cell.
+func SegmentHull(segments []Segment, cell float64) []Polygon {
+	if len(segments) == 0 || cell <= 0 {
+		return nil
+	}
+	g := newHullGrid(segments[0].A, cell)
+	for _, s := range segments {
+		g.rasterize(s)
+	}
+	g.dilate()
+	rings := g.trace()
+
+	// Outer rings run counterclockwise, holes clockwise.
+	type outer struct {
+		ring []hullVertex
+		area float64
+		poly *Polygon
+	}
+	var outers []outer
+	var holes [][]hullVertex
+	for _, r := range rings {
+		if a := ringArea(r); a > 0 {
+			outers = append(outers, outer{ring: r, area: a})
+		} else if -a >= minHoleCells {
+			holes = append(holes, r)
+		}
+	}
+	sort.Slice(outers, func(i, j int) bool { return outers[i].area < outers[j].area })
+
+	polys := make([]Polygon, len(outers))
+	for i := range outers {
+		polys[i].Outer = g.toPoints(outers[i].ring)
+		outers[i].poly = &polys[i]
+	}
+	for _, h := range holes {
+		// The cell left of a hole's first edge is covered, and lies
+		// inside the smallest outer ring around the hole.
+		x, y := h[0].leftCell(h[1])
+		for _, o := range outers {
+			if gridRingContains(o.ring, float64(x)+0.5, float64(y)+0.5) {
+				o.poly.Holes = append(o.poly.Holes, g.toPoints(h))
+				break
+			}
+		}
+	}
+	return polys
+}
+
+type hullCell struct{ x, y int }
+
+type hullVertex struct{ x, y int }
+
+// leftCell returns the cell to the left of the unit edge from v to w.
+func (v hullVertex) leftCell(w hullVertex) (int, int) {
+	switch {
+	case w.x > v.x: // east
+		return v.x, v.y
+	case w.y > v.y: // north
+		return v.x - 1, v.y
+	case w.x < v.x: // west
+		return w.x, w.y - 1
+	default: // south
+		return v.x, w.y
+	}
+}
+
+// hullGrid is an equirectangular grid of cells around an origin.
+type hullGrid struct {
+	origin Point
+	cell   float64
+	kx     float64 // meters per degree of longitude at the origin
+	cells  map[hullCell]bool
+}
+
+func newHullGrid(origin Point, cell float64) *hullGrid {
+	return &hullGrid{
+		origin: origin,
+		cell:   cell,
+		kx:     metersPerDegreeLat * math.Max(math.Cos(radians(origin.Lat)), 1e-6),
+		cells:  make(map[hullCell]bool),
+	}
+}
+
+func (g *hullGrid) xy(p Point) (float64, float64) {
+	return (p.Lon - g.origin.Lon) * g.kx / g.cell, (p.Lat - g.origin.Lat) * metersPerDegreeLat / g.cell
+}
+
+func (g *hullGrid) toPoints(ring []hullVertex) []Point {
+	pts := make([]Point, 0, len(ring)+1)
+	for _, v := range ring {
+		pts = append(pts, Point{
+			Lat: g.origin.Lat + float64(v.y)*g.cell/metersPerDegreeLat,
+			Lon: g.origin.Lon + float64(v.x)*g.cell/g.kx,
+		})
+	}
+	pts = append(pts, pts[0])
+	return Simplify(pts, g.cell)
+}
+
+// rasterize marks every cell the segment passes through, sampling it at
+// quarter-cell steps.
+func (g *hullGrid) rasterize(s Segment) {
+	ax, ay := g.xy(s.A)
+	bx, by := g.xy(s.B)
+	steps := int(math.Ceil(math.Hypot(bx-ax, by-ay) * 4))
+	for i := 0; i <= steps; i++ {
+		f := 1.0
+		if steps > 0 {
+			f = float64(i) / float64(steps)
+		}
+		g.cells[hullCell{int(math.Floor(ax + (bx-ax)*f)), int(math.Floor(ay + (by-ay)*f))}] = true
+	}
+}
+
+// dilate grows the covered area by one cell in every direction, so roads
+// a cell apart merge into one area.
+func (g *hullGrid) dilate() {
+	grown := make(map[hullCell]bool, len(g.cells)*3)
+	for c := range g.cells {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				grown[hullCell{c.x + dx, c.y + dy}] = true
+			}
+		}
+	}
+	g.cells = grown
+}
+
+// trace returns the boundaries between covered and empty cells as closed
+// rings of corner vertices, each with the covered cells on its left.
+func (g *hullGrid) trace() [][]hullVertex {
+	type edge struct{ from, to hullVertex }
+	out := make(map[hullVertex][]hullVertex)
+	for c := range g.cells {
+		x, y := c.x, c.y
+		if !g.cells[hullCell{x, y - 1}] {
+			out[hullVertex{x, y}] = append(out[hullVertex{x, y}], hullVertex{x + 1, y})
+		}
+		if !g.cells[hullCell{x + 1, y}] {
+			out[hullVertex{x + 1, y}] = append(out[hullVertex{x + 1, y}], hullVertex{x + 1, y + 1})
+		}
+		if !g.cells[hullCell{x, y + 1}] {
+			out[hullVertex{x + 1, y + 1}] = append(out[hullVertex{x + 1, y + 1}], hullVertex{x, y + 1})
+		}
+		if !g.cells[hullCell{x - 1, y}] {
+			out[hullVertex{x, y + 1}] = append(out[hullVertex{x, y + 1}], hullVertex{x, y})
+		}
+	}
+
+	// next picks the outgoing edge at v for a boundary arriving from
+	// prev. Where two covered cells touch only at a corner, turning left
+	// keeps them in separate rings.
+	next := func(prev, v hullVertex) hullVertex {
+		cands := out[v]
+		if len(cands) == 1 {
+			return cands[0]
+		}
+		dx, dy := v.x-prev.x, v.y-prev.y
+		left := hullVertex{v.x - dy, v.y + dx}
+		for _, w := range cands {
+			if w == left {
+				return w
+			}
+		}
+		return cands[0]
+	}
+
+	used := make(map[edge]bool)
+	var rings [][]hullVertex
+	// Visit start vertices in a fixed order so output is deterministic.
+	starts := make([]hullVertex, 0, len(out))
+	for v := range out {
+		starts = append(starts, v)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		if starts[i].y != starts[j].y {
+			return starts[i].y < starts[j].y
+		}
+		return starts[i].x < starts[j].x
+	})
+	for _, start := range starts {
+		for _, first := range out[start] {
+			if used[edge{start, first}] {
+				continue
+			}
+			var ring []hullVertex
+			used[edge{start, first}] = true
+			prev, v := start, first
+			for {
+				w := next(prev, v)
+				if v == start && w == first {
+					break
+				}
+				used[edge{v, w}] = true
+				if isCorner(prev, v, w) {
+					ring = append(ring, v)
+				}
+				prev, v = v, w
+			}
+			if isCorner(prev, start, first) {
+				ring = append(ring, start)
+			}
+			if len(ring) >= 3 {
+				rings = append(rings, ring)
+			}
+		}
+	}
+	return rings
+}
+
+// isCorner reports whether the boundary turns at v.
+func isCorner(prev, v, w hullVertex) bool {
+	return w.x-v.x != v.x-prev.x || w.y-v.y != v.y-prev.y
+}
+
+// ringArea is the signed area of a grid ring, positive when
+// counterclockwise.
+func ringArea(ring []hullVertex) float64 {
+	a := 0
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a += ring[j].x*ring[i].y - ring[i].x*ring[j].y
+	}
+	return float64(a) / 2
+}
+
+func gridRingContains(ring []hullVertex, x, y float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		ax, ay := float64(ring[i].x), float64(ring[i].y)
+		bx, by := float64(ring[j].x), float64(ring[j].y)
+		if (ay > y) != (by > y) && x < (bx-ax)*(y-ay)/(by-ay)+ax {
+			inside = !inside
+		}
+	}
+	return inside
+}
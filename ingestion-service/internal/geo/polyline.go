@@ -0,0 +1,169 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Simplify reduces a path with the Douglas-Peucker algorithm, keeping the
+// end points and every point farther than tolerance meters from the
+// simplified line.
+func Simplify(path []Point, tolerance float64) []Point {
+	if len(path) < 3 {
+		return append([]Point(nil), path...)
+	}
+	keep := make([]bool, len(path))
+	keep[0], keep[len(path)-1] = true, true
+
+	// An explicit stack avoids deep recursion on long trips.
+	type span struct{ from, to int }
+	stack := []span{{0, len(path) - 1}}
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		worst, worstDist := -1, tolerance
+		for i := s.from + 1; i < s.to; i++ {
+			closest, _ := Project(path[i], path[s.from], path[s.to])
+			if d := Distance(path[i], closest); d > worstDist {
+				worst, worstDist = i, d
+			}
+		}
+		if worst >= 0 {
+			keep[worst] = true
+			stack = append(stack, span{s.from, worst}, span{worst, s.to})
+		}
+	}
+
+	out := make([]Point, 0, len(path))
+	for i, p := range path {
+		if keep[i] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Downsample picks at most n points of path with the largest-triangle-
+// three-buckets algorithm and returns their indexes in order. It keeps the
+// end points and, from each run of points in between, the one that
+// deviates most from its neighbours, so turns survive while straight runs
+// collapse. Unlike Simplify the output size is fixed rather than the error.
+func Downsample(path []Point, n int) []int {
+	if n >= len(path) || n <= 0 {
+		idx := make([]int, len(path))
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+	if n == 1 {
+		return []int{0}
+	}
+	if n == 2 {
+		return []int{0, len(path) - 1}
+	}
+
+	// Triangle areas only rank candidates, so a local equirectangular
+	// projection is accurate enough.
+	cosLat := math.Cos(radians(path[0].Lat))
+	xy := func(p Point) (float64, float64) { return p.Lon * cosLat, p.Lat }
+
+	out := make([]int, 0, n)
+	out = append(out, 0)
+	bucket := float64(len(path)-2) / float64(n-2)
+	prev := 0
+	for b := 0; b < n-2; b++ {
+		from := int(float64(b)*bucket) + 1
+		to := int(float64(b+1)*bucket) + 1
+
+		// The next bucket's centroid stands in for the point that will be
+		// picked from it.
+		nextFrom, nextTo := to, min(int(float64(b+2)*bucket)+1, len(path))
+		if b == n-3 {
+			nextFrom, nextTo = len(path)-1, len(path)
+		}
+		var cx, cy float64
+		for i := nextFrom; i < nextTo; i++ {
+			x, y := xy(path[i])
+			cx += x
+			cy += y
+		}
+		cx /= float64(nextTo - nextFrom)
+		cy /= float64(nextTo - nextFrom)
+
+		ax, ay := xy(path[prev])
+		best, bestArea := from, -1.0
+		for i := from; i < to; i++ {
+			x, y := xy(path[i])
+			area := math.Abs((ax-cx)*(y-ay) - (ax-x)*(cy-ay))
+			if area > bestArea {
+				best, bestArea = i, area
+			}
+		}
+		out = append(out, best)
+		prev = best
+	}
+	return append(out, len(path)-1)
+}
+
+// EncodePolyline encodes a path in the Google encoded polyline format with
+// five decimal places, as used by most map libraries.
+func EncodePolyline(path []Point) string {
+	var b strings.Builder
+	var prevLat, prevLon int64
+	for _, p := range path {
+		lat := int64(math.Round(p.Lat * 1e5))
+		lon := int64(math.Round(p.Lon * 1e5))
+		encodeValue(&b, lat-prevLat)
+		encodeValue(&b, lon-prevLon)
+		prevLat, prevLon = lat, lon
+	}
+	return b.String()
+}
+
+func encodeValue(b *strings.Builder, v int64) {
+	u := uint64(v) << 1
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b.WriteByte(byte(0x20|u&0x1f) + 63)
+		u >>= 5
+	}
+	b.WriteByte(byte(u) + 63)
+}
+
+// DecodePolyline is the inverse of EncodePolyline.
+func DecodePolyline(s string) ([]Point, error) {
+	var path []Point
+	var lat, lon int64
+	for i := 0; i < len(s); {
+		var deltas [2]int64
+		for j := range deltas {
+			var u uint64
+			var shift uint
+			for {
+				if i >= len(s) {
+					return nil, fmt.Errorf("truncated polyline")
+				}
+				c := uint64(s[i]) - 63
+				i++
+				u |= (c & 0x1f) << shift
+				shift += 5
+				if c < 0x20 {
+					break
+				}
+			}
+			if u&1 != 0 {
+				deltas[j] = ^int64(u >> 1)
+			} else {
+				deltas[j] = int64(u >> 1)
+			}
+		}
+		lat += deltas[0]
+		lon += deltas[1]
+		path = append(path, Point{Lat: float64(lat) / 1e5, Lon: float64(lon) / 1e5})
+	}
+	return path, nil
+}
@@ -0,0 +1,192 @@
+package geofence
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+type EventType string
+
+const (
+	EventEnter         EventType = "enter"
+	EventExit          EventType = "exit"
+	EventDwellExceeded EventType = "dwell_exceeded"
+)
+
+// Event is published to the geofence-events topic.
+type Event struct {
+	Type         EventType `json:"type"`
+	VehicleID    string    `json:"vehicle_id"`
+	Tenant       string    `json:"tenant,omitempty"`
+	GeofenceID   string    `json:"geofence_id"`
+	GeofenceName string    `json:"geofence_name,omitempty"`
+	Latitude     float64   `json:"latitude"`
+	Longitude    float64   `json:"longitude"`
+	// Timestamp is the Unix time of the ping that caused the event.
+	Timestamp int64 `json:"timestamp"`
+	// DwellSeconds is the time spent inside, set on exit and dwell events.
+	DwellSeconds int64 `json:"dwell_seconds,omitempty"`
+}
+
+// Engine tracks which geofences each vehicle is inside. It is safe for
+// concurrent use; geofences can be replaced at any time with Set.
+type Engine struct {
+	index atomic.Pointer[Index]
+
+	mu       sync.Mutex
+	vehicles map[string]*vehicleState
+}
+
+type vehicleState struct {
+	lastSeen int64
+	inside   map[string]*presence
+}
+
+type presence struct {
+	since         int64
+	dwellReported bool
+}
+
+func NewEngine() *Engine {
+	e := &Engine{vehicles: make(map[string]*vehicleState)}
+	e.index.Store(NewIndex(nil))
+	return e
+}
+
+// Set replaces the evaluated geofences. Vehicles inside a geofence that no
+// longer exists simply forget it, without an exit event.
+func (e *Engine) Set(fences []Geofence) {
+	e.index.Store(NewIndex(fences))
+}
+
+// Index returns the geofences currently evaluated.
+func (e *Engine) Index() *Index {
+	return e.index.Load()
+}
+
+// Transition is the effect of one position on a vehicle's state: the
+// events it causes and the state that follows.
+type Transition struct {
+	Events []Event
+
+	vehicleID string
+	base      *vehicleState
+	next      *vehicleState
+}
+
+// Evaluate computes the events one position causes without changing any
+// state, so that a failed publish can be retried against the same state.
+// Call Apply once the events are published. Pings older than the last one
+// seen for the vehicle cause nothing, since applying them would produce
+// spurious exit/enter pairs.
+func (e *Engine) Evaluate(vehicleID, tenant string, p geo.Point, ts int64) *Transition {
+	idx := e.index.Load()
+	containing := idx.Containing(p)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	base := e.vehicles[vehicleID]
+	if base != nil && ts < base.lastSeen {
+		return &Transition{}
+	}
+	st := base.clone()
+	st.lastSeen = ts
+	t := &Transition{vehicleID: vehicleID, base: base, next: st}
+
+	newEvent := func(t EventType, g *Geofence) Event {
+		return Event{
+			Type:         t,
+			VehicleID:    vehicleID,
+			Tenant:       tenant,
+			GeofenceID:   g.ID,
+			GeofenceName: g.Name,
+			Latitude:     p.Lat,
+			Longitude:    p.Lon,
+			Timestamp:    ts,
+		}
+	}
+
+	now := make(map[string]bool, len(containing))
+	for _, g := range containing {
+		now[g.ID] = true
+		pr, was := st.inside[g.ID]
+		if !was {
+			st.inside[g.ID] = &presence{since: ts}
+			t.Events = append(t.Events, newEvent(EventEnter, g))
+			continue
+		}
+		if g.DwellLimitSeconds > 0 && !pr.dwellReported && ts-pr.since > g.DwellLimitSeconds {
+			pr.dwellReported = true
+			ev := newEvent(EventDwellExceeded, g)
+			ev.DwellSeconds = ts - pr.since
+			t.Events = append(t.Events, ev)
+		}
+	}
+
+	for id, pr := range st.inside {
+		if now[id] {
+			continue
+		}
+		delete(st.inside, id)
+		g, exists := idx.Get(id)
+		if !exists {
+			continue
+		}
+		ev := newEvent(EventExit, g)
+		ev.DwellSeconds = ts - pr.since
+		t.Events = append(t.Events, ev)
+	}
+	return t
+}
+
+// Apply makes a transition's state current. It does nothing if the
+// vehicle's state changed since the transition was evaluated.
+func (e *Engine) Apply(t *Transition) {
+	if t.next == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.vehicles[t.vehicleID] != t.base {
+		return
+	}
+	e.vehicles[t.vehicleID] = t.next
+}
+
+func (st *vehicleState) clone() *vehicleState {
+	c := &vehicleState{inside: make(map[string]*presence)}
+	if st == nil {
+		return c
+	}
+	c.lastSeen = st.lastSeen
+	for id, pr := range st.inside {
+		cp := *pr
+		c.inside[id] = &cp
+	}
+	return c
+}
+
+// Evict drops the state of vehicles not seen since the given Unix time and
+// returns how many were removed.
+func (e *Engine) Evict(before int64) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	n := 0
+	for id, st := range e.vehicles {
+		if st.lastSeen < before {
+			delete(e.vehicles, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Vehicles returns the number of vehicles with tracked state.
+func (e *Engine) Vehicles() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.vehicles)
+}
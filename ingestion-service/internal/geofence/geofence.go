@@ -0,0 +1,76 @@
+// Package geofence evaluates vehicle positions against circular and polygon
+// geofences and turns the transitions into enter, exit and dwell events.
+package geofence
+
+import (
+	"fmt"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// Geofence is either a circle (Center and RadiusMeters) or an area made of
+// one or more polygons.
+type Geofence struct {
+	ID           string        `json:"id"`
+	Name         string        `json:"name,omitempty"`
+	Center       *geo.Point    `json:"center,omitempty"`
+	RadiusMeters float64       `json:"radius_meters,omitempty"`
+	Polygons     []geo.Polygon `json:"polygons,omitempty"`
+	// DwellLimitSeconds raises a dwell_exceeded event once a vehicle has
+	// been inside for longer. Zero disables dwell alerts.
+	DwellLimitSeconds int64 `json:"dwell_limit_seconds,omitempty"`
+}
+
+// Validate checks that the geofence has an ID and exactly one usable shape.
+func (g *Geofence) Validate() error {
+	if g.ID == "" {
+		return fmt.Errorf("geofence has no id")
+	}
+	switch {
+	case g.Center != nil && len(g.Polygons) > 0:
+		return fmt.Errorf("geofence %s has both a circle and polygons", g.ID)
+	case g.Center != nil:
+		if !g.Center.Valid() || g.RadiusMeters <= 0 {
+			return fmt.Errorf("geofence %s has an invalid circle", g.ID)
+		}
+	case len(g.Polygons) > 0:
+		for _, p := range g.Polygons {
+			if len(p.Outer) < 3 {
+				return fmt.Errorf("geofence %s has a polygon with fewer than 3 points", g.ID)
+			}
+		}
+	default:
+		return fmt.Errorf("geofence %s has no shape", g.ID)
+	}
+	if g.DwellLimitSeconds < 0 {
+		return fmt.Errorf("geofence %s has a negative dwell limit", g.ID)
+	}
+	return nil
+}
+
+// Contains reports whether p is inside the geofence.
+func (g *Geofence) Contains(p geo.Point) bool {
+	if g.Center != nil {
+		return geo.Distance(*g.Center, p) <= g.RadiusMeters
+	}
+	for _, poly := range g.Polygons {
+		if poly.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bounds returns the bounding box of the geofence.
+func (g *Geofence) Bounds() geo.BBox {
+	if g.Center != nil {
+		return geo.Around(*g.Center, g.RadiusMeters)
+	}
+	b := geo.EmptyBBox()
+	for _, poly := range g.Polygons {
+		pb := poly.Bounds()
+		b.Extend(geo.Point{Lat: pb.MinLat, Lon: pb.MinLon})
+		b.Extend(geo.Point{Lat: pb.MaxLat, Lon: pb.MaxLon})
+	}
+	return b
+}
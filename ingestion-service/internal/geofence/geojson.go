@@ -0,0 +1,124 @@
+package geofence
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// GeoJSON input: Polygon and MultiPolygon features become polygon
+// geofences, Point features become circles and need a "radius_meters"
+// property. The geofence ID comes from the feature id or an "id" property;
+// "name" and "dwell_limit_seconds" properties are optional.
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type       string          `json:"type"`
+	ID         interface{}     `json:"id"`
+	Geometry   geometry        `json:"geometry"`
+	Properties json.RawMessage `json:"properties"`
+}
+
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+type featureProperties struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	RadiusMeters      float64 `json:"radius_meters"`
+	DwellLimitSeconds int64   `json:"dwell_limit_seconds"`
+}
+
+// ParseFeatureCollection converts a GeoJSON FeatureCollection into
+// validated geofences.
+func ParseFeatureCollection(data []byte) ([]Geofence, error) {
+	var fc featureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("expected a FeatureCollection, got %q", fc.Type)
+	}
+
+	fences := make([]Geofence, 0, len(fc.Features))
+	for i, f := range fc.Features {
+		g, err := parseFeature(f)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i, err)
+		}
+		fences = append(fences, g)
+	}
+	return fences, nil
+}
+
+func parseFeature(f feature) (Geofence, error) {
+	var props featureProperties
+	if len(f.Properties) > 0 && string(f.Properties) != "null" {
+		if err := json.Unmarshal(f.Properties, &props); err != nil {
+			return Geofence{}, fmt.Errorf("invalid properties: %w", err)
+		}
+	}
+
+	g := Geofence{
+		ID:                props.ID,
+		Name:              props.Name,
+		DwellLimitSeconds: props.DwellLimitSeconds,
+	}
+	if g.ID == "" && f.ID != nil {
+		g.ID = fmt.Sprint(f.ID)
+	}
+
+	switch f.Geometry.Type {
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &c); err != nil || len(c) < 2 {
+			return Geofence{}, fmt.Errorf("invalid Point coordinates")
+		}
+		g.Center = &geo.Point{Lat: c[1], Lon: c[0]}
+		g.RadiusMeters = props.RadiusMeters
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &rings); err != nil {
+			return Geofence{}, fmt.Errorf("invalid Polygon coordinates: %w", err)
+		}
+		g.Polygons = []geo.Polygon{toPolygon(rings)}
+	case "MultiPolygon":
+		var polys [][][][]float64
+		if err := json.Unmarshal(f.Geometry.Coordinates, &polys); err != nil {
+			return Geofence{}, fmt.Errorf("invalid MultiPolygon coordinates: %w", err)
+		}
+		for _, rings := range polys {
+			g.Polygons = append(g.Polygons, toPolygon(rings))
+		}
+	default:
+		return Geofence{}, fmt.Errorf("unsupported geometry type %q", f.Geometry.Type)
+	}
+
+	return g, g.Validate()
+}
+
+// toPolygon converts GeoJSON [lon, lat] rings; the first ring is the outer
+// boundary and the rest are holes.
+func toPolygon(rings [][][]float64) geo.Polygon {
+	var poly geo.Polygon
+	for i, ring := range rings {
+		pts := make([]geo.Point, 0, len(ring))
+		for _, c := range ring {
+			if len(c) >= 2 {
+				pts = append(pts, geo.Point{Lat: c[1], Lon: c[0]})
+			}
+		}
+		if i == 0 {
+			poly.Outer = pts
+		} else {
+			poly.Holes = append(poly.Holes, pts)
+		}
+	}
+	return poly
+}
@@ -0,0 +1,86 @@
+package geofence
+
+import (
+	"math"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+const (
+	// cellDegrees is the size of an index cell, roughly 1.1 km of latitude.
+	cellDegrees = 0.01
+	// maxCellsPerFence keeps very large fences out of the grid; they are
+	// tested against every point instead.
+	maxCellsPerFence = 4096
+)
+
+type cell struct{ x, y int32 }
+
+// Index is an immutable uniform-grid spatial index over a set of geofences.
+// A lookup tests only the fences whose bounding box overlaps the point's
+// cell, plus the few fences too large for the grid.
+type Index struct {
+	fences []Geofence
+	byID   map[string]int
+	cells  map[cell][]int
+	large  []int
+}
+
+func cellOf(lat, lon float64) cell {
+	return cell{x: int32(math.Floor(lon / cellDegrees)), y: int32(math.Floor(lat / cellDegrees))}
+}
+
+// NewIndex builds an index over fences, which must already be valid.
+func NewIndex(fences []Geofence) *Index {
+	idx := &Index{
+		fences: fences,
+		byID:   make(map[string]int, len(fences)),
+		cells:  make(map[cell][]int),
+	}
+	for i := range fences {
+		idx.byID[fences[i].ID] = i
+		b := fences[i].Bounds()
+		lo, hi := cellOf(b.MinLat, b.MinLon), cellOf(b.MaxLat, b.MaxLon)
+		if int64(hi.x-lo.x+1)*int64(hi.y-lo.y+1) > maxCellsPerFence {
+			idx.large = append(idx.large, i)
+			continue
+		}
+		for x := lo.x; x <= hi.x; x++ {
+			for y := lo.y; y <= hi.y; y++ {
+				c := cell{x, y}
+				idx.cells[c] = append(idx.cells[c], i)
+			}
+		}
+	}
+	return idx
+}
+
+// Containing returns the geofences that contain p.
+func (idx *Index) Containing(p geo.Point) []*Geofence {
+	var out []*Geofence
+	for _, i := range idx.cells[cellOf(p.Lat, p.Lon)] {
+		if idx.fences[i].Contains(p) {
+			out = append(out, &idx.fences[i])
+		}
+	}
+	for _, i := range idx.large {
+		if idx.fences[i].Contains(p) {
+			out = append(out, &idx.fences[i])
+		}
+	}
+	return out
+}
+
+// Get returns the geofence with the given ID.
+func (idx *Index) Get(id string) (*Geofence, bool) {
+	i, ok := idx.byID[id]
+	if !ok {
+		return nil, false
+	}
+	return &idx.fences[i], true
+}
+
+// Len returns the number of indexed geofences.
+func (idx *Index) Len() int {
+	return len(idx.fences)
+}
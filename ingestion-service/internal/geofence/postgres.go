@@ -0,0 +1,247 @@
+package geofence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// notifyChannel is the LISTEN/NOTIFY channel used to tell every geofence
+// service instance that geofences changed.
+const notifyChannel = "geofences_changed"
+
+// PostgresStore keeps every geofence version as a row of the append-only
+// geofence_versions table (tracking-service/migrations/init.sql); the
+// current geofence is its highest version.
+type PostgresStore struct {
+	db  *sql.DB
+	dsn string
+}
+
+// NewPostgresStore uses dsn for the LISTEN connection of Watch.
+func NewPostgresStore(db *sql.DB, dsn string) *PostgresStore {
+	return &PostgresStore{db: db, dsn: dsn}
+}
+
+// write appends a version in a transaction after checking the current one.
+// check receives the current version (nil if none) and may reject it.
+func (s *PostgresStore) write(ctx context.Context, g Geofence, deleted bool, check func(cur *Version) error) (Version, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Version{}, err
+	}
+	defer tx.Rollback()
+
+	next, err := appendVersion(ctx, tx, g, deleted, check)
+	if err != nil {
+		return Version{}, err
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, notifyChannel, g.ID); err != nil {
+		return Version{}, err
+	}
+	return next, tx.Commit()
+}
+
+// appendVersion inserts the next version of g within tx, locking the
+// current one, after check accepts it.
+func appendVersion(ctx context.Context, tx *sql.Tx, g Geofence, deleted bool, check func(cur *Version) error) (Version, error) {
+	body, err := json.Marshal(g)
+	if err != nil {
+		return Version{}, err
+	}
+
+	var cur *Version
+	var v Version
+	err = tx.QueryRowContext(ctx,
+		`SELECT version, deleted FROM geofence_versions WHERE geofence_id = $1 ORDER BY version DESC LIMIT 1 FOR UPDATE`,
+		g.ID).Scan(&v.Version, &v.Deleted)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return Version{}, err
+	default:
+		cur = &v
+	}
+	if err := check(cur); err != nil {
+		return Version{}, err
+	}
+
+	next := Version{Geofence: g, Version: 1, Deleted: deleted}
+	if cur != nil {
+		next.Version = cur.Version + 1
+	}
+	err = tx.QueryRowContext(ctx,
+		`INSERT INTO geofence_versions (geofence_id, version, body, deleted) VALUES ($1, $2, $3, $4) RETURNING created_at`,
+		g.ID, next.Version, body, deleted).Scan(&next.UpdatedAt)
+	if err != nil {
+		// Two creates of a new ID race on the primary key.
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return Version{}, ErrVersionConflict
+		}
+		return Version{}, err
+	}
+	return next, nil
+}
+
+func (s *PostgresStore) Create(ctx context.Context, g Geofence) (Version, error) {
+	return s.write(ctx, g, false, func(cur *Version) error {
+		if cur != nil && !cur.Deleted {
+			return ErrExists
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) Update(ctx context.Context, g Geofence, expectedVersion int64) (Version, error) {
+	return s.write(ctx, g, false, func(cur *Version) error {
+		if cur == nil || cur.Deleted {
+			return ErrNotFound
+		}
+		if expectedVersion != 0 && expectedVersion != cur.Version {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) Import(ctx context.Context, gs []Geofence) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	created := 0
+	for _, g := range gs {
+		_, err := appendVersion(ctx, tx, g, false, func(cur *Version) error {
+			if cur == nil || cur.Deleted {
+				created++
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("geofence %s: %w", g.ID, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, '')`, notifyChannel); err != nil {
+		return 0, err
+	}
+	return created, tx.Commit()
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, id string, expectedVersion int64) (int64, error) {
+	v, err := s.write(ctx, Geofence{ID: id}, true, func(cur *Version) error {
+		if cur == nil || cur.Deleted {
+			return ErrNotFound
+		}
+		if expectedVersion != 0 && expectedVersion != cur.Version {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+	return v.Version, err
+}
+
+func scanVersion(row interface{ Scan(...interface{}) error }) (Version, error) {
+	var v Version
+	var body []byte
+	if err := row.Scan(&v.ID, &v.Version, &body, &v.Deleted, &v.UpdatedAt); err != nil {
+		return Version{}, err
+	}
+	id := v.ID
+	if err := json.Unmarshal(body, &v.Geofence); err != nil {
+		return Version{}, fmt.Errorf("corrupt geofence %s version %d: %w", id, v.Version, err)
+	}
+	v.ID = id
+	return v, nil
+}
+
+func (s *PostgresStore) Get(ctx context.Context, id string, version int64) (Version, error) {
+	query := `SELECT geofence_id, version, body, deleted, created_at FROM geofence_versions
+		WHERE geofence_id = $1 AND ($2 = 0 OR version = $2) ORDER BY version DESC LIMIT 1`
+	v, err := scanVersion(s.db.QueryRowContext(ctx, query, id, version))
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && version == 0 && v.Deleted) {
+		return Version{}, ErrNotFound
+	}
+	return v, err
+}
+
+func (s *PostgresStore) List(ctx context.Context, afterID string, limit int) ([]Version, error) {
+	query := `SELECT geofence_id, version, body, deleted, created_at FROM (
+			SELECT DISTINCT ON (geofence_id) geofence_id, version, body, deleted, created_at
+			FROM geofence_versions WHERE geofence_id > $1
+			ORDER BY geofence_id, version DESC
+		) latest WHERE NOT deleted ORDER BY geofence_id`
+	args := []interface{}{afterID}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+	return s.query(ctx, query, args...)
+}
+
+func (s *PostgresStore) Empty(ctx context.Context) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM geofence_versions)`).Scan(&exists)
+	return !exists, err
+}
+
+func (s *PostgresStore) History(ctx context.Context, id string) ([]Version, error) {
+	vs, err := s.query(ctx, `SELECT geofence_id, version, body, deleted, created_at FROM geofence_versions
+		WHERE geofence_id = $1 ORDER BY version DESC`, id)
+	if err == nil && len(vs) == 0 {
+		return nil, ErrNotFound
+	}
+	return vs, err
+}
+
+func (s *PostgresStore) query(ctx context.Context, query string, args ...interface{}) ([]Version, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Version
+	for rows.Next() {
+		v, err := scanVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// Watch listens on the geofences_changed channel, so changes made through
+// any instance reach every evaluator. A reconnect also triggers onChange
+// because notifications may have been missed while disconnected.
+func (s *PostgresStore) Watch(ctx context.Context, onChange func()) {
+	listener := pq.NewListener(s.dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Warn("Geofence change listener", "event", ev, "error", err)
+		}
+	})
+	defer listener.Close()
+	if err := listener.Listen(notifyChannel); err != nil {
+		slog.Error("Failed to listen for geofence changes", "error", err)
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-listener.Notify:
+			// A nil notification signals a reconnect.
+			onChange()
+		case <-time.After(90 * time.Second):
+			go listener.Ping()
+		}
+	}
+}
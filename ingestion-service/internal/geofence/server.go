@@ -0,0 +1,230 @@
+package geofence
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// Server implements the GeofenceService gRPC API on top of a Store.
+type Server struct {
+	pb.UnimplementedGeofenceServiceServer
+	store Store
+}
+
+func NewServer(store Store) *Server {
+	return &Server{store: store}
+}
+
+func (s *Server) CreateGeofence(ctx context.Context, req *pb.CreateGeofenceRequest) (*pb.Geofence, error) {
+	g, err := fromProto(req.GetGeofence())
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.store.Create(ctx, g)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return toProto(v), nil
+}
+
+func (s *Server) UpdateGeofence(ctx context.Context, req *pb.UpdateGeofenceRequest) (*pb.Geofence, error) {
+	g, err := fromProto(req.GetGeofence())
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.store.Update(ctx, g, req.GetExpectedVersion())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return toProto(v), nil
+}
+
+func (s *Server) DeleteGeofence(ctx context.Context, req *pb.DeleteGeofenceRequest) (*pb.DeleteGeofenceResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	version, err := s.store.Delete(ctx, req.GetId(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return &pb.DeleteGeofenceResponse{Version: version}, nil
+}
+
+func (s *Server) GetGeofence(ctx context.Context, req *pb.GetGeofenceRequest) (*pb.Geofence, error) {
+	v, err := s.store.Get(ctx, req.GetId(), req.GetVersion())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return toProto(v), nil
+}
+
+// ListGeofences pages through current geofences in ID order. The page token
+// is the last ID of the previous page.
+func (s *Server) ListGeofences(ctx context.Context, req *pb.ListGeofencesRequest) (*pb.ListGeofencesResponse, error) {
+	size := int(req.GetPageSize())
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	after, err := base64.RawURLEncoding.DecodeString(req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid page token")
+	}
+
+	// Fetch one extra to know whether there is a next page.
+	vs, err := s.store.List(ctx, string(after), size+1)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	resp := &pb.ListGeofencesResponse{}
+	if len(vs) > size {
+		vs = vs[:size]
+		resp.NextPageToken = base64.RawURLEncoding.EncodeToString([]byte(vs[size-1].ID))
+	}
+	for _, v := range vs {
+		resp.Geofences = append(resp.Geofences, toProto(v))
+	}
+	return resp, nil
+}
+
+func (s *Server) ListGeofenceVersions(ctx context.Context, req *pb.ListGeofenceVersionsRequest) (*pb.ListGeofencesResponse, error) {
+	vs, err := s.store.History(ctx, req.GetId())
+	if err != nil {
+		return nil, storeError(err)
+	}
+	resp := &pb.ListGeofencesResponse{}
+	for _, v := range vs {
+		resp.Geofences = append(resp.Geofences, toProto(v))
+	}
+	return resp, nil
+}
+
+// ImportGeoJSON validates the whole collection, then creates new geofences
+// and updates existing ones in one transaction, so a failed import stores
+// nothing.
+func (s *Server) ImportGeoJSON(ctx context.Context, req *pb.ImportGeoJSONRequest) (*pb.ImportGeoJSONResponse, error) {
+	fences, err := ParseFeatureCollection(req.GetFeatureCollection())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	created, err := s.store.Import(ctx, fences)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	resp := &pb.ImportGeoJSONResponse{Created: int32(created), Updated: int32(len(fences) - created)}
+	for _, g := range fences {
+		resp.Ids = append(resp.Ids, g.ID)
+	}
+	return resp, nil
+}
+
+func storeError(err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, ErrVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func fromProto(in *pb.Geofence) (Geofence, error) {
+	if in == nil {
+		return Geofence{}, status.Error(codes.InvalidArgument, "geofence is required")
+	}
+	g := Geofence{
+		ID:                in.GetId(),
+		Name:              in.GetName(),
+		DwellLimitSeconds: in.GetDwellLimitSeconds(),
+	}
+	switch shape := in.GetShape().(type) {
+	case *pb.Geofence_Circle:
+		c := pointFromProto(shape.Circle.GetCenter())
+		g.Center = &c
+		g.RadiusMeters = shape.Circle.GetRadiusMeters()
+	case *pb.Geofence_Area:
+		for _, poly := range shape.Area.GetPolygons() {
+			p := geo.Polygon{Outer: pointsFromProto(poly.GetOuter())}
+			for _, hole := range poly.GetHoles() {
+				p.Holes = append(p.Holes, pointsFromProto(hole.GetPoints()))
+			}
+			g.Polygons = append(g.Polygons, p)
+		}
+	}
+	if err := g.Validate(); err != nil {
+		return Geofence{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return g, nil
+}
+
+func toProto(v Version) *pb.Geofence {
+	out := &pb.Geofence{
+		Id:                v.ID,
+		Name:              v.Name,
+		DwellLimitSeconds: v.DwellLimitSeconds,
+		Version:           v.Version,
+		UpdatedAt:         v.UpdatedAt.Unix(),
+		Deleted:           v.Deleted,
+	}
+	switch {
+	case v.Center != nil:
+		out.Shape = &pb.Geofence_Circle{Circle: &pb.Circle{
+			Center:       pointToProto(*v.Center),
+			RadiusMeters: v.RadiusMeters,
+		}}
+	case len(v.Polygons) > 0:
+		area := &pb.Area{}
+		for _, poly := range v.Polygons {
+			p := &pb.Polygon{Outer: pointsToProto(poly.Outer)}
+			for _, hole := range poly.Holes {
+				p.Holes = append(p.Holes, &pb.Ring{Points: pointsToProto(hole)})
+			}
+			area.Polygons = append(area.Polygons, p)
+		}
+		out.Shape = &pb.Geofence_Area{Area: area}
+	}
+	return out
+}
+
+func pointFromProto(p *pb.LatLng) geo.Point {
+	return geo.Point{Lat: p.GetLatitude(), Lon: p.GetLongitude()}
+}
+
+func pointToProto(p geo.Point) *pb.LatLng {
+	return &pb.LatLng{Latitude: p.Lat, Longitude: p.Lon}
+}
+
+func pointsFromProto(in []*pb.LatLng) []geo.Point {
+	out := make([]geo.Point, len(in))
+	for i, p := range in {
+		out[i] = pointFromProto(p)
+	}
+	return out
+}
+
+func pointsToProto(in []geo.Point) []*pb.LatLng {
+	out := make([]*pb.LatLng, len(in))
+	for i, p := range in {
+		out[i] = pointToProto(p)
+	}
+	return out
+}
@@ -0,0 +1,202 @@
+package geofence
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound        = errors.New("geofence not found")
+	ErrExists          = errors.New("geofence already exists")
+	ErrVersionConflict = errors.New("geofence was modified concurrently")
+)
+
+// Version is one stored revision of a geofence. Deleting a geofence stores
+// a tombstone version, so the full history survives deletes.
+type Version struct {
+	Geofence
+	Version   int64
+	UpdatedAt time.Time
+	Deleted   bool
+}
+
+// Store persists geofences with their version history.
+type Store interface {
+	// Create stores version 1 of a new geofence, or the next version of a
+	// deleted one.
+	Create(ctx context.Context, g Geofence) (Version, error)
+	// Update stores a new version of an existing geofence. A non-zero
+	// expectedVersion must match the current version.
+	Update(ctx context.Context, g Geofence, expectedVersion int64) (Version, error)
+	// Import creates or updates every geofence in one transaction: either
+	// all are stored or none are. It returns how many were created.
+	Import(ctx context.Context, gs []Geofence) (int, error)
+	// Delete stores a tombstone and returns its version.
+	Delete(ctx context.Context, id string, expectedVersion int64) (int64, error)
+	// Get returns a specific version, or the current one for version 0.
+	Get(ctx context.Context, id string, version int64) (Version, error)
+	// List returns current geofences ordered by ID, starting after afterID.
+	// limit <= 0 returns all of them.
+	List(ctx context.Context, afterID string, limit int) ([]Version, error)
+	// History returns every version of a geofence, newest first.
+	History(ctx context.Context, id string) ([]Version, error)
+	// Empty reports whether no geofence was ever stored, counting deleted
+	// ones.
+	Empty(ctx context.Context) (bool, error)
+	// Watch calls onChange after any change, including changes made by
+	// other processes where the store supports it, until ctx is done.
+	Watch(ctx context.Context, onChange func())
+}
+
+// MemoryStore keeps geofences in process memory. It is used when no
+// database is configured and does not survive restarts.
+type MemoryStore struct {
+	mu       sync.Mutex
+	versions map[string][]Version
+	watchers []chan struct{}
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{versions: make(map[string][]Version)}
+}
+
+func (s *MemoryStore) current(id string) (Version, bool) {
+	vs := s.versions[id]
+	if len(vs) == 0 {
+		return Version{}, false
+	}
+	return vs[len(vs)-1], true
+}
+
+func (s *MemoryStore) append(g Geofence, deleted bool) Version {
+	cur, _ := s.current(g.ID)
+	v := Version{Geofence: g, Version: cur.Version + 1, UpdatedAt: time.Now(), Deleted: deleted}
+	s.versions[g.ID] = append(s.versions[g.ID], v)
+	for _, w := range s.watchers {
+		select {
+		case w <- struct{}{}:
+		default:
+		}
+	}
+	return v
+}
+
+func (s *MemoryStore) Create(ctx context.Context, g Geofence) (Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cur, ok := s.current(g.ID); ok && !cur.Deleted {
+		return Version{}, ErrExists
+	}
+	return s.append(g, false), nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, g Geofence, expectedVersion int64) (Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cur, ok := s.current(g.ID)
+	if !ok || cur.Deleted {
+		return Version{}, ErrNotFound
+	}
+	if expectedVersion != 0 && expectedVersion != cur.Version {
+		return Version{}, ErrVersionConflict
+	}
+	return s.append(g, false), nil
+}
+
+func (s *MemoryStore) Import(ctx context.Context, gs []Geofence) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	created := 0
+	for _, g := range gs {
+		if cur, ok := s.current(g.ID); !ok || cur.Deleted {
+			created++
+		}
+		s.append(g, false)
+	}
+	return created, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string, expectedVersion int64) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cur, ok := s.current(id)
+	if !ok || cur.Deleted {
+		return 0, ErrNotFound
+	}
+	if expectedVersion != 0 && expectedVersion != cur.Version {
+		return 0, ErrVersionConflict
+	}
+	return s.append(Geofence{ID: id}, true).Version, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id string, version int64) (Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if version == 0 {
+		cur, ok := s.current(id)
+		if !ok || cur.Deleted {
+			return Version{}, ErrNotFound
+		}
+		return cur, nil
+	}
+	for _, v := range s.versions[id] {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return Version{}, ErrNotFound
+}
+
+func (s *MemoryStore) List(ctx context.Context, afterID string, limit int) ([]Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Version
+	for id := range s.versions {
+		if cur, _ := s.current(id); id > afterID && !cur.Deleted {
+			out = append(out, cur)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) Empty(ctx context.Context) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.versions) == 0, nil
+}
+
+func (s *MemoryStore) History(ctx context.Context, id string) ([]Version, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	vs := s.versions[id]
+	if len(vs) == 0 {
+		return nil, ErrNotFound
+	}
+	out := make([]Version, len(vs))
+	for i, v := range vs {
+		out[len(vs)-1-i] = v
+	}
+	return out, nil
+}
+
+func (s *MemoryStore) Watch(ctx context.Context, onChange func()) {
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.watchers = append(s.watchers, ch)
+	s.mu.Unlock()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+			onChange()
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package history
+
+import (
+	"encoding/json"
+	"math"
+)
+
+type feature struct {
+	Type       string          `json:"type"`
+	Geometry   *geometry       `json:"geometry"`
+	Properties trackProperties `json:"properties"`
+}
+
+type geometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+type trackProperties struct {
+	VehicleID string `json:"vehicle_id"`
+	// Timestamps holds each position's Unix timestamp, in order.
+	Timestamps []int64 `json:"timestamps"`
+}
+
+// TrackGeoJSON renders a track as a GeoJSON Feature: a LineString, a Point
+// for a single position, or a null geometry for an empty track.
+// Coordinates are rounded to about 10 cm.
+func TrackGeoJSON(vehicleID string, points []Point) ([]byte, error) {
+	f := feature{
+		Type:       "Feature",
+		Properties: trackProperties{VehicleID: vehicleID, Timestamps: make([]int64, len(points))},
+	}
+	coords := make([][2]float64, len(points))
+	for i, p := range points {
+		coords[i] = [2]float64{math.Round(p.Lon*1e6) / 1e6, math.Round(p.Lat*1e6) / 1e6}
+		f.Properties.Timestamps[i] = p.Timestamp
+	}
+	switch len(points) {
+	case 0:
+	case 1:
+		f.Geometry = &geometry{Type: "Point", Coordinates: coords[0]}
+	default:
+		f.Geometry = &geometry{Type: "LineString", Coordinates: coords}
+	}
+	return json.Marshal(f)
+}
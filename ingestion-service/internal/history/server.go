@@ -0,0 +1,163 @@
+package history
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+)
+
+const (
+	defaultPageSize = 1000
+	maxPageSize     = 10_000
+	maxPoints       = 10_000
+	// maxScanPoints bounds the rows read to downsample one range.
+	maxScanPoints = 1_000_000
+)
+
+// Server implements the HistoryService gRPC API.
+type Server struct {
+	pb.UnimplementedHistoryServiceServer
+	store Store
+	now   func() time.Time
+}
+
+func NewServer(store Store) *Server {
+	return &Server{store: store, now: time.Now}
+}
+
+func (s *Server) GetTrack(ctx context.Context, req *pb.GetTrackRequest) (*pb.GetTrackResponse, error) {
+	q, err := s.query(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var next string
+	n := int(req.GetMaxPoints())
+	if n > 0 {
+		q.Limit = maxScanPoints + 1
+	}
+	points, err := s.store.Track(ctx, q)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	source := len(points)
+	if n > 0 {
+		if len(points) > maxScanPoints {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"time range holds more than %d points; narrow it or page through it without max_points", maxScanPoints)
+		}
+		points = downsample(points, n)
+	} else if len(points) > q.Limit-1 {
+		points = points[:q.Limit-1]
+		source = len(points)
+		last := points[len(points)-1]
+		next = encodeCursor(Cursor{Timestamp: last.Timestamp, ID: last.ID})
+	}
+
+	resp := &pb.GetTrackResponse{NextPageToken: next, SourcePoints: int32(source)}
+	switch req.GetFormat() {
+	case pb.TrackFormat_TRACK_FORMAT_GEOJSON:
+		resp.Geojson, err = TrackGeoJSON(req.GetVehicleId(), points)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	case pb.TrackFormat_TRACK_FORMAT_POLYLINE:
+		path := make([]geo.Point, len(points))
+		resp.Timestamps = make([]int64, len(points))
+		for i, p := range points {
+			path[i] = p.Point
+			resp.Timestamps[i] = p.Timestamp
+		}
+		resp.Polyline = geo.EncodePolyline(path)
+	default:
+		resp.Points = make([]*pb.TrackPoint, len(points))
+		for i, p := range points {
+			resp.Points[i] = &pb.TrackPoint{
+				Position:  &pb.LatLng{Latitude: p.Lat, Longitude: p.Lon},
+				Timestamp: p.Timestamp,
+			}
+		}
+	}
+	return resp, nil
+}
+
+// query validates a request. Its Limit is one more than the page size so
+// the presence of a further page can be detected.
+func (s *Server) query(req *pb.GetTrackRequest) (Query, error) {
+	q := Query{VehicleID: req.GetVehicleId(), Start: req.GetStartTime(), End: req.GetEndTime()}
+	if q.VehicleID == "" {
+		return q, status.Error(codes.InvalidArgument, "vehicle_id is required")
+	}
+	if q.End == 0 {
+		q.End = s.now().Unix() + 1
+	}
+	if q.Start < 0 || q.Start >= q.End {
+		return q, status.Error(codes.InvalidArgument, "start_time must be before end_time")
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize < 0 || pageSize > maxPageSize {
+		return q, status.Errorf(codes.InvalidArgument, "page_size must be between 1 and %d", maxPageSize)
+	}
+	q.Limit = pageSize + 1
+	if n := req.GetMaxPoints(); n < 0 || n > maxPoints {
+		return q, status.Errorf(codes.InvalidArgument, "max_points must be between 1 and %d", maxPoints)
+	}
+	if token := req.GetPageToken(); token != "" {
+		if req.GetMaxPoints() > 0 {
+			return q, status.Error(codes.InvalidArgument, "page_token cannot be used with max_points")
+		}
+		c, err := decodeCursor(token)
+		if err != nil {
+			return q, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		q.After = &c
+	}
+	switch req.GetFormat() {
+	case pb.TrackFormat_TRACK_FORMAT_POINTS, pb.TrackFormat_TRACK_FORMAT_GEOJSON, pb.TrackFormat_TRACK_FORMAT_POLYLINE:
+	default:
+		return q, status.Errorf(codes.InvalidArgument, "unknown format %d", req.GetFormat())
+	}
+	return q, nil
+}
+
+func downsample(points []Point, n int) []Point {
+	path := make([]geo.Point, len(points))
+	for i, p := range points {
+		path[i] = p.Point
+	}
+	idx := geo.Downsample(path, n)
+	out := make([]Point, len(idx))
+	for i, j := range idx {
+		out[i] = points[j]
+	}
+	return out
+}
+
+func encodeCursor(c Cursor) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d", c.Timestamp, c.ID))
+}
+
+func decodeCursor(token string) (Cursor, error) {
+	var c Cursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, err
+	}
+	if _, err := fmt.Sscanf(string(data), "%d:%d", &c.Timestamp, &c.ID); err != nil {
+		return c, err
+	}
+	return c, nil
+}
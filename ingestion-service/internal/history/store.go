@@ -0,0 +1,82 @@
+// Package history queries the location history persisted in
+// vehicle_locations.
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// Point is one stored location.
+type Point struct {
+	ID int64
+	geo.Point
+	Timestamp int64
+}
+
+// Cursor is a position in a track, ordered by timestamp and then row ID.
+type Cursor struct {
+	Timestamp int64
+	ID        int64
+}
+
+// Query selects a page of a vehicle's track.
+type Query struct {
+	VehicleID string
+	// Start is inclusive and End exclusive (Unix seconds).
+	Start, End int64
+	// After resumes a track after the last point of the previous page.
+	After *Cursor
+	Limit int
+}
+
+// Store reads tracks.
+type Store interface {
+	// Track returns up to q.Limit points in (timestamp, ID) order.
+	Track(ctx context.Context, q Query) ([]Point, error)
+}
+
+// PostgresStore reads vehicle_locations. Keyset pagination on
+// (timestamp, id) uses the (vehicle_id, timestamp) index however deep the
+// page, unlike OFFSET.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Track(ctx context.Context, q Query) ([]Point, error) {
+	// Row IDs start at 1, so (Start, 0) precedes every point in range.
+	after := Cursor{Timestamp: q.Start}
+	if q.After != nil {
+		after = *q.After
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, latitude, longitude, timestamp FROM vehicle_locations
+		WHERE vehicle_id = $1 AND timestamp >= $2 AND timestamp < $3
+		  AND (timestamp, id) > ($4, $5)
+		ORDER BY timestamp, id LIMIT $6`,
+		q.VehicleID, q.Start, q.End, after.Timestamp, after.ID, q.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query track: %w", err)
+	}
+	defer rows.Close()
+
+	var points []Point
+	for rows.Next() {
+		var p Point
+		if err := rows.Scan(&p.ID, &p.Lat, &p.Lon, &p.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan track point: %w", err)
+		}
+		points = append(points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read track: %w", err)
+	}
+	return points, nil
+}
@@ -0,0 +1,404 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	consumedRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_consumer_records_total",
+		Help: "Records consumed, by consumer group and topic",
+	}, []string{"group", "topic"})
+	handlerErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_consumer_handler_errors_total",
+		Help: "Records whose handler returned an error and were skipped, by consumer group and topic",
+	}, []string{"group", "topic"})
+	batchErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kafka_consumer_batch_errors_total",
+		Help: "Failed attempts to handle a batch, which is then retried, by consumer group",
+	}, []string{"group"})
+	consumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kafka_consumer_lag",
+		Help: "Records between the consumer's position and the end of each assigned partition",
+	}, []string{"group", "topic", "partition"})
+)
+
+// lagInterval is how often the consumer lag gauge is refreshed.
+const lagInterval = 10 * time.Second
+
+type ConsumerConfig struct {
+	Brokers string
+	GroupID string
+	Topics  []string
+	// OffsetReset is where a group without committed offsets starts:
+	// "earliest" or "latest" (default).
+	OffsetReset string
+	// CoPartitioned assigns partition i of every topic to the same member,
+	// for consumers that join topics by key. It uses the range assignor
+	// and requires the topics to have the same number of partitions.
+	// Producers of every topic must also use the same partitioner: the Go
+	// services (librdkafka, crc32) and the Java ones (murmur2) do not.
+	CoPartitioned bool
+	// ManualOffsets stops Run and RunBatch from storing or committing the
+	// offsets of handled records. The caller stores them with StoreOffsets
+	// once the records' effects are durable, and they are committed
+	// periodically.
+	ManualOffsets bool
+	// StartAt, when set, starts each partition, the first time it is
+	// assigned, at its first record timestamped at or after StartAt
+	// instead of at the committed offset. Consumers that load a snapshot
+	// first set it to just before loading, to read every record published
+	// since.
+	StartAt time.Time
+}
+
+// Record is a consumed Kafka message.
+type Record struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Timestamp time.Time
+}
+
+// Consumer reads topics as a member of a consumer group.
+type Consumer struct {
+	consumer *kafka.Consumer
+	cfg      ConsumerConfig
+	// started holds the partitions already started at cfg.StartAt.
+	started map[topicPartition]bool
+}
+
+func NewConsumer(cfg ConsumerConfig) (*Consumer, error) {
+	if cfg.OffsetReset == "" {
+		cfg.OffsetReset = "latest"
+	}
+	conf := &kafka.ConfigMap{
+		"bootstrap.servers":  cfg.Brokers,
+		"group.id":           cfg.GroupID,
+		"auto.offset.reset":  cfg.OffsetReset,
+		"isolation.level":    "read_committed",
+		"enable.auto.commit": true,
+		// Offsets are stored explicitly once a record has been handled.
+		"enable.auto.offset.store": false,
+	}
+	if cfg.CoPartitioned {
+		conf.SetKey("partition.assignment.strategy", "range")
+	}
+	c, err := kafka.NewConsumer(conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka consumer: %w", err)
+	}
+	if cfg.CoPartitioned {
+		if err := checkCoPartitioned(c, cfg.Topics); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	cons := &Consumer{consumer: c, cfg: cfg, started: make(map[topicPartition]bool)}
+	var rebalance kafka.RebalanceCb
+	if !cfg.StartAt.IsZero() {
+		rebalance = cons.assignFromStart
+	}
+	if err := c.SubscribeTopics(cfg.Topics, rebalance); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to subscribe to %v: %w", cfg.Topics, err)
+	}
+	return cons, nil
+}
+
+// assignFromStart assigns partitions new to this consumer from their
+// offsets at cfg.StartAt. Other events get the default handling.
+func (c *Consumer) assignFromStart(kc *kafka.Consumer, ev kafka.Event) error {
+	assigned, ok := ev.(kafka.AssignedPartitions)
+	if !ok {
+		return nil
+	}
+	var times []kafka.TopicPartition
+	for _, tp := range assigned.Partitions {
+		if !c.started[topicPartition{*tp.Topic, tp.Partition}] {
+			tp.Offset = kafka.Offset(c.cfg.StartAt.UnixMilli())
+			times = append(times, tp)
+		}
+	}
+	if len(times) == 0 {
+		return nil
+	}
+	offsets, err := kc.OffsetsForTimes(times, 10000)
+	if err != nil {
+		slog.Warn("Failed to look up start offsets, using committed offsets", "error", err)
+		return nil
+	}
+	start := make(map[topicPartition]kafka.Offset, len(offsets))
+	for _, tp := range offsets {
+		if tp.Error == nil {
+			start[topicPartition{*tp.Topic, tp.Partition}] = tp.Offset
+		}
+	}
+	partitions := make([]kafka.TopicPartition, len(assigned.Partitions))
+	for i, tp := range assigned.Partitions {
+		key := topicPartition{*tp.Topic, tp.Partition}
+		if off, ok := start[key]; ok {
+			// A partition with nothing since StartAt starts at its end.
+			tp.Offset = off
+			c.started[key] = true
+		}
+		partitions[i] = tp
+	}
+	return kc.Assign(partitions)
+}
+
+// checkCoPartitioned fails if the topics that exist have different numbers
+// of partitions. Topics not created yet are skipped.
+func checkCoPartitioned(c *kafka.Consumer, topics []string) error {
+	first, firstCount := "", 0
+	for _, topic := range topics {
+		md, err := c.GetMetadata(&topic, false, 10000)
+		if err != nil {
+			return fmt.Errorf("failed to get metadata for %s: %w", topic, err)
+		}
+		tm, ok := md.Topics[topic]
+		if !ok || tm.Error.Code() == kafka.ErrUnknownTopicOrPart || len(tm.Partitions) == 0 {
+			slog.Warn("Topic does not exist yet, cannot check co-partitioning", "topic", topic)
+			continue
+		}
+		if first == "" {
+			first, firstCount = topic, len(tm.Partitions)
+		} else if len(tm.Partitions) != firstCount {
+			return fmt.Errorf("topics %s and %s must have the same number of partitions, have %d and %d",
+				first, topic, firstCount, len(tm.Partitions))
+		}
+	}
+	return nil
+}
+
+// Run polls until ctx is cancelled and calls handle for every record. A
+// record's offset is stored for commit once handle returns, so delivery is
+// at-least-once. Handler errors are logged and the record is skipped so a
+// single malformed message cannot stall the partition, except when ctx was
+// cancelled, in which case Run returns and the record is redelivered.
+func (c *Consumer) Run(ctx context.Context, handle func(context.Context, Record) error) error {
+	lastLag := time.Now()
+	for ctx.Err() == nil {
+		if time.Since(lastLag) >= lagInterval {
+			c.reportLag()
+			lastLag = time.Now()
+		}
+		switch ev := c.consumer.Poll(100).(type) {
+		case *kafka.Message:
+			rec := newRecord(ev)
+			consumedRecords.WithLabelValues(c.cfg.GroupID, rec.Topic).Inc()
+			if err := handle(ctx, rec); err != nil {
+				if ctx.Err() != nil {
+					// Interrupted by shutdown: leave the record for
+					// redelivery rather than skipping it.
+					return nil
+				}
+				handlerErrors.WithLabelValues(c.cfg.GroupID, rec.Topic).Inc()
+				slog.ErrorContext(ctx, "Failed to handle record", "error", err,
+					"topic", rec.Topic, "partition", rec.Partition, "offset", rec.Offset)
+			}
+			if c.cfg.ManualOffsets {
+				continue
+			}
+			if _, err := c.consumer.StoreMessage(ev); err != nil {
+				slog.WarnContext(ctx, "Failed to store offset", "error", err)
+			}
+		case kafka.Error:
+			slog.ErrorContext(ctx, "Kafka consumer error", "error", ev, "code", ev.Code().String())
+			if ev.IsFatal() {
+				return ev
+			}
+		}
+	}
+	return nil
+}
+
+// BatchConfig bounds the batches passed to RunBatch.
+type BatchConfig struct {
+	// MaxSize is the most records in one batch.
+	MaxSize int
+	// MaxWait is how long the first record of a batch waits for more.
+	MaxWait time.Duration
+}
+
+// RunBatch polls until ctx is cancelled and calls handle with batches of up
+// to MaxSize records, cut short after MaxWait. Unlike Run, a failed batch is
+// retried with backoff rather than skipped, and offsets are committed
+// synchronously only once handle has succeeded, so a batch is never
+// acknowledged before it is durable. Delivery is at-least-once: a crash or
+// rebalance mid-batch redelivers the batch.
+func (c *Consumer) RunBatch(ctx context.Context, cfg BatchConfig, handle func(context.Context, []Record) error) error {
+	var (
+		batch    []Record
+		messages []*kafka.Message
+		deadline time.Time
+		lastLag  = time.Now()
+	)
+	for ctx.Err() == nil {
+		if time.Since(lastLag) >= lagInterval {
+			c.reportLag()
+			lastLag = time.Now()
+		}
+
+		timeout := 100
+		if len(batch) > 0 {
+			timeout = int(time.Until(deadline).Milliseconds())
+		}
+		if timeout > 0 {
+			switch ev := c.consumer.Poll(timeout).(type) {
+			case *kafka.Message:
+				rec := newRecord(ev)
+				consumedRecords.WithLabelValues(c.cfg.GroupID, rec.Topic).Inc()
+				if len(batch) == 0 {
+					deadline = time.Now().Add(cfg.MaxWait)
+				}
+				batch = append(batch, rec)
+				messages = append(messages, ev)
+			case kafka.Error:
+				slog.ErrorContext(ctx, "Kafka consumer error", "error", ev, "code", ev.Code().String())
+				if ev.IsFatal() {
+					return ev
+				}
+			}
+		}
+		if len(batch) == 0 || (len(batch) < cfg.MaxSize && time.Now().Before(deadline)) {
+			continue
+		}
+
+		if err := c.handleBatch(ctx, batch, handle); err != nil {
+			// Only cancellation stops the retries; the batch is left
+			// uncommitted for the next owner of its partitions.
+			return nil
+		}
+		if c.cfg.ManualOffsets {
+			// The caller stores offsets itself.
+		} else if err := c.commit(messages); err != nil {
+			slog.WarnContext(ctx, "Failed to commit offsets", "error", err)
+		}
+		batch, messages = batch[:0], messages[:0]
+	}
+	return nil
+}
+
+// handleBatch calls handle until it succeeds or ctx is cancelled.
+func (c *Consumer) handleBatch(ctx context.Context, batch []Record, handle func(context.Context, []Record) error) error {
+	backoff := 100 * time.Millisecond
+	for {
+		err := handle(ctx, batch)
+		if err == nil {
+			return nil
+		}
+		batchErrors.WithLabelValues(c.cfg.GroupID).Inc()
+		slog.ErrorContext(ctx, "Failed to handle batch, retrying", "error", err, "records", len(batch), "backoff", backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, 30*time.Second)
+	}
+}
+
+// commit synchronously commits the offsets following the last message of
+// each partition in a handled batch.
+func (c *Consumer) commit(messages []*kafka.Message) error {
+	type key struct {
+		topic     string
+		partition int32
+	}
+	next := make(map[key]kafka.TopicPartition)
+	for _, m := range messages {
+		tp := m.TopicPartition
+		k := key{*tp.Topic, tp.Partition}
+		if cur, ok := next[k]; !ok || tp.Offset+1 > cur.Offset {
+			tp.Offset++
+			next[k] = tp
+		}
+	}
+	offsets := make([]kafka.TopicPartition, 0, len(next))
+	for _, tp := range next {
+		offsets = append(offsets, tp)
+	}
+	_, err := c.consumer.CommitOffsets(offsets)
+	return err
+}
+
+// StoreOffsets stores the offset to commit next for each position's
+// partition, skipping partitions no longer assigned to this consumer.
+func (c *Consumer) StoreOffsets(positions []Position) error {
+	assigned, err := c.consumer.Assignment()
+	if err != nil {
+		return fmt.Errorf("failed to get assignment: %w", err)
+	}
+	owned := make(map[topicPartition]bool, len(assigned))
+	for _, tp := range assigned {
+		owned[topicPartition{*tp.Topic, tp.Partition}] = true
+	}
+	offsets := make([]kafka.TopicPartition, 0, len(positions))
+	for _, pos := range positions {
+		if owned[topicPartition{pos.Topic, pos.Partition}] {
+			topic := pos.Topic
+			offsets = append(offsets, kafka.TopicPartition{Topic: &topic, Partition: pos.Partition, Offset: kafka.Offset(pos.Offset)})
+		}
+	}
+	if len(offsets) == 0 {
+		return nil
+	}
+	if _, err := c.consumer.StoreOffsets(offsets); err != nil {
+		return fmt.Errorf("failed to store offsets: %w", err)
+	}
+	return nil
+}
+
+// reportLag updates the lag gauge from the consumer's position and the
+// cached high watermark of each assigned partition.
+func (c *Consumer) reportLag() {
+	assigned, err := c.consumer.Assignment()
+	if err != nil || len(assigned) == 0 {
+		return
+	}
+	positions, err := c.consumer.Position(assigned)
+	if err != nil {
+		return
+	}
+	for _, tp := range positions {
+		_, high, err := c.consumer.GetWatermarkOffsets(*tp.Topic, tp.Partition)
+		if err != nil || tp.Offset < 0 || high < 0 {
+			continue
+		}
+		consumerLag.WithLabelValues(c.cfg.GroupID, *tp.Topic, fmt.Sprint(tp.Partition)).Set(float64(high - int64(tp.Offset)))
+	}
+}
+
+func newRecord(m *kafka.Message) Record {
+	rec := Record{
+		Topic:     *m.TopicPartition.Topic,
+		Partition: m.TopicPartition.Partition,
+		Offset:    int64(m.TopicPartition.Offset),
+		Key:       m.Key,
+		Value:     m.Value,
+		Timestamp: m.Timestamp,
+	}
+	if len(m.Headers) > 0 {
+		rec.Headers = make(map[string]string, len(m.Headers))
+		for _, h := range m.Headers {
+			rec.Headers[h.Key] = string(h.Value)
+		}
+	}
+	return rec
+}
+
+// Close commits stored offsets and leaves the consumer group.
+func (c *Consumer) Close() error {
+	return c.consumer.Close()
+}
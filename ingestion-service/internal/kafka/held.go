@@ -0,0 +1,94 @@
+package kafka
+
+import "sync"
+
+// Position identifies a consumed record.
+type Position struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+}
+
+func (r Record) Position() Position {
+	return Position{Topic: r.Topic, Partition: r.Partition, Offset: r.Offset}
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// Held tracks records whose effects exist only in memory, such as pings
+// buffered in a reorder window, so that offsets are committed no further
+// than the oldest of them and a crash redelivers them. It is safe for
+// concurrent use.
+type Held struct {
+	mu    sync.Mutex
+	parts map[topicPartition]*heldPartition
+}
+
+type heldPartition struct {
+	// next is the offset after the last record seen.
+	next int64
+	// held counts the held records by offset.
+	held map[int64]int
+}
+
+func NewHeld() *Held {
+	return &Held{parts: make(map[topicPartition]*heldPartition)}
+}
+
+func (h *Held) partition(pos Position) *heldPartition {
+	tp := topicPartition{pos.Topic, pos.Partition}
+	p, ok := h.parts[tp]
+	if !ok {
+		p = &heldPartition{held: make(map[int64]int)}
+		h.parts[tp] = p
+	}
+	return p
+}
+
+// Seen records that the record at pos was handled. Unless it is held, its
+// offset can be committed.
+func (h *Held) Seen(pos Position) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	p := h.partition(pos)
+	p.next = max(p.next, pos.Offset+1)
+}
+
+// Hold keeps the offset of the record at pos from being committed until
+// Release is called for it.
+func (h *Held) Hold(pos Position) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.partition(pos).held[pos.Offset]++
+}
+
+// Release ends a Hold.
+func (h *Held) Release(pos Position) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	p := h.partition(pos)
+	if p.held[pos.Offset]--; p.held[pos.Offset] <= 0 {
+		delete(p.held, pos.Offset)
+	}
+}
+
+// Offsets returns the offset to commit for each partition seen: that of
+// its oldest held record, or the one after its last record seen.
+func (h *Held) Offsets() []Position {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]Position, 0, len(h.parts))
+	for tp, p := range h.parts {
+		next := p.next
+		for off := range p.held {
+			next = min(next, off)
+		}
+		if next > 0 {
+			out = append(out, Position{Topic: tp.topic, Partition: tp.partition, Offset: next})
+		}
+	}
+	return out
+}
@@ -0,0 +1,220 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	produceSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ingestion_kafka_produce_duration_seconds",
+		Help:    "Time from enqueueing a message to receiving its delivery report",
+		Buckets: []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"topic"})
+	produceFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ingestion_kafka_produce_failures_total",
+		Help: "Messages that could not be produced, by topic and error class",
+	}, []string{"topic", "reason"})
+	clientErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ingestion_kafka_client_errors_total",
+		Help: "Asynchronous client-level errors reported by librdkafka, by error class",
+	}, []string{"reason"})
+	producerInfo = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "ingestion_kafka_producer_info",
+		Help: "Producer delivery guarantees: mode, whether retries are deduplicated (idempotent) and whether batches commit atomically (transactional)",
+	}, []string{"mode", "idempotent", "transactional"})
+	producerRecreations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ingestion_kafka_producer_recreations_total",
+		Help: "Times the producer was torn down and re-created, by the error class that caused it",
+	}, []string{"reason"})
+	transactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ingestion_kafka_transactions_total",
+		Help: "Producer transactions by outcome (committed, aborted, failed)",
+	}, []string{"result"})
+)
+
+// errorClass maps a produce error to a small, fixed set of label values so
+// the failure counter stays low-cardinality.
+func errorClass(err error) string {
+	var kerr kafka.Error
+	if !errors.As(err, &kerr) {
+		var jerr *json.UnsupportedTypeError
+		var verr *json.UnsupportedValueError
+		if errors.As(err, &jerr) || errors.As(err, &verr) {
+			return "serialization"
+		}
+		return "other"
+	}
+
+	switch kerr.Code() {
+	case kafka.ErrQueueFull:
+		return "queue_full"
+	case kafka.ErrMsgTimedOut, kafka.ErrTimedOut, kafka.ErrRequestTimedOut:
+		return "timeout"
+	case kafka.ErrMsgSizeTooLarge:
+		return "message_too_large"
+	case kafka.ErrTransport, kafka.ErrAllBrokersDown, kafka.ErrNetworkException:
+		return "broker_unavailable"
+	case kafka.ErrUnknownTopicOrPart, kafka.ErrUnknownTopic, kafka.ErrUnknownPartition:
+		return "unknown_topic"
+	case kafka.ErrNotLeaderForPartition, kafka.ErrLeaderNotAvailable:
+		return "leader_unavailable"
+	case kafka.ErrTopicAuthorizationFailed, kafka.ErrClusterAuthorizationFailed:
+		return "authorization"
+	}
+	if kerr.IsFatal() {
+		return "fatal"
+	}
+	return "other"
+}
+
+// statsCollector exposes the most recent librdkafka statistics snapshot
+// (enabled with statistics.interval.ms) as Prometheus metrics. Cumulative
+// librdkafka counters are exported as counters so rate() gives throughput.
+type statsCollector struct {
+	mu    sync.Mutex
+	stats map[string]*rdkafkaStats // keyed by client name
+
+	queueMessages  *prometheus.Desc
+	queueBytes     *prometheus.Desc
+	queueMaxMsgs   *prometheus.Desc
+	brokerRTT      *prometheus.Desc
+	brokerOutbuf   *prometheus.Desc
+	brokerWaitResp *prometheus.Desc
+	partQueue      *prometheus.Desc
+	partTxMsgs     *prometheus.Desc
+	partTxBytes    *prometheus.Desc
+}
+
+// rdkafkaStats is the subset of the librdkafka statistics JSON we export.
+// See STATISTICS.md in the librdkafka repository for the full schema.
+type rdkafkaStats struct {
+	Name    string `json:"name"`
+	MsgCnt  int64  `json:"msg_cnt"`
+	MsgSize int64  `json:"msg_size"`
+	MsgMax  int64  `json:"msg_max"`
+	Brokers map[string]struct {
+		Name        string `json:"name"`
+		NodeID      int32  `json:"nodeid"`
+		OutbufCnt   int64  `json:"outbuf_msg_cnt"`
+		WaitRespCnt int64  `json:"waitresp_msg_cnt"`
+		RTT         struct {
+			Avg int64 `json:"avg"`
+			P99 int64 `json:"p99"`
+		} `json:"rtt"`
+	} `json:"brokers"`
+	Topics map[string]struct {
+		Partitions map[string]struct {
+			Partition int32 `json:"partition"`
+			MsgqCnt   int64 `json:"msgq_cnt"`
+			XmitMsgq  int64 `json:"xmit_msgq_cnt"`
+			TxMsgs    int64 `json:"txmsgs"`
+			TxBytes   int64 `json:"txbytes"`
+		} `json:"partitions"`
+	} `json:"topics"`
+}
+
+var kafkaStats = newStatsCollector()
+
+func init() {
+	prometheus.MustRegister(kafkaStats)
+}
+
+func newStatsCollector() *statsCollector {
+	client := []string{"client"}
+	return &statsCollector{
+		stats: make(map[string]*rdkafkaStats),
+		queueMessages: prometheus.NewDesc("ingestion_kafka_producer_queue_messages",
+			"Messages waiting in the producer queue", client, nil),
+		queueBytes: prometheus.NewDesc("ingestion_kafka_producer_queue_bytes",
+			"Bytes waiting in the producer queue", client, nil),
+		queueMaxMsgs: prometheus.NewDesc("ingestion_kafka_producer_queue_max_messages",
+			"Configured producer queue capacity (queue.buffering.max.messages)", client, nil),
+		brokerRTT: prometheus.NewDesc("ingestion_kafka_broker_rtt_seconds",
+			"Broker round-trip time over the last statistics window", []string{"client", "broker", "stat"}, nil),
+		brokerOutbuf: prometheus.NewDesc("ingestion_kafka_broker_outbuf_messages",
+			"Messages waiting to be sent to the broker", []string{"client", "broker"}, nil),
+		brokerWaitResp: prometheus.NewDesc("ingestion_kafka_broker_waitresp_messages",
+			"Messages sent to the broker and awaiting a response", []string{"client", "broker"}, nil),
+		partQueue: prometheus.NewDesc("ingestion_kafka_partition_queue_messages",
+			"Messages queued for a partition, including those being transmitted", []string{"client", "topic", "partition"}, nil),
+		partTxMsgs: prometheus.NewDesc("ingestion_kafka_partition_tx_messages_total",
+			"Messages transmitted to a partition", []string{"client", "topic", "partition"}, nil),
+		partTxBytes: prometheus.NewDesc("ingestion_kafka_partition_tx_bytes_total",
+			"Bytes transmitted to a partition", []string{"client", "topic", "partition"}, nil),
+	}
+}
+
+// update parses a librdkafka statistics JSON document and replaces the
+// snapshot for the client that emitted it.
+func (c *statsCollector) update(raw string) error {
+	var s rdkafkaStats
+	if err := json.Unmarshal([]byte(raw), &s); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.stats[s.Name] = &s
+	c.mu.Unlock()
+	return nil
+}
+
+// forget drops the snapshot for a closed client so its series disappear.
+func (c *statsCollector) forget(name string) {
+	c.mu.Lock()
+	delete(c.stats, name)
+	c.mu.Unlock()
+}
+
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queueMessages
+	ch <- c.queueBytes
+	ch <- c.queueMaxMsgs
+	ch <- c.brokerRTT
+	ch <- c.brokerOutbuf
+	ch <- c.brokerWaitResp
+	ch <- c.partQueue
+	ch <- c.partTxMsgs
+	ch <- c.partTxBytes
+}
+
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for client, s := range c.stats {
+		ch <- prometheus.MustNewConstMetric(c.queueMessages, prometheus.GaugeValue, float64(s.MsgCnt), client)
+		ch <- prometheus.MustNewConstMetric(c.queueBytes, prometheus.GaugeValue, float64(s.MsgSize), client)
+		ch <- prometheus.MustNewConstMetric(c.queueMaxMsgs, prometheus.GaugeValue, float64(s.MsgMax), client)
+
+		for _, b := range s.Brokers {
+			// Skip bootstrap and internal pseudo-brokers, they carry no traffic.
+			if b.NodeID < 0 {
+				continue
+			}
+			// librdkafka reports RTT in microseconds.
+			ch <- prometheus.MustNewConstMetric(c.brokerRTT, prometheus.GaugeValue, float64(b.RTT.Avg)/1e6, client, b.Name, "avg")
+			ch <- prometheus.MustNewConstMetric(c.brokerRTT, prometheus.GaugeValue, float64(b.RTT.P99)/1e6, client, b.Name, "p99")
+			ch <- prometheus.MustNewConstMetric(c.brokerOutbuf, prometheus.GaugeValue, float64(b.OutbufCnt), client, b.Name)
+			ch <- prometheus.MustNewConstMetric(c.brokerWaitResp, prometheus.GaugeValue, float64(b.WaitRespCnt), client, b.Name)
+		}
+
+		for topic, t := range s.Topics {
+			for _, p := range t.Partitions {
+				// Partition -1 is librdkafka's internal unassigned partition.
+				if p.Partition < 0 {
+					continue
+				}
+				partition := strconv.Itoa(int(p.Partition))
+				ch <- prometheus.MustNewConstMetric(c.partQueue, prometheus.GaugeValue, float64(p.MsgqCnt+p.XmitMsgq), client, topic, partition)
+				ch <- prometheus.MustNewConstMetric(c.partTxMsgs, prometheus.CounterValue, float64(p.TxMsgs), client, topic, partition)
+				ch <- prometheus.MustNewConstMetric(c.partTxBytes, prometheus.CounterValue, float64(p.TxBytes), client, topic, partition)
+			}
+		}
+	}
+}
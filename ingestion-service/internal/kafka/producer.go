@@ -1,60 +1,430 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 )
 
+// statsInterval controls how often librdkafka emits its statistics JSON,
+// which feeds the queue, broker RTT and partition throughput metrics.
+const statsInterval = 5 * time.Second
+
+// commitAttempts bounds how often a transaction commit is retried after
+// retriable errors, waiting commitBackoff, doubled each time, in between.
+const (
+	commitAttempts = 5
+	commitBackoff  = 100 * time.Millisecond
+)
+
+// Mode selects the delivery guarantee of the producer.
+type Mode string
+
+const (
+	// ModeAtLeastOnce waits for acks from all in-sync replicas and lets
+	// librdkafka retry. A retried request may be written twice, and retries
+	// can reorder pings of the same vehicle within a partition.
+	ModeAtLeastOnce Mode = "at-least-once"
+	// ModeIdempotent enables the idempotent producer: the broker discards
+	// retried duplicates and per-partition order is preserved, so each ping
+	// is written exactly once and in order for the lifetime of the producer.
+	// Idempotence violations surface as fatal errors, after which the
+	// producer is re-created.
+	ModeIdempotent Mode = "idempotent"
+	// ModeTransactional is idempotent and additionally commits each batch
+	// in a Kafka transaction: consumers using isolation.level=read_committed
+	// see either every ping of a batch or none of them. Single pings are
+	// committed as one-message transactions.
+	ModeTransactional Mode = "transactional"
+)
+
+// ParseMode parses a KAFKA_PRODUCER_MODE value. An empty string selects
+// ModeAtLeastOnce.
+func ParseMode(s string) (Mode, error) {
+	switch m := Mode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "":
+		return ModeAtLeastOnce, nil
+	case ModeAtLeastOnce, ModeIdempotent, ModeTransactional:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown producer mode %q", s)
+}
+
+type Config struct {
+	Brokers string
+	Topic   string
+	Mode    Mode
+	// TransactionalID identifies the producer across restarts in
+	// ModeTransactional. It must be unique per running instance.
+	TransactionalID string
+}
+
+// Message is a single record of a batch. An empty Topic selects the
+// producer's configured topic.
+type Message struct {
+	Topic   string
+	Key     string
+	Value   interface{}
+	Headers map[string]string
+}
+
+var errClosed = errors.New("producer is closed")
+
 type Producer struct {
+	cfg Config
+
+	// mu guards client. Produce paths hold the read lock while a message is
+	// in flight so the client is never closed underneath them.
+	mu     sync.RWMutex
+	client *client
+	closed bool
+
+	// txnMu serializes transactions; a producer has at most one open.
+	txnMu sync.Mutex
+}
+
+// client is one librdkafka producer instance. It is replaced as a whole
+// after a fatal error.
+type client struct {
 	producer *kafka.Producer
-	topic    string
+	done     chan struct{}
+}
+
+func NewProducer(cfg Config) (*Producer, error) {
+	if cfg.Mode == "" {
+		cfg.Mode = ModeAtLeastOnce
+	}
+	if cfg.Mode == ModeTransactional && cfg.TransactionalID == "" {
+		return nil, errors.New("transactional mode requires a transactional id")
+	}
+
+	p := &Producer{cfg: cfg}
+	c, err := p.newClient()
+	if err != nil {
+		return nil, err
+	}
+	p.client = c
+	producerInfo.WithLabelValues(string(cfg.Mode),
+		fmt.Sprint(cfg.Mode != ModeAtLeastOnce),
+		fmt.Sprint(cfg.Mode == ModeTransactional)).Set(1)
+	return p, nil
 }
 
-func NewProducer(brokers string, topic string) (*Producer, error) {
-	p, err := kafka.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": brokers,
-		"client.id":         "ingestion-service",
-		"acks":              "all",
-	})
+func (p *Producer) newClient() (*client, error) {
+	conf := &kafka.ConfigMap{
+		"bootstrap.servers":      p.cfg.Brokers,
+		"client.id":              "ingestion-service",
+		"acks":                   "all",
+		"statistics.interval.ms": int(statsInterval / time.Millisecond),
+	}
+	if p.cfg.Mode != ModeAtLeastOnce {
+		conf.SetKey("enable.idempotence", true)
+	}
+	if p.cfg.Mode == ModeTransactional {
+		conf.SetKey("transactional.id", p.cfg.TransactionalID)
+	}
+
+	kp, err := kafka.NewProducer(conf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
 	}
 
-	return &Producer{
-		producer: p,
-		topic:    topic,
-	}, nil
+	if p.cfg.Mode == ModeTransactional {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := kp.InitTransactions(ctx); err != nil {
+			kp.Close()
+			return nil, fmt.Errorf("failed to initialize transactions: %w", err)
+		}
+	}
+
+	c := &client{producer: kp, done: make(chan struct{})}
+	go p.handleEvents(c)
+	return c, nil
+}
+
+// handleEvents drains the producer's event channel, which carries statistics,
+// client-level errors and delivery reports of transactional batches.
+func (p *Producer) handleEvents(c *client) {
+	defer close(c.done)
+	for e := range c.producer.Events() {
+		switch ev := e.(type) {
+		case *kafka.Stats:
+			if err := kafkaStats.update(ev.String()); err != nil {
+				slog.Warn("Failed to parse kafka statistics", "error", err)
+			}
+		case kafka.Error:
+			clientErrors.WithLabelValues(errorClass(ev)).Inc()
+			slog.Error("Kafka client error", "error", ev, "code", ev.Code().String(), "fatal", ev.IsFatal())
+			if ev.IsFatal() {
+				go p.recreate(c, ev)
+			}
+		}
+	}
+}
+
+// acquire returns the current client with the read lock held, re-creating
+// the client first if a previous re-creation failed. Callers must RUnlock.
+func (p *Producer) acquire() (*client, error) {
+	p.mu.RLock()
+	if p.client != nil {
+		return p.client, nil
+	}
+	if p.closed {
+		p.mu.RUnlock()
+		return nil, errClosed
+	}
+	p.mu.RUnlock()
+
+	p.mu.Lock()
+	if p.client == nil && !p.closed {
+		c, err := p.newClient()
+		if err != nil {
+			p.mu.Unlock()
+			return nil, err
+		}
+		p.client = c
+		producerRecreations.WithLabelValues("retry").Inc()
+	}
+	p.mu.Unlock()
+	return p.acquire()
+}
+
+// recreate replaces a client that hit a fatal error. Outstanding messages
+// are purged first so that callers waiting on them fail fast and release
+// the read lock.
+func (p *Producer) recreate(old *client, cause error) {
+	old.producer.Purge(kafka.PurgeQueue | kafka.PurgeInFlight | kafka.PurgeNonBlocking)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client != old || p.closed {
+		return
+	}
+
+	slog.Error("Re-creating Kafka producer after fatal error", "error", cause, "mode", p.cfg.Mode)
+	producerRecreations.WithLabelValues(errorClass(cause)).Inc()
+	old.close()
+	p.client = nil
+
+	c, err := p.newClient()
+	if err != nil {
+		// acquire retries on the next produce call.
+		slog.Error("Failed to re-create Kafka producer", "error", err)
+		return
+	}
+	p.client = c
 }
 
 func (p *Producer) Produce(key string, value interface{}) error {
+	if p.cfg.Mode == ModeTransactional {
+		return p.ProduceBatch(context.Background(), []Message{{Key: key, Value: value}})
+	}
+
 	bytes, err := json.Marshal(value)
 	if err != nil {
+		produceFailures.WithLabelValues(p.cfg.Topic, errorClass(err)).Inc()
 		return fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	deliveryChan := make(chan kafka.Event)
-	err = p.producer.Produce(&kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &p.topic, Partition: kafka.PartitionAny},
+	c, err := p.acquire()
+	if err != nil {
+		produceFailures.WithLabelValues(p.cfg.Topic, "unavailable").Inc()
+		return err
+	}
+	defer p.mu.RUnlock()
+
+	start := time.Now()
+	deliveryChan := make(chan kafka.Event, 1)
+	err = c.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &p.cfg.Topic, Partition: kafka.PartitionAny},
 		Key:            []byte(key),
 		Value:          bytes,
 	}, deliveryChan)
 
 	if err != nil {
+		produceFailures.WithLabelValues(p.cfg.Topic, errorClass(err)).Inc()
 		return fmt.Errorf("failed to produce message: %w", err)
 	}
 
 	e := <-deliveryChan
 	m := e.(*kafka.Message)
+	produceSeconds.WithLabelValues(p.cfg.Topic).Observe(time.Since(start).Seconds())
 
 	if m.TopicPartition.Error != nil {
-		return fmt.Errorf("delivery failed: %v", m.TopicPartition.Error)
+		produceFailures.WithLabelValues(p.cfg.Topic, errorClass(m.TopicPartition.Error)).Inc()
+		return fmt.Errorf("delivery failed: %w", m.TopicPartition.Error)
 	}
 
-	close(deliveryChan)
 	return nil
 }
 
+// ProduceBatch writes msgs, possibly to several topics. In ModeTransactional
+// the batch is committed atomically; in the other modes each message is
+// delivered independently and the first failure is returned.
+func (p *Producer) ProduceBatch(ctx context.Context, msgs []Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	records := make([]*kafka.Message, len(msgs))
+	for i, msg := range msgs {
+		topic := msg.Topic
+		if topic == "" {
+			topic = p.cfg.Topic
+		}
+		bytes, err := json.Marshal(msg.Value)
+		if err != nil {
+			produceFailures.WithLabelValues(topic, errorClass(err)).Inc()
+			return fmt.Errorf("failed to marshal value: %w", err)
+		}
+		records[i] = &kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+			Key:            []byte(msg.Key),
+			Value:          bytes,
+		}
+		for k, v := range msg.Headers {
+			records[i].Headers = append(records[i].Headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+	}
+
+	if p.cfg.Mode == ModeTransactional {
+		p.txnMu.Lock()
+		defer p.txnMu.Unlock()
+	}
+
+	c, err := p.acquire()
+	if err != nil {
+		for _, r := range records {
+			produceFailures.WithLabelValues(*r.TopicPartition.Topic, "unavailable").Inc()
+		}
+		return err
+	}
+	defer p.mu.RUnlock()
+
+	if p.cfg.Mode == ModeTransactional {
+		return p.produceTransaction(ctx, c, records)
+	}
+	return produceAll(c, records)
+}
+
+// produceAll enqueues every record and waits for all delivery reports.
+func produceAll(c *client, records []*kafka.Message) error {
+	start := time.Now()
+	deliveryChan := make(chan kafka.Event, len(records))
+	pending := 0
+	var firstErr error
+	for _, r := range records {
+		if err := c.producer.Produce(r, deliveryChan); err != nil {
+			produceFailures.WithLabelValues(*r.TopicPartition.Topic, errorClass(err)).Inc()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to produce message: %w", err)
+			}
+			continue
+		}
+		pending++
+	}
+
+	for ; pending > 0; pending-- {
+		m := (<-deliveryChan).(*kafka.Message)
+		produceSeconds.WithLabelValues(*m.TopicPartition.Topic).Observe(time.Since(start).Seconds())
+		if m.TopicPartition.Error != nil {
+			produceFailures.WithLabelValues(*m.TopicPartition.Topic, errorClass(m.TopicPartition.Error)).Inc()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("delivery failed: %w", m.TopicPartition.Error)
+			}
+		}
+	}
+	return firstErr
+}
+
+// produceTransaction writes records in a single transaction. Delivery
+// reports go to the event channel; CommitTransaction flushes them and fails
+// if any record could not be delivered.
+func (p *Producer) produceTransaction(ctx context.Context, c *client, records []*kafka.Message) error {
+	start := time.Now()
+	if err := c.producer.BeginTransaction(); err != nil {
+		return p.failTransaction(ctx, c, records, false, err)
+	}
+
+	for _, r := range records {
+		if err := c.producer.Produce(r, nil); err != nil {
+			return p.failTransaction(ctx, c, records, true, err)
+		}
+	}
+
+	backoff := commitBackoff
+	for attempt := 1; ; attempt++ {
+		err := c.producer.CommitTransaction(ctx)
+		if err == nil {
+			transactions.WithLabelValues("committed").Inc()
+			for _, r := range records {
+				produceSeconds.WithLabelValues(*r.TopicPartition.Topic).Observe(time.Since(start).Seconds())
+			}
+			return nil
+		}
+		var kerr kafka.Error
+		if !errors.As(err, &kerr) || !kerr.IsRetriable() || attempt == commitAttempts {
+			return p.failTransaction(ctx, c, records, true, err)
+		}
+		select {
+		case <-ctx.Done():
+			return p.failTransaction(ctx, c, records, true, err)
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// failTransaction records a failed transaction, aborts it when it was
+// started and the error allows it, and schedules producer re-creation for
+// fatal errors.
+func (p *Producer) failTransaction(ctx context.Context, c *client, records []*kafka.Message, started bool, err error) error {
+	for _, r := range records {
+		produceFailures.WithLabelValues(*r.TopicPartition.Topic, errorClass(err)).Inc()
+	}
+
+	var kerr kafka.Error
+	fatal := errors.As(err, &kerr) && kerr.IsFatal()
+	if started && !fatal {
+		// Abort with a fresh context: the caller's may be what expired.
+		abortCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if abortErr := c.producer.AbortTransaction(abortCtx); abortErr != nil {
+			slog.ErrorContext(ctx, "Failed to abort Kafka transaction", "error", abortErr)
+			fatal = true
+			err = abortErr
+		} else {
+			transactions.WithLabelValues("aborted").Inc()
+		}
+	}
+	if fatal {
+		transactions.WithLabelValues("failed").Inc()
+		go p.recreate(c, err)
+	}
+	return fmt.Errorf("transaction failed: %w", err)
+}
+
+func (c *client) close() {
+	name := c.producer.String()
+	c.producer.Close()
+	<-c.done
+	kafkaStats.forget(name)
+}
+
 func (p *Producer) Close() {
-	p.producer.Close()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+	if p.client != nil {
+		p.client.close()
+		p.client = nil
+	}
 }
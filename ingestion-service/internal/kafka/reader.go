@@ -0,0 +1,98 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+// OffsetRange is the offsets [Start, End) of one partition.
+type OffsetRange struct {
+	Start, End int64
+}
+
+// RangeConfig selects records to read outside any consumer group.
+type RangeConfig struct {
+	Brokers string
+	Topic   string
+	Ranges  map[int32]OffsetRange
+}
+
+// ReadRange reads the records in cfg.Ranges and calls handle for each, in
+// offset order within a partition. Ranges are clamped to the records the
+// partitions still hold. No offsets are committed, so reading does not
+// disturb the services consuming the topic.
+func ReadRange(ctx context.Context, cfg RangeConfig, handle func(Record) error) error {
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers": cfg.Brokers,
+		// Required by the client, but partitions are assigned directly so
+		// the group is never joined.
+		"group.id":           "range-reader",
+		"enable.auto.commit": false,
+		"isolation.level":    "read_committed",
+		// Transaction markers can take a range's last offsets, so the end
+		// of a partition also ends its range.
+		"enable.partition.eof": true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create kafka consumer: %w", err)
+	}
+	defer c.Close()
+
+	remaining := make(map[int32]int64, len(cfg.Ranges))
+	var assign []kafka.TopicPartition
+	for p, r := range cfg.Ranges {
+		low, high, err := c.QueryWatermarkOffsets(cfg.Topic, p, 10000)
+		if err != nil {
+			return fmt.Errorf("failed to query offsets of %s[%d]: %w", cfg.Topic, p, err)
+		}
+		start, end := max(r.Start, low), min(r.End, high)
+		if start >= end {
+			slog.Warn("Offset range holds no records", "topic", cfg.Topic, "partition", p,
+				"start", r.Start, "end", r.End, "low", low, "high", high)
+			continue
+		}
+		remaining[p] = end
+		assign = append(assign, kafka.TopicPartition{Topic: &cfg.Topic, Partition: p, Offset: kafka.Offset(start)})
+	}
+	if len(assign) == 0 {
+		return nil
+	}
+	if err := c.Assign(assign); err != nil {
+		return fmt.Errorf("failed to assign partitions: %w", err)
+	}
+
+	for len(remaining) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		switch ev := c.Poll(100).(type) {
+		case *kafka.Message:
+			p := ev.TopicPartition.Partition
+			end, ok := remaining[p]
+			if !ok {
+				continue
+			}
+			if int64(ev.TopicPartition.Offset) >= end {
+				delete(remaining, p)
+				continue
+			}
+			if err := handle(newRecord(ev)); err != nil {
+				return err
+			}
+			if int64(ev.TopicPartition.Offset) == end-1 {
+				delete(remaining, p)
+			}
+		case kafka.PartitionEOF:
+			delete(remaining, ev.Partition)
+		case kafka.Error:
+			if ev.IsFatal() {
+				return ev
+			}
+			slog.Warn("Kafka consumer error", "error", ev, "code", ev.Code().String())
+		}
+	}
+	return nil
+}
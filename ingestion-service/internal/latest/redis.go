@@ -0,0 +1,167 @@
+package latest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+// ActiveKey is the sorted set indexing vehicles by their latest ping
+// timestamp, so active vehicles can be listed without KEYS.
+const ActiveKey = "vehicles:active"
+
+// updateScript sets the latest position only when the incoming timestamp
+// is newer than the stored one, and indexes the vehicle.
+//
+// KEYS[1] latest key, KEYS[2] active index
+// ARGV[1] timestamp, ARGV[2] ping JSON, ARGV[3] TTL seconds, ARGV[4] vehicle ID
+var updateScript = redis.NewScript(`
+local cur = redis.call('GET', KEYS[1])
+if cur then
+  local ok, doc = pcall(cjson.decode, cur)
+  if ok and type(doc) == 'table' then
+    local ts = tonumber(doc['timestamp'])
+    if ts and ts >= tonumber(ARGV[1]) then
+      return 0
+    end
+  end
+end
+redis.call('SET', KEYS[1], ARGV[2], 'EX', ARGV[3])
+redis.call('ZADD', KEYS[2], ARGV[1], ARGV[4])
+return 1
+`)
+
+// RedisStore keeps latest positions in Redis under the keys the tracking
+// service reads. The script touches two keys, so it needs a single Redis
+// node rather than a cluster.
+type RedisStore struct {
+	rdb *redis.Client
+	ttl time.Duration
+}
+
+func NewRedisStore(rdb *redis.Client, ttl time.Duration) *RedisStore {
+	return &RedisStore{rdb: rdb, ttl: ttl}
+}
+
+// LoadScripts loads the update script so that Update's pipeline can use
+// EVALSHA. Call it once at startup; Update reloads the script itself if
+// Redis loses it, e.g. on restart.
+func (s *RedisStore) LoadScripts(ctx context.Context) error {
+	if err := updateScript.Load(ctx, s.rdb).Err(); err != nil {
+		return fmt.Errorf("failed to load update script: %w", err)
+	}
+	return nil
+}
+
+// Update stores each ping unless its vehicle already has a position with
+// the same or a newer timestamp, and reports which were stored. Pings are
+// applied in order.
+func (s *RedisStore) Update(ctx context.Context, pings []service.PingPayload) ([]bool, error) {
+	if len(pings) == 0 {
+		return nil, nil
+	}
+	applied, err := s.update(ctx, pings)
+	if redis.HasErrorPrefix(err, "NOSCRIPT") {
+		// Updates are compare-and-set, so running the batch again after
+		// reloading the script is safe.
+		if err := s.LoadScripts(ctx); err != nil {
+			return nil, err
+		}
+		applied, err = s.update(ctx, pings)
+	}
+	return applied, err
+}
+
+func (s *RedisStore) update(ctx context.Context, pings []service.PingPayload) ([]bool, error) {
+	ttl := strconv.FormatInt(int64(s.ttl.Seconds()), 10)
+	cmds := make([]*redis.Cmd, len(pings))
+	_, err := s.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, p := range pings {
+			data, err := json.Marshal(p)
+			if err != nil {
+				return err
+			}
+			cmds[i] = updateScript.EvalSha(ctx, pipe, []string{Key(p.VehicleID), ActiveKey}, p.Timestamp, data, ttl, p.VehicleID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update latest positions: %w", err)
+	}
+	applied := make([]bool, len(pings))
+	for i, cmd := range cmds {
+		n, err := cmd.Int()
+		if err != nil {
+			return nil, fmt.Errorf("failed to update latest position: %w", err)
+		}
+		applied[i] = n == 1
+	}
+	return applied, nil
+}
+
+// Get returns a vehicle's latest position.
+func (s *RedisStore) Get(ctx context.Context, vehicleID string) (service.PingPayload, bool, error) {
+	data, err := s.rdb.Get(ctx, Key(vehicleID)).Result()
+	if err == redis.Nil {
+		return service.PingPayload{}, false, nil
+	}
+	if err != nil {
+		return service.PingPayload{}, false, err
+	}
+	p, err := decode(data)
+	return p, err == nil, err
+}
+
+// Active returns up to limit vehicles with a ping at or after since (Unix
+// seconds), most recent first.
+func (s *RedisStore) Active(ctx context.Context, since int64, limit int) ([]service.PingPayload, error) {
+	ids, err := s.rdb.ZRevRangeByScore(ctx, ActiveKey, &redis.ZRangeBy{
+		Min:   strconv.FormatInt(since, 10),
+		Max:   "+inf",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active vehicles: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = Key(id)
+	}
+	vals, err := s.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read latest positions: %w", err)
+	}
+	out := make([]service.PingPayload, 0, len(vals))
+	for _, v := range vals {
+		// Positions can expire before the index is pruned.
+		data, ok := v.(string)
+		if !ok {
+			continue
+		}
+		p, err := decode(data)
+		if err != nil {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// Prune drops vehicles without a ping since before from the active index
+// and returns how many were removed.
+func (s *RedisStore) Prune(ctx context.Context, before int64) (int, error) {
+	n, err := s.rdb.ZRemRangeByScore(ctx, ActiveKey, "-inf", "("+strconv.FormatInt(before, 10)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune active vehicles: %w", err)
+	}
+	return int(n), nil
+}
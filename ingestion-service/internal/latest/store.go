@@ -0,0 +1,30 @@
+// Package latest keeps each vehicle's most recent position. Updates are
+// compare-and-set on the ping timestamp, so late or replayed pings never
+// move a vehicle back in time.
+package latest
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+// DefaultTTL is how long a vehicle's position is kept after its last ping,
+// matching the tracking service's cache expiry.
+const DefaultTTL = 24 * time.Hour
+
+// Key is the Redis key holding a vehicle's latest ping as JSON, as read by
+// the tracking service.
+func Key(vehicleID string) string {
+	return fmt.Sprintf("vehicle:%s:latest", vehicleID)
+}
+
+func decode(data string) (service.PingPayload, error) {
+	var p service.PingPayload
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		return p, fmt.Errorf("invalid latest position: %w", err)
+	}
+	return p, nil
+}
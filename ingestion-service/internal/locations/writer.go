@@ -0,0 +1,132 @@
+// Package locations persists location pings to the vehicle_locations table
+// shared with the tracking service.
+package locations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+// maxVehicleID is the length of the vehicle_id column, in characters.
+const maxVehicleID = 255
+
+// Valid reports whether a ping fits the vehicle_locations columns. Pings
+// that do not would fail the whole COPY.
+func Valid(p service.PingPayload) bool {
+	return p.VehicleID != "" &&
+		utf8.RuneCountInString(p.VehicleID) <= maxVehicleID &&
+		utf8.ValidString(p.VehicleID) &&
+		!strings.ContainsRune(p.VehicleID, 0) &&
+		!math.IsNaN(p.Latitude) && !math.IsInf(p.Latitude, 0) &&
+		!math.IsNaN(p.Longitude) && !math.IsInf(p.Longitude, 0)
+}
+
+// Writer bulk-loads pings with COPY, which is an order of magnitude faster
+// than one INSERT per ping. The vehicle_locations table is created by
+// tracking-service/migrations/init.sql.
+type Writer struct {
+	db *sql.DB
+}
+
+func NewWriter(db *sql.DB) *Writer {
+	return &Writer{db: db}
+}
+
+// Write stores pings in vehicle_locations in one transaction and returns
+// how many were rejected. If the database rejects the batch's data (class
+// 22 or 23 errors), the pings are inserted one at a time instead and those
+// it rejects are skipped, so one bad row cannot fail the batch forever.
+// When Write returns nil the other rows are committed; on error none are.
+func (w *Writer) Write(ctx context.Context, pings []service.PingPayload) (int, error) {
+	err := w.copy(ctx, pings)
+	if err == nil || !isDataError(err) {
+		return 0, err
+	}
+	return w.insertEach(ctx, pings)
+}
+
+// isDataError reports whether err is a data exception or integrity
+// constraint violation, which retrying the same rows cannot fix.
+func isDataError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	class := pqErr.Code.Class()
+	return class == "22" || class == "23"
+}
+
+// insertEach inserts pings one at a time, each under a savepoint so that a
+// rejected row does not abort the transaction.
+func (w *Writer) insertEach(ctx context.Context, pings []service.PingPayload) (int, error) {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rejected := 0
+	for _, p := range pings {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT ping"); err != nil {
+			return 0, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO vehicle_locations (vehicle_id, latitude, longitude, timestamp) VALUES ($1, $2, $3, $4)",
+			p.VehicleID, p.Latitude, p.Longitude, p.Timestamp)
+		switch {
+		case err == nil:
+		case isDataError(err):
+			rejected++
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT ping"); err != nil {
+				return 0, fmt.Errorf("failed to roll back rejected row: %w", err)
+			}
+		default:
+			return 0, fmt.Errorf("failed to insert row: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit rows: %w", err)
+	}
+	return rejected, nil
+}
+
+// copy copies pings into vehicle_locations in one transaction.
+func (w *Writer) copy(ctx context.Context, pings []service.PingPayload) error {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("vehicle_locations", "vehicle_id", "latitude", "longitude", "timestamp"))
+	if err != nil {
+		return fmt.Errorf("failed to start copy: %w", err)
+	}
+	for _, p := range pings {
+		if _, err := stmt.ExecContext(ctx, p.VehicleID, p.Latitude, p.Longitude, p.Timestamp); err != nil {
+			stmt.Close()
+			return fmt.Errorf("failed to copy row: %w", err)
+		}
+	}
+	// The final empty Exec flushes the buffered rows to the server.
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return fmt.Errorf("failed to copy rows: %w", err)
+	}
+	if err := stmt.Close(); err != nil {
+		return fmt.Errorf("failed to finish copy: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// CorrelationIDHeader is read from incoming metadata and always returned
+	// in the response trailers.
+	CorrelationIDHeader = "x-correlation-id"
+)
+
+type correlationIDKey struct{}
+
+// CorrelationID returns the correlation ID assigned to the current request.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// UnaryServerInterceptor assigns every RPC a correlation ID, taken from the
+// x-correlation-id metadata when the caller supplies one, and attaches it
+// together with the vehicle ID to all logs for the request.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		id := first(md, CorrelationIDHeader)
+		if id == "" {
+			id = newCorrelationID()
+		}
+		ctx = context.WithValue(ctx, correlationIDKey{}, id)
+		grpc.SetTrailer(ctx, metadata.Pairs(CorrelationIDHeader, id))
+
+		attrs := []slog.Attr{
+			slog.String("correlation_id", id),
+			slog.String("method", info.FullMethod),
+		}
+		if v, ok := req.(interface{ GetVehicleId() string }); ok && v.GetVehicleId() != "" {
+			attrs = append(attrs, slog.String("vehicle_id", v.GetVehicleId()))
+		}
+		ctx = WithAttrs(ctx, attrs...)
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor does the same for streaming RPCs. The vehicle ID
+// is not known until the first message, so only the correlation ID and
+// method are attached.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		id := first(md, CorrelationIDHeader)
+		if id == "" {
+			id = newCorrelationID()
+		}
+		ctx = context.WithValue(ctx, correlationIDKey{}, id)
+		ss.SetTrailer(metadata.Pairs(CorrelationIDHeader, id))
+		ctx = WithAttrs(ctx,
+			slog.String("correlation_id", id),
+			slog.String("method", info.FullMethod),
+		)
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+func first(md metadata.MD, key string) string {
+	if vals := md.Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func newCorrelationID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
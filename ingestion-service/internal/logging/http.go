@@ -0,0 +1,38 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// LevelHandler exposes the log level over HTTP: GET returns it, PUT or POST
+// with a level name ("debug", "info", "warn", "error") in the body sets it.
+func LevelHandler(lv *slog.LevelVar) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			body, err := io.ReadAll(io.LimitReader(r.Body, 64))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var level slog.Level
+			if err := level.UnmarshalText([]byte(strings.TrimSpace(string(body)))); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			old := lv.Level()
+			lv.Set(level)
+			slog.Warn("Log level changed", "from", old.String(), "to", level.String())
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprintln(w, lv.Level().String())
+	})
+}
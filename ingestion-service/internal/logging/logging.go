@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+type attrsKey struct{}
+
+// Setup installs a structured logger as the slog and std log default and
+// returns the level variable so the level can be changed at runtime.
+// format is "json" (default) or "text"; level is any slog level name.
+func Setup(w io.Writer, level, format string) *slog.LevelVar {
+	lv := new(slog.LevelVar)
+	if level != "" {
+		if err := lv.UnmarshalText([]byte(level)); err != nil {
+			lv.Set(slog.LevelInfo)
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: lv}
+	var h slog.Handler
+	if strings.EqualFold(format, "text") {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+
+	slog.SetDefault(slog.New(&contextHandler{Handler: h}))
+	return lv
+}
+
+// WithAttrs returns a context carrying attrs that every log record written
+// with that context (via the *Context logging methods) will include.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// contextHandler adds the attributes stored by WithAttrs to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var sampledDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ingestion_log_messages_dropped_total",
+	Help: "Log records dropped by hot-path sampling, by level",
+}, []string{"level"})
+
+// counterBuckets bounds sampler memory; messages hash into a fixed table.
+const counterBuckets = 1024
+
+// Sampled wraps l so that, per message and level, only the first `first`
+// records in each tick are written and every `thereafter`-th record after
+// that. Use it for per-request logs on hot paths so enabling debug logging
+// during an incident cannot flood the output. thereafter <= 0 drops all
+// records beyond the first ones in each tick.
+func Sampled(l *slog.Logger, tick time.Duration, first, thereafter int) *slog.Logger {
+	return slog.New(&sampledHandler{
+		Handler: l.Handler(),
+		s: &sampler{
+			tick:       tick,
+			first:      uint64(first),
+			thereafter: uint64(thereafter),
+		},
+	})
+}
+
+type sampler struct {
+	tick       time.Duration
+	first      uint64
+	thereafter uint64
+	counters   [counterBuckets]counter
+}
+
+type counter struct {
+	resetAt atomic.Int64
+	n       atomic.Uint64
+}
+
+// inc bumps the counter, restarting it when the current tick has elapsed.
+func (c *counter) inc(now int64, tick time.Duration) uint64 {
+	resetAt := c.resetAt.Load()
+	if now < resetAt {
+		return c.n.Add(1)
+	}
+	c.n.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now+int64(tick)) {
+		return c.n.Add(1)
+	}
+	return 1
+}
+
+func (s *sampler) allow(r slog.Record) bool {
+	h := fnv.New32a()
+	h.Write([]byte(r.Message))
+	idx := (h.Sum32() + uint32(r.Level+8)) % counterBuckets
+
+	n := s.counters[idx].inc(r.Time.UnixNano(), s.tick)
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
+
+type sampledHandler struct {
+	slog.Handler
+	s *sampler
+}
+
+func (h *sampledHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !h.s.allow(r) {
+		sampledDropped.WithLabelValues(r.Level.String()).Inc()
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *sampledHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sampledHandler{Handler: h.Handler.WithAttrs(attrs), s: h.s}
+}
+
+func (h *sampledHandler) WithGroup(name string) slog.Handler {
+	return &sampledHandler{Handler: h.Handler.WithGroup(name), s: h.s}
+}
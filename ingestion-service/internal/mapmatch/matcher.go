@@ -0,0 +1,309 @@
+// Package mapmatch snaps GPS positions onto the road graph with a hidden
+// Markov model: candidate road positions are the hidden states, GPS error
+// gives the emission probability and the agreement between driven and
+// straight-line distance gives the transition probability (Newson and
+// Krumm, "Hidden Markov Map Matching Through Noise and Sparseness", 2009).
+package mapmatch
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/roadgraph"
+)
+
+type Config struct {
+	// SearchRadius is how far from a ping candidate roads are looked for.
+	SearchRadius float64
+	// MaxCandidates bounds the states per ping, closest roads first.
+	MaxCandidates int
+	// GPSSigma is the standard deviation of GPS error in meters.
+	GPSSigma float64
+	// Beta scales how strongly a difference between route distance and
+	// straight-line distance is penalized, in meters.
+	Beta float64
+	// MaxDetour bounds the route search between consecutive pings to this
+	// multiple of their straight-line distance (plus the search radius).
+	MaxDetour float64
+	// Lag is how many later pings a session sees before settling a ping's
+	// match. Larger lags let more of the trace correct a match, and delay
+	// it by as many pings; 0 matches each ping on arrival.
+	Lag int
+}
+
+func DefaultConfig() Config {
+	return Config{
+		SearchRadius:  50,
+		MaxCandidates: 8,
+		GPSSigma:      10,
+		Beta:          20,
+		MaxDetour:     3,
+		Lag:           5,
+	}
+}
+
+// Match is the road position assigned to one ping.
+type Match struct {
+	Matched bool
+	Edge    int32
+	Point   geo.Point
+	// Distance is how far the ping was from the road, in meters.
+	Distance float64
+}
+
+type Matcher struct {
+	g   *roadgraph.Graph
+	cfg Config
+}
+
+func NewMatcher(g *roadgraph.Graph, cfg Config) *Matcher {
+	return &Matcher{g: g, cfg: cfg}
+}
+
+func (m *Matcher) Graph() *roadgraph.Graph { return m.g }
+
+// state is a candidate with its best log probability and the index of the
+// best previous state (-1 at the start of a chain).
+type state struct {
+	cand roadgraph.Candidate
+	logp float64
+	back int
+}
+
+func (m *Matcher) candidates(p geo.Point) []roadgraph.Candidate {
+	cands := m.g.Nearby(p, m.cfg.SearchRadius)
+	if len(cands) > m.cfg.MaxCandidates {
+		cands = cands[:m.cfg.MaxCandidates]
+	}
+	return cands
+}
+
+func (m *Matcher) emission(c roadgraph.Candidate) float64 {
+	z := c.Distance / m.cfg.GPSSigma
+	return -0.5 * z * z
+}
+
+// step advances the model by one ping. It returns the new states, or nil
+// when the ping has no candidate roads. broken is set when no candidate is
+// reachable from the previous states, in which case a new chain starts.
+func (m *Matcher) step(prev []state, prevPoint geo.Point, p geo.Point) (cur []state, broken bool) {
+	cands := m.candidates(p)
+	if len(cands) == 0 {
+		return nil, false
+	}
+	cur = make([]state, len(cands))
+	for j, c := range cands {
+		cur[j] = state{cand: c, logp: math.Inf(-1), back: -1}
+	}
+
+	if len(prev) > 0 {
+		gc := geo.Distance(prevPoint, p)
+		limit := gc*m.cfg.MaxDetour + 2*m.cfg.SearchRadius
+		for i, ps := range prev {
+			if math.IsInf(ps.logp, -1) {
+				continue
+			}
+			routes := m.routeDistances(ps.cand, cands, limit)
+			for j, c := range cands {
+				if math.IsInf(routes[j], 1) {
+					continue
+				}
+				lp := ps.logp - math.Abs(routes[j]-gc)/m.cfg.Beta + m.emission(c)
+				if lp > cur[j].logp {
+					cur[j].logp, cur[j].back = lp, i
+				}
+			}
+		}
+	}
+
+	best := math.Inf(-1)
+	for _, s := range cur {
+		best = math.Max(best, s.logp)
+	}
+	if math.IsInf(best, -1) {
+		broken = len(prev) > 0
+		for j := range cur {
+			cur[j].logp, cur[j].back = m.emission(cur[j].cand), -1
+		}
+		best = 0
+		for _, s := range cur {
+			best = math.Max(best, s.logp)
+		}
+	}
+	// Keep log probabilities near zero so long chains do not underflow.
+	for j := range cur {
+		cur[j].logp -= best
+	}
+	return cur, broken
+}
+
+// routeDistances returns the driving distance from candidate a to each
+// target candidate, or +Inf when it is farther than limit.
+func (m *Matcher) routeDistances(a roadgraph.Candidate, targets []roadgraph.Candidate, limit float64) []float64 {
+	ea := &m.g.Edges[a.Edge]
+	out := make([]float64, len(targets))
+	want := make(map[int32]bool)
+	for j, t := range targets {
+		out[j] = math.Inf(1)
+		if t.Edge == a.Edge && t.Fraction >= a.Fraction {
+			out[j] = (t.Fraction - a.Fraction) * ea.Length
+			continue
+		}
+		want[m.g.Edges[t.Edge].From] = true
+	}
+	if len(want) == 0 {
+		return out
+	}
+
+	rest := (1 - a.Fraction) * ea.Length
+	nodes := m.distances(ea.To, limit-rest, want)
+	for j, t := range targets {
+		et := &m.g.Edges[t.Edge]
+		if d, ok := nodes[et.From]; ok && !(t.Edge == a.Edge && t.Fraction >= a.Fraction) {
+			if total := rest + d + t.Fraction*et.Length; total < out[j] && total <= limit {
+				out[j] = total
+			}
+		}
+	}
+	return out
+}
+
+// distances runs Dijkstra from source up to limit meters and returns the
+// distances to the wanted nodes it reached.
+func (m *Matcher) distances(source int32, limit float64, want map[int32]bool) map[int32]float64 {
+	found := make(map[int32]float64, len(want))
+	if limit < 0 {
+		return found
+	}
+	dist := map[int32]float64{source: 0}
+	pq := &queue{{node: source}}
+	for pq.Len() > 0 && len(found) < len(want) {
+		it := heap.Pop(pq).(item)
+		if it.dist > dist[it.node] {
+			continue
+		}
+		if want[it.node] {
+			found[it.node] = it.dist
+		}
+		from, to := m.g.Out(it.node)
+		for e := from; e < to; e++ {
+			edge := &m.g.Edges[e]
+			d := it.dist + edge.Length
+			if d > limit {
+				continue
+			}
+			if old, ok := dist[edge.To]; !ok || d < old {
+				dist[edge.To] = d
+				heap.Push(pq, item{node: edge.To, dist: d})
+			}
+		}
+	}
+	return found
+}
+
+type item struct {
+	node int32
+	dist float64
+}
+
+type queue []item
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(item)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
+
+func toMatch(s state) Match {
+	return Match{Matched: true, Edge: s.cand.Edge, Point: s.cand.Point, Distance: s.cand.Distance}
+}
+
+// Session matches one vehicle's pings as they arrive with fixed-lag
+// Viterbi decoding: a ping's match is settled once Lag later pings have
+// been seen, by following the most likely path back from the newest ping,
+// so later pings can correct it. Settled matches are not revised.
+type Session struct {
+	m *Matcher
+	// window holds the steps of the pings not yet settled, oldest first.
+	// A nil step is a ping with no road nearby.
+	window    [][]state
+	prev      []state
+	prevPoint geo.Point
+	LastSeen  int64
+}
+
+func (m *Matcher) NewSession() *Session {
+	return &Session{m: m}
+}
+
+// Step adds the next ping and returns the matches it settles, oldest
+// first. Every ping is settled exactly once, in the order added.
+func (s *Session) Step(p geo.Point, ts int64) []Match {
+	s.LastSeen = ts
+	cur, broken := s.m.step(s.prev, s.prevPoint, p)
+	var out []Match
+	if broken {
+		// No path continues the window's chain, so nothing later can
+		// change it.
+		out = s.settle(len(s.window))
+	}
+	if cur != nil {
+		// Pings without candidates keep the previous states so one
+		// off-road ping does not reset the model.
+		s.prev, s.prevPoint = cur, p
+	}
+	s.window = append(s.window, cur)
+	if n := len(s.window) - s.m.cfg.Lag; n > 0 {
+		out = append(out, s.settle(n)...)
+	}
+	return out
+}
+
+// Flush settles every ping still in the window, as when the vehicle goes
+// silent.
+func (s *Session) Flush() []Match {
+	return s.settle(len(s.window))
+}
+
+// settle decides the oldest n pings of the window by following the back
+// pointers from the most likely state of the newest ping.
+func (s *Session) settle(n int) []Match {
+	if n == 0 {
+		return nil
+	}
+	path := make([]Match, len(s.window))
+	best := -1
+	for k := len(s.window) - 1; k >= 0; k-- {
+		states := s.window[k]
+		if states == nil {
+			continue
+		}
+		if best < 0 {
+			best = 0
+			for j := range states {
+				if states[j].logp > states[best].logp {
+					best = j
+				}
+			}
+		}
+		path[k] = toMatch(states[best])
+		best = states[best].back
+	}
+	s.window = append(s.window[:0], s.window[n:]...)
+	return path[:n]
+}
+
+// Nearest snaps p to the closest road, ignoring history.
+func (m *Matcher) Nearest(p geo.Point) Match {
+	cands := m.g.Nearby(p, m.cfg.SearchRadius)
+	if len(cands) == 0 {
+		return Match{}
+	}
+	return toMatch(state{cand: cands[0]})
+}
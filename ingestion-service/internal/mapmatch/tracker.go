@@ -0,0 +1,146 @@
+package mapmatch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+// MatchedPing is published to the vehicle-locations-matched topic: the
+// original ping plus its position on the road network.
+type MatchedPing struct {
+	service.PingPayload
+	// Matched is false when no road was within the search radius; the
+	// road fields are then empty.
+	Matched          bool    `json:"matched"`
+	MatchedLatitude  float64 `json:"matched_latitude,omitempty"`
+	MatchedLongitude float64 `json:"matched_longitude,omitempty"`
+	// SegmentID is "<way id>:<segment index>", see roadgraph.Edge.
+	SegmentID        string  `json:"segment_id,omitempty"`
+	WayID            int64   `json:"way_id,omitempty"`
+	RoadName         string  `json:"road_name,omitempty"`
+	RoadClass        string  `json:"road_class,omitempty"`
+	DistanceFromRoad float64 `json:"distance_from_road,omitempty"`
+}
+
+// Settled is a ping whose match is final, with the record it came in.
+type Settled struct {
+	Ping MatchedPing
+	// Headers are those of the ping's record, such as trace context.
+	Headers map[string]string
+	Pos     kafka.Position
+}
+
+// Tracker keeps a matching session per vehicle, and the pings waiting for
+// their matches to settle. It is safe for concurrent use.
+type Tracker struct {
+	m *Matcher
+
+	mu       sync.Mutex
+	sessions map[string]*vehicle
+}
+
+type vehicle struct {
+	s *Session
+	// pending are the pings in the session's window, oldest first.
+	pending []pending
+	arrived time.Time
+}
+
+type pending struct {
+	ping    service.PingPayload
+	headers map[string]string
+	pos     kafka.Position
+}
+
+func NewTracker(m *Matcher) *Tracker {
+	return &Tracker{m: m, sessions: make(map[string]*vehicle)}
+}
+
+// Add takes the next ping of a vehicle, read from the record at pos at
+// now, and returns the pings whose matches it settles. Pings older than
+// the vehicle's last ping are snapped to the nearest road and returned at
+// once, without updating its session.
+func (t *Tracker) Add(ping service.PingPayload, headers map[string]string, pos kafka.Position, now time.Time) []Settled {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	v, ok := t.sessions[ping.VehicleID]
+	if !ok {
+		v = &vehicle{s: t.m.NewSession()}
+		t.sessions[ping.VehicleID] = v
+	}
+	p := geo.Point{Lat: ping.Latitude, Lon: ping.Longitude}
+	pg := pending{ping: ping, headers: headers, pos: pos}
+	if ping.Timestamp < v.s.LastSeen {
+		return []Settled{t.settled(pg, t.m.Nearest(p))}
+	}
+	v.arrived = now
+	v.pending = append(v.pending, pg)
+	return t.settle(v, v.s.Step(p, ping.Timestamp))
+}
+
+// Flush settles the pending pings of vehicles with no ping since before,
+// so a vehicle going silent does not hold back its last matches.
+func (t *Tracker) Flush(before time.Time) []Settled {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var out []Settled
+	for _, v := range t.sessions {
+		if len(v.pending) > 0 && v.arrived.Before(before) {
+			out = append(out, t.settle(v, v.s.Flush())...)
+		}
+	}
+	return out
+}
+
+// settle pairs the matches a session settled with its oldest pending pings.
+func (t *Tracker) settle(v *vehicle, matches []Match) []Settled {
+	out := make([]Settled, len(matches))
+	for i, m := range matches {
+		out[i] = t.settled(v.pending[i], m)
+	}
+	v.pending = append(v.pending[:0], v.pending[len(matches):]...)
+	return out
+}
+
+// settled builds the published record for a ping and its match.
+func (t *Tracker) settled(pg pending, m Match) Settled {
+	out := MatchedPing{PingPayload: pg.ping, Matched: m.Matched}
+	if m.Matched {
+		e := &t.m.g.Edges[m.Edge]
+		out.MatchedLatitude = m.Point.Lat
+		out.MatchedLongitude = m.Point.Lon
+		out.SegmentID = e.SegmentID()
+		out.WayID = e.WayID
+		out.RoadName = e.Name
+		out.RoadClass = e.Class
+		out.DistanceFromRoad = m.Distance
+	}
+	return Settled{Ping: out, Headers: pg.headers, Pos: pg.pos}
+}
+
+// Evict forgets vehicles whose last ping is older than before (Unix
+// seconds) and returns how many were removed. Vehicles with pings still
+// pending are kept until a Flush settles them.
+func (t *Tracker) Evict(before int64) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := 0
+	for id, v := range t.sessions {
+		if len(v.pending) == 0 && v.s.LastSeen < before {
+			delete(t.sessions, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Vehicles returns the number of vehicles with a session.
+func (t *Tracker) Vehicles() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.sessions)
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metric and label names follow the go-grpc-prometheus conventions so
+// existing dashboards and recording rules keep working.
+var (
+	grpcStarted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_started_total",
+		Help: "Total number of RPCs started on the server",
+	}, []string{"grpc_type", "grpc_service", "grpc_method"})
+	grpcHandled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, regardless of success or failure",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+	grpcHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Histogram of response latency of RPCs handled by the server",
+		Buckets: []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"})
+)
+
+// UnaryServerInterceptor records start count, latency and status code for
+// every unary RPC.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		service, method := splitMethodName(info.FullMethod)
+		grpcStarted.WithLabelValues("unary", service, method).Inc()
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		code := status.Code(err).String()
+
+		grpcHandled.WithLabelValues("unary", service, method, code).Inc()
+		grpcHandlingSeconds.WithLabelValues("unary", service, method, code).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the same metrics for streaming RPCs,
+// timing the whole stream.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		typ := "bidi_stream"
+		switch {
+		case info.IsServerStream && !info.IsClientStream:
+			typ = "server_stream"
+		case info.IsClientStream && !info.IsServerStream:
+			typ = "client_stream"
+		}
+		service, method := splitMethodName(info.FullMethod)
+		grpcStarted.WithLabelValues(typ, service, method).Inc()
+
+		start := time.Now()
+		err := handler(srv, ss)
+		code := status.Code(err).String()
+
+		grpcHandled.WithLabelValues(typ, service, method, code).Inc()
+		grpcHandlingSeconds.WithLabelValues(typ, service, method, code).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// splitMethodName turns "/tracker.TrackerService/SendPing" into
+// ("tracker.TrackerService", "SendPing").
+func splitMethodName(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
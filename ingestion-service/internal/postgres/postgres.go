@@ -0,0 +1,54 @@
+// Package postgres opens connections to the NexusLogistics PostgreSQL
+// database using the same POSTGRES_* environment variables as the tracking
+// service.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// DSNFromEnv builds a connection string from POSTGRES_HOST, POSTGRES_PORT,
+// POSTGRES_USER, POSTGRES_PASSWORD and POSTGRES_DB, with the tracking
+// service's defaults.
+func DSNFromEnv() string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(getEnv("POSTGRES_USER", "nexus"), getEnv("POSTGRES_PASSWORD", "password")),
+		Host:     getEnv("POSTGRES_HOST", "localhost") + ":" + getEnv("POSTGRES_PORT", "5432"),
+		Path:     getEnv("POSTGRES_DB", "nexus_logistics"),
+		RawQuery: "sslmode=" + getEnv("POSTGRES_SSLMODE", "disable"),
+	}
+	return u.String()
+}
+
+// Open connects to dsn and verifies the connection.
+func Open(ctx context.Context, dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres: %w", err)
+	}
+	db.SetMaxOpenConns(10)
+	db.SetConnMaxIdleTime(5 * time.Minute)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	return db, nil
+}
+
+func getEnv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
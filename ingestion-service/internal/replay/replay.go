@@ -0,0 +1,140 @@
+// Package replay re-publishes recorded pings with their original timing, so
+// an incident's ping stream can be fed back through the pipeline.
+package replay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+// maxBatch bounds the pings sent together when several are due at once.
+const maxBatch = 1000
+
+// Event is a recorded ping and when it originally arrived.
+type Event struct {
+	Ping service.PingPayload
+	At   time.Time
+}
+
+// Source yields events in arrival order and io.EOF after the last.
+type Source interface {
+	Next(ctx context.Context) (Event, error)
+}
+
+// Sink publishes pings.
+type Sink interface {
+	Send(ctx context.Context, pings []service.PingPayload) error
+}
+
+type Options struct {
+	// Speed multiplies the original pace; 0 sends as fast as the sink
+	// accepts.
+	Speed float64
+	// VehicleID rewrites vehicle IDs, with "{id}" standing for the
+	// original, e.g. "replay-{id}". Empty keeps them.
+	VehicleID string
+	// ShiftTime moves timestamps onto the replay clock: the first ping is
+	// stamped with the start of the replay and later ones by their scaled
+	// offset from it, so the pipeline sees live traffic. At speeds above 1
+	// vehicles therefore appear to move that much faster; at speed 0 the
+	// original spacing is kept.
+	ShiftTime bool
+	// Progress is how often progress is logged; 0 disables it.
+	Progress time.Duration
+}
+
+// Stats summarises a replay.
+type Stats struct {
+	Sent int64
+	// MaxLag is the furthest sending fell behind the scaled schedule.
+	MaxLag time.Duration
+}
+
+// Run sends every event from src to sink, spacing them as they originally
+// arrived divided by opts.Speed, until src is exhausted or ctx is
+// cancelled. Pings due together are sent as one batch.
+func Run(ctx context.Context, src Source, sink Sink, opts Options) (Stats, error) {
+	var (
+		stats        Stats
+		batch        []service.PingPayload
+		start        time.Time
+		first        Event
+		lastProgress = time.Now()
+	)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := sink.Send(ctx, batch); err != nil {
+			return err
+		}
+		stats.Sent += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	for n := 0; ; n++ {
+		ev, err := src.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return stats, err
+		}
+		if n == 0 {
+			start, first = time.Now(), ev
+		}
+
+		due := start.Add(scale(ev.At.Sub(first.At), opts.Speed))
+		if wait := time.Until(due); wait > 0 {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+			select {
+			case <-ctx.Done():
+				return stats, ctx.Err()
+			case <-time.After(time.Until(due)):
+			}
+		} else if lag := -wait; opts.Speed > 0 && lag > stats.MaxLag {
+			stats.MaxLag = lag
+		}
+
+		p := ev.Ping
+		if opts.VehicleID != "" {
+			p.VehicleID = strings.ReplaceAll(opts.VehicleID, "{id}", p.VehicleID)
+		}
+		if opts.ShiftTime {
+			offset := time.Duration(p.Timestamp-first.Ping.Timestamp) * time.Second
+			if opts.Speed > 0 {
+				offset = scale(offset, opts.Speed)
+			}
+			p.Timestamp = start.Add(offset).Unix()
+		}
+		batch = append(batch, p)
+		if len(batch) >= maxBatch {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+		}
+
+		if opts.Progress > 0 && time.Since(lastProgress) >= opts.Progress {
+			lastProgress = time.Now()
+			slog.Info("Replay progress", "sent", stats.Sent, "replayed_to", ev.At.UTC(), "max_lag", stats.MaxLag)
+		}
+	}
+	return stats, flush()
+}
+
+// scale divides d by speed; speed 0 collapses all waits.
+func scale(d time.Duration, speed float64) time.Duration {
+	if speed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(d) / speed)
+}
@@ -0,0 +1,66 @@
+package replay
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/nexus-logistics/ingestion-service/internal/tenant"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+)
+
+// KafkaSink produces pings to the producer's topic, keyed by vehicle ID
+// like the ingestion service, bypassing ingestion.
+type KafkaSink struct {
+	producer *kafka.Producer
+}
+
+func NewKafkaSink(p *kafka.Producer) *KafkaSink {
+	return &KafkaSink{producer: p}
+}
+
+func (s *KafkaSink) Send(ctx context.Context, pings []service.PingPayload) error {
+	msgs := make([]kafka.Message, len(pings))
+	for i, p := range pings {
+		msgs[i] = kafka.Message{Key: p.VehicleID, Value: p}
+	}
+	return s.producer.ProduceBatch(ctx, msgs)
+}
+
+// GRPCSink sends pings through the ingestion service's SendPing, so they
+// pass validation, anomaly detection and topic routing again.
+type GRPCSink struct {
+	client pb.TrackerServiceClient
+	apiKey string
+}
+
+// NewGRPCSink authenticates with apiKey when set. Otherwise each ping's
+// recorded tenant is sent in the x-tenant-id header, which ingestion only
+// honours when configured to trust it.
+func NewGRPCSink(client pb.TrackerServiceClient, apiKey string) *GRPCSink {
+	return &GRPCSink{client: client, apiKey: apiKey}
+}
+
+func (s *GRPCSink) Send(ctx context.Context, pings []service.PingPayload) error {
+	for _, p := range pings {
+		md := metadata.MD{}
+		if s.apiKey != "" {
+			md.Set("authorization", "Bearer "+s.apiKey)
+		} else if p.Tenant != "" {
+			md.Set(tenant.Header, p.Tenant)
+		}
+		_, err := s.client.SendPing(metadata.NewOutgoingContext(ctx, md), &pb.LocationPing{
+			VehicleId:  p.VehicleID,
+			Latitude:   p.Latitude,
+			Longitude:  p.Longitude,
+			Timestamp:  p.Timestamp,
+			Attributes: p.Attributes,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
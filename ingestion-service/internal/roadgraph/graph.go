@@ -0,0 +1,325 @@
+// Package roadgraph builds a routable road network from an OpenStreetMap
+// PBF extract and indexes its segments for nearest-road lookups.
+package roadgraph
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// defaultSpeeds is the assumed speed in km/h of each drivable highway
+// class when a way has no usable maxspeed tag.
+var defaultSpeeds = map[string]float64{
+	"motorway":       100,
+	"motorway_link":  60,
+	"trunk":          80,
+	"trunk_link":     50,
+	"primary":        65,
+	"primary_link":   45,
+	"secondary":      55,
+	"secondary_link": 40,
+	"tertiary":       45,
+	"tertiary_link":  35,
+	"unclassified":   35,
+	"residential":    30,
+	"living_street":  10,
+	"service":        15,
+	"road":           30,
+}
+
+type Node struct {
+	OSMID int64
+	Point geo.Point
+}
+
+// Edge is a directed road segment between two consecutive nodes of a way.
+// Two-way roads have one edge in each direction, sharing a SegmentID.
+type Edge struct {
+	From, To int32
+	WayID    int64
+	// Seq is the index of the segment within its way.
+	Seq int32
+	// Forward is false for edges that run against the way's node order.
+	Forward  bool
+	Length   float64 // meters
+	SpeedKPH float64
+	Class    string
+	Name     string
+}
+
+// SegmentID identifies the road segment independently of direction. It is
+// stable for a given extract.
+func (e *Edge) SegmentID() string {
+	return fmt.Sprintf("%d:%d", e.WayID, e.Seq)
+}
+
+// TravelSeconds is the free-flow time to drive the whole edge.
+func (e *Edge) TravelSeconds() float64 {
+	return e.Length / (e.SpeedKPH / 3.6)
+}
+
+const cellDegrees = 0.01
+
+type cell struct{ x, y int32 }
+
+func cellOf(lat, lon float64) cell {
+	return cell{x: int32(math.Floor(lon / cellDegrees)), y: int32(math.Floor(lat / cellDegrees))}
+}
+
+// Graph is an immutable directed road graph. Edges are sorted by From, so
+// the edges leaving a node are a contiguous range.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+	// firstOut[n] is the index of the first edge leaving node n.
+	firstOut []int32
+	// inEdges[firstIn[n]:firstIn[n+1]] are the edges entering node n.
+	firstIn  []int32
+	inEdges  []int32
+	cells    map[cell][]int32
+	maxSpeed float64
+}
+
+// Out returns the indices of the edges leaving node n as [from, to).
+func (g *Graph) Out(n int32) (int32, int32) {
+	return g.firstOut[n], g.firstOut[n+1]
+}
+
+// In returns the indices of the edges entering node n.
+func (g *Graph) In(n int32) []int32 {
+	return g.inEdges[g.firstIn[n]:g.firstIn[n+1]]
+}
+
+// MaxSpeedKPH is the highest edge speed, which bounds travel time from
+// below for A* heuristics.
+func (g *Graph) MaxSpeedKPH() float64 {
+	return g.maxSpeed
+}
+
+// Way is an OSM way reduced to what the graph needs.
+type Way struct {
+	ID   int64
+	Tags map[string]string
+	Refs []int64
+}
+
+// Load reads a PBF extract and builds the graph of its drivable roads. The
+// file is read twice: once for the ways, then for the coordinates of the
+// nodes they use.
+func Load(path string) (*Graph, error) {
+	var ways []Way
+	needed := make(map[int64]geo.Point)
+	err := scanFile(path, Handler{Way: func(id int64, tags map[string]string, refs []int64) {
+		if !drivable(tags) || len(refs) < 2 {
+			return
+		}
+		ways = append(ways, Way{ID: id, Tags: tags, Refs: refs})
+		for _, ref := range refs {
+			needed[ref] = geo.Point{Lat: math.NaN()}
+		}
+	}})
+	if err != nil {
+		return nil, err
+	}
+	err = scanFile(path, Handler{Node: func(id int64, p geo.Point) {
+		if _, ok := needed[id]; ok {
+			needed[id] = p
+		}
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return Build(needed, ways), nil
+}
+
+func scanFile(path string, h Handler) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := ScanPBF(f, h); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// drivable reports whether a way is a road open to motor vehicles.
+func drivable(tags map[string]string) bool {
+	if _, ok := defaultSpeeds[tags["highway"]]; !ok {
+		return false
+	}
+	if tags["area"] == "yes" {
+		return false
+	}
+	switch tags["motor_vehicle"] {
+	case "yes", "designated", "destination", "delivery":
+		return true
+	case "no", "private":
+		return false
+	}
+	switch tags["access"] {
+	case "no", "private":
+		return false
+	}
+	return true
+}
+
+// oneway returns 1 for ways drivable only in node order, -1 only against
+// it, and 0 for two-way roads.
+func oneway(tags map[string]string) int {
+	switch tags["oneway"] {
+	case "yes", "1", "true":
+		return 1
+	case "-1", "reverse":
+		return -1
+	case "no", "0", "false":
+		return 0
+	}
+	if tags["junction"] == "roundabout" || tags["highway"] == "motorway" {
+		return 1
+	}
+	return 0
+}
+
+// speed parses maxspeed ("50", "30 mph") or falls back to the class default.
+func speed(tags map[string]string) float64 {
+	v := strings.TrimSpace(tags["maxspeed"])
+	factor := 1.0
+	if strings.HasSuffix(v, "mph") {
+		v = strings.TrimSpace(strings.TrimSuffix(v, "mph"))
+		factor = 1.609344
+	}
+	if kph, err := strconv.ParseFloat(v, 64); err == nil && kph > 0 {
+		return kph * factor
+	}
+	return defaultSpeeds[tags["highway"]]
+}
+
+// Build creates a graph from ways and the coordinates of their nodes.
+// Segments touching a node without coordinates, as at the edge of an
+// extract, are dropped.
+func Build(coords map[int64]geo.Point, ways []Way) *Graph {
+	g := &Graph{cells: make(map[cell][]int32)}
+	index := make(map[int64]int32)
+	nodeOf := func(id int64) (int32, bool) {
+		if i, ok := index[id]; ok {
+			return i, true
+		}
+		p, ok := coords[id]
+		if !ok || !p.Valid() {
+			return 0, false
+		}
+		i := int32(len(g.Nodes))
+		index[id] = i
+		g.Nodes = append(g.Nodes, Node{OSMID: id, Point: p})
+		return i, true
+	}
+
+	for _, w := range ways {
+		dir := oneway(w.Tags)
+		kph := speed(w.Tags)
+		for seq := 0; seq+1 < len(w.Refs); seq++ {
+			a, okA := nodeOf(w.Refs[seq])
+			b, okB := nodeOf(w.Refs[seq+1])
+			if !okA || !okB || a == b {
+				continue
+			}
+			e := Edge{
+				WayID:    w.ID,
+				Seq:      int32(seq),
+				Length:   geo.Distance(g.Nodes[a].Point, g.Nodes[b].Point),
+				SpeedKPH: kph,
+				Class:    w.Tags["highway"],
+				Name:     w.Tags["name"],
+			}
+			if dir >= 0 {
+				e.From, e.To, e.Forward = a, b, true
+				g.Edges = append(g.Edges, e)
+			}
+			if dir <= 0 {
+				e.From, e.To, e.Forward = b, a, false
+				g.Edges = append(g.Edges, e)
+			}
+		}
+	}
+
+	sort.SliceStable(g.Edges, func(i, j int) bool { return g.Edges[i].From < g.Edges[j].From })
+	g.firstOut = make([]int32, len(g.Nodes)+1)
+	for _, e := range g.Edges {
+		g.firstOut[e.From+1]++
+	}
+	for i := 1; i < len(g.firstOut); i++ {
+		g.firstOut[i] += g.firstOut[i-1]
+	}
+
+	g.firstIn = make([]int32, len(g.Nodes)+1)
+	for _, e := range g.Edges {
+		g.firstIn[e.To+1]++
+	}
+	for i := 1; i < len(g.firstIn); i++ {
+		g.firstIn[i] += g.firstIn[i-1]
+	}
+	g.inEdges = make([]int32, len(g.Edges))
+	next := append([]int32(nil), g.firstIn[:len(g.Nodes)]...)
+	for i, e := range g.Edges {
+		g.inEdges[next[e.To]] = int32(i)
+		next[e.To]++
+		g.maxSpeed = math.Max(g.maxSpeed, e.SpeedKPH)
+	}
+
+	for i := range g.Edges {
+		e := &g.Edges[i]
+		a, b := g.Nodes[e.From].Point, g.Nodes[e.To].Point
+		lo := cellOf(math.Min(a.Lat, b.Lat), math.Min(a.Lon, b.Lon))
+		hi := cellOf(math.Max(a.Lat, b.Lat), math.Max(a.Lon, b.Lon))
+		for x := lo.x; x <= hi.x; x++ {
+			for y := lo.y; y <= hi.y; y++ {
+				c := cell{x, y}
+				g.cells[c] = append(g.cells[c], int32(i))
+			}
+		}
+	}
+	return g
+}
+
+// Candidate is the closest point of an edge to a query position.
+type Candidate struct {
+	Edge int32
+	// Point is the projection onto the edge, Fraction how far along the
+	// edge it lies (0 at From, 1 at To).
+	Point    geo.Point
+	Fraction float64
+	Distance float64 // meters from the query position
+}
+
+// Nearby returns the edges within radius meters of p, closest first.
+func (g *Graph) Nearby(p geo.Point, radius float64) []Candidate {
+	b := geo.Around(p, radius)
+	lo, hi := cellOf(b.MinLat, b.MinLon), cellOf(b.MaxLat, b.MaxLon)
+	seen := make(map[int32]bool)
+	var out []Candidate
+	for x := lo.x; x <= hi.x; x++ {
+		for y := lo.y; y <= hi.y; y++ {
+			for _, i := range g.cells[cell{x, y}] {
+				if seen[i] {
+					continue
+				}
+				seen[i] = true
+				e := &g.Edges[i]
+				proj, t := geo.Project(p, g.Nodes[e.From].Point, g.Nodes[e.To].Point)
+				if d := geo.Distance(p, proj); d <= radius {
+					out = append(out, Candidate{Edge: i, Point: proj, Fraction: t, Distance: d})
+				}
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Distance < out[j].Distance })
+	return out
+}
@@ -0,0 +1,367 @@
+package roadgraph
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// The OSM PBF format is a sequence of blobs, each preceded by a 4-byte
+// length and a BlobHeader. Blobs are zlib-compressed protobuf messages
+// (https://wiki.openstreetmap.org/wiki/PBF_Format). Only the messages and
+// fields needed to build a road graph are decoded.
+
+const (
+	maxHeaderSize = 64 * 1024
+	maxBlobSize   = 32 * 1024 * 1024
+)
+
+// supportedFeatures are the HeaderBlock required_features this reader
+// understands.
+var supportedFeatures = map[string]bool{
+	"OsmSchema-V0.6": true,
+	"DenseNodes":     true,
+}
+
+// Handler receives the elements of a PBF file. Nil callbacks skip decoding
+// of that element type.
+type Handler struct {
+	Node func(id int64, p geo.Point)
+	Way  func(id int64, tags map[string]string, refs []int64)
+}
+
+// ScanPBF reads an OSM PBF stream and calls h for every node and way.
+func ScanPBF(r io.Reader, h Handler) error {
+	var lenBuf [4]byte
+	for {
+		if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read blob header length: %w", err)
+		}
+		headerLen := binary.BigEndian.Uint32(lenBuf[:])
+		if headerLen > maxHeaderSize {
+			return fmt.Errorf("blob header of %d bytes is too large", headerLen)
+		}
+		header := make([]byte, headerLen)
+		if _, err := io.ReadFull(r, header); err != nil {
+			return fmt.Errorf("failed to read blob header: %w", err)
+		}
+		blobType, blobLen, err := parseBlobHeader(header)
+		if err != nil {
+			return err
+		}
+		if blobLen > maxBlobSize {
+			return fmt.Errorf("blob of %d bytes is too large", blobLen)
+		}
+		blob := make([]byte, blobLen)
+		if _, err := io.ReadFull(r, blob); err != nil {
+			return fmt.Errorf("failed to read blob: %w", err)
+		}
+
+		switch blobType {
+		case "OSMHeader":
+			data, err := blobData(blob)
+			if err != nil {
+				return err
+			}
+			if err := checkHeader(data); err != nil {
+				return err
+			}
+		case "OSMData":
+			if h.Node == nil && h.Way == nil {
+				continue
+			}
+			data, err := blobData(blob)
+			if err != nil {
+				return err
+			}
+			if err := parsePrimitiveBlock(data, h); err != nil {
+				return err
+			}
+		default:
+			// Unknown blob types are skipped, as the format requires.
+		}
+	}
+}
+
+// fields iterates over the fields of a protobuf message, calling fn with the
+// field number, wire type and raw value (varint or bytes).
+func fields(b []byte, fn func(num protowire.Number, typ protowire.Type, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		var v uint64
+		var data []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			data, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if err := fn(num, typ, v, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// varints decodes a repeated varint field, packed or not, appending to dst.
+func varints(dst []uint64, typ protowire.Type, v uint64, data []byte) ([]uint64, error) {
+	if typ == protowire.VarintType {
+		return append(dst, v), nil
+	}
+	for len(data) > 0 {
+		x, n := protowire.ConsumeVarint(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		dst = append(dst, x)
+		data = data[n:]
+	}
+	return dst, nil
+}
+
+func parseBlobHeader(b []byte) (string, int, error) {
+	var typ string
+	size := -1
+	err := fields(b, func(num protowire.Number, _ protowire.Type, v uint64, data []byte) error {
+		switch num {
+		case 1:
+			typ = string(data)
+		case 3:
+			size = int(int32(v))
+		}
+		return nil
+	})
+	if err == nil && (typ == "" || size < 0) {
+		err = errors.New("blob header without type or size")
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid blob header: %w", err)
+	}
+	return typ, size, nil
+}
+
+// blobData returns the uncompressed content of a Blob.
+func blobData(b []byte) ([]byte, error) {
+	var raw, compressed []byte
+	rawSize := 0
+	unsupported := false
+	err := fields(b, func(num protowire.Number, _ protowire.Type, v uint64, data []byte) error {
+		switch num {
+		case 1:
+			raw = data
+		case 2:
+			rawSize = int(int32(v))
+		case 3:
+			compressed = data
+		case 4, 5, 6, 7:
+			unsupported = true
+		}
+		return nil
+	})
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("invalid blob: %w", err)
+	case raw != nil:
+		return raw, nil
+	case compressed != nil:
+		if rawSize > maxBlobSize {
+			return nil, fmt.Errorf("blob of %d bytes is too large", rawSize)
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, fmt.Errorf("invalid zlib blob: %w", err)
+		}
+		defer zr.Close()
+		out := bytes.NewBuffer(make([]byte, 0, rawSize))
+		if _, err := io.Copy(out, io.LimitReader(zr, maxBlobSize)); err != nil {
+			return nil, fmt.Errorf("invalid zlib blob: %w", err)
+		}
+		return out.Bytes(), nil
+	case unsupported:
+		return nil, errors.New("blob compression is not supported, only zlib")
+	default:
+		return nil, errors.New("empty blob")
+	}
+}
+
+func checkHeader(b []byte) error {
+	return fields(b, func(num protowire.Number, _ protowire.Type, _ uint64, data []byte) error {
+		if num == 4 && !supportedFeatures[string(data)] {
+			return fmt.Errorf("unsupported PBF feature %q", data)
+		}
+		return nil
+	})
+}
+
+// block holds the PrimitiveBlock fields needed to decode its groups.
+type block struct {
+	strings     []string
+	granularity int64
+	latOffset   int64
+	lonOffset   int64
+}
+
+func (b *block) point(lat, lon int64) geo.Point {
+	return geo.Point{
+		Lat: 1e-9 * float64(b.latOffset+b.granularity*lat),
+		Lon: 1e-9 * float64(b.lonOffset+b.granularity*lon),
+	}
+}
+
+func parsePrimitiveBlock(data []byte, h Handler) error {
+	blk := block{granularity: 100}
+	var groups [][]byte
+	err := fields(data, func(num protowire.Number, _ protowire.Type, v uint64, d []byte) error {
+		switch num {
+		case 1:
+			return fields(d, func(num protowire.Number, _ protowire.Type, _ uint64, s []byte) error {
+				if num == 1 {
+					blk.strings = append(blk.strings, string(s))
+				}
+				return nil
+			})
+		case 2:
+			groups = append(groups, d)
+		case 17:
+			blk.granularity = int64(int32(v))
+		case 19:
+			blk.latOffset = int64(v)
+		case 20:
+			blk.lonOffset = int64(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("invalid primitive block: %w", err)
+	}
+
+	// Groups are decoded after the whole block so that the offsets and
+	// granularity are known regardless of field order.
+	for _, g := range groups {
+		err := fields(g, func(num protowire.Number, _ protowire.Type, _ uint64, d []byte) error {
+			switch {
+			case num == 1 && h.Node != nil:
+				return blk.parseNode(d, h.Node)
+			case num == 2 && h.Node != nil:
+				return blk.parseDenseNodes(d, h.Node)
+			case num == 3 && h.Way != nil:
+				return blk.parseWay(d, h.Way)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("invalid primitive group: %w", err)
+		}
+	}
+	return nil
+}
+
+func (b *block) parseNode(data []byte, fn func(int64, geo.Point)) error {
+	var id, lat, lon int64
+	err := fields(data, func(num protowire.Number, _ protowire.Type, v uint64, _ []byte) error {
+		switch num {
+		case 1:
+			id = protowire.DecodeZigZag(v)
+		case 8:
+			lat = protowire.DecodeZigZag(v)
+		case 9:
+			lon = protowire.DecodeZigZag(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fn(id, b.point(lat, lon))
+	return nil
+}
+
+func (b *block) parseDenseNodes(data []byte, fn func(int64, geo.Point)) error {
+	var ids, lats, lons []uint64
+	err := fields(data, func(num protowire.Number, typ protowire.Type, v uint64, d []byte) error {
+		var err error
+		switch num {
+		case 1:
+			ids, err = varints(ids, typ, v, d)
+		case 8:
+			lats, err = varints(lats, typ, v, d)
+		case 9:
+			lons, err = varints(lons, typ, v, d)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(lats) != len(ids) || len(lons) != len(ids) {
+		return errors.New("dense nodes with mismatched id and coordinate counts")
+	}
+	var id, lat, lon int64
+	for i := range ids {
+		id += protowire.DecodeZigZag(ids[i])
+		lat += protowire.DecodeZigZag(lats[i])
+		lon += protowire.DecodeZigZag(lons[i])
+		fn(id, b.point(lat, lon))
+	}
+	return nil
+}
+
+func (b *block) parseWay(data []byte, fn func(int64, map[string]string, []int64)) error {
+	var id int64
+	var keys, vals, refs []uint64
+	err := fields(data, func(num protowire.Number, typ protowire.Type, v uint64, d []byte) error {
+		var err error
+		switch num {
+		case 1:
+			id = int64(v)
+		case 2:
+			keys, err = varints(keys, typ, v, d)
+		case 3:
+			vals, err = varints(vals, typ, v, d)
+		case 8:
+			refs, err = varints(refs, typ, v, d)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(keys) != len(vals) {
+		return fmt.Errorf("way %d has mismatched tag keys and values", id)
+	}
+	tags := make(map[string]string, len(keys))
+	for i := range keys {
+		if keys[i] >= uint64(len(b.strings)) || vals[i] >= uint64(len(b.strings)) {
+			return fmt.Errorf("way %d references a missing string", id)
+		}
+		tags[b.strings[keys[i]]] = b.strings[vals[i]]
+	}
+	nodeRefs := make([]int64, len(refs))
+	var ref int64
+	for i, r := range refs {
+		ref += protowire.DecodeZigZag(r)
+		nodeRefs[i] = ref
+	}
+	fn(id, tags, nodeRefs)
+	return nil
+}
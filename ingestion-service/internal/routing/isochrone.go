@@ -0,0 +1,140 @@
+package routing
+
+import (
+	"container/heap"
+	"encoding/json"
+	"math"
+	"sort"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// Isochrone is the area reachable within a travel time.
+type Isochrone struct {
+	Seconds  float64
+	Polygons []geo.Polygon
+}
+
+// Isochrones returns, for each band in seconds, the area reachable by road
+// from origin within that time, in ascending order of band. It runs one
+// Dijkstra search bounded by the largest band, collects the road stretches
+// reached within each band, partial edges included, and traces their hull
+// on a grid of cell meters.
+func (r *Router) Isochrones(origin geo.Point, bands []float64, cell float64) ([]Isochrone, error) {
+	src, err := r.Snap(origin)
+	if err != nil {
+		return nil, err
+	}
+	bands = append([]float64(nil), bands...)
+	sort.Float64s(bands)
+	if len(bands) == 0 {
+		return nil, nil
+	}
+	limit := bands[len(bands)-1]
+
+	g := r.g
+	s := r.pool.Get().(*search)
+	defer func() {
+		s.reset()
+		r.pool.Put(s)
+	}()
+	for i, a := range src {
+		e := &g.Edges[a.Edge]
+		d := (1 - a.Fraction) * e.TravelSeconds()
+		s.relax(e.To, d, seedVia(i), d)
+	}
+	for s.queue.Len() > 0 && s.queue[0].key <= limit {
+		it := heap.Pop(&s.queue).(item)
+		n := it.node
+		if s.settled[n] {
+			continue
+		}
+		s.settled[n] = true
+		from, to := g.Out(n)
+		for e := from; e < to; e++ {
+			d := s.dist[n] + g.Edges[e].TravelSeconds()
+			s.relax(g.Edges[e].To, d, e, d)
+		}
+	}
+
+	isochrones := make([]Isochrone, len(bands))
+	for k, band := range bands {
+		var segments []geo.Segment
+		// The stretch from the origin to the end of its road.
+		for _, a := range src {
+			e := &g.Edges[a.Edge]
+			f := math.Min(1, a.Fraction+band/e.TravelSeconds())
+			segments = append(segments, geo.Segment{
+				A: a.Point,
+				B: geo.Interpolate(g.Nodes[e.From].Point, g.Nodes[e.To].Point, f),
+			})
+		}
+		for _, n := range s.touched {
+			if !s.settled[n] || s.dist[n] > band {
+				continue
+			}
+			from, to := g.Out(n)
+			for i := from; i < to; i++ {
+				e := &g.Edges[i]
+				f := math.Min(1, (band-s.dist[n])/e.TravelSeconds())
+				segments = append(segments, geo.Segment{
+					A: g.Nodes[n].Point,
+					B: geo.Interpolate(g.Nodes[n].Point, g.Nodes[e.To].Point, f),
+				})
+			}
+		}
+		isochrones[k] = Isochrone{Seconds: band, Polygons: geo.SegmentHull(segments, cell)}
+	}
+	return isochrones, nil
+}
+
+type featureCollection struct {
+	Type     string    `json:"type"`
+	Features []feature `json:"features"`
+}
+
+type feature struct {
+	Type       string            `json:"type"`
+	Geometry   multiPolygon      `json:"geometry"`
+	Properties isochroneProperty `json:"properties"`
+}
+
+type multiPolygon struct {
+	Type        string           `json:"type"`
+	Coordinates [][][][2]float64 `json:"coordinates"`
+}
+
+type isochroneProperty struct {
+	Seconds float64 `json:"seconds"`
+}
+
+// IsochroneGeoJSON encodes isochrones as a GeoJSON FeatureCollection with
+// one MultiPolygon feature per band.
+func IsochroneGeoJSON(isochrones []Isochrone) ([]byte, error) {
+	fc := featureCollection{Type: "FeatureCollection", Features: make([]feature, 0, len(isochrones))}
+	for _, iso := range isochrones {
+		mp := multiPolygon{Type: "MultiPolygon", Coordinates: make([][][][2]float64, 0, len(iso.Polygons))}
+		for _, poly := range iso.Polygons {
+			rings := [][][2]float64{lonLat(poly.Outer)}
+			for _, h := range poly.Holes {
+				rings = append(rings, lonLat(h))
+			}
+			mp.Coordinates = append(mp.Coordinates, rings)
+		}
+		fc.Features = append(fc.Features, feature{
+			Type:       "Feature",
+			Geometry:   mp,
+			Properties: isochroneProperty{Seconds: iso.Seconds},
+		})
+	}
+	return json.Marshal(fc)
+}
+
+// lonLat converts a ring to GeoJSON positions, rounded to about 10 cm.
+func lonLat(ring []geo.Point) [][2]float64 {
+	out := make([][2]float64, len(ring))
+	for i, p := range ring {
+		out[i] = [2]float64{math.Round(p.Lon*1e6) / 1e6, math.Round(p.Lat*1e6) / 1e6}
+	}
+	return out
+}
@@ -0,0 +1,175 @@
+package routing
+
+import (
+	"container/heap"
+	"context"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// Matrix holds travel times and distances between points. Unreachable
+// pairs, and points too far from any road, are +Inf.
+type Matrix struct {
+	Durations [][]float64 // seconds
+	Distances [][]float64 // meters
+}
+
+// Matrix computes the fastest routes from every source to every target,
+// running one forward search per source in parallel.
+func (r *Router) Matrix(sources, targets []geo.Point) *Matrix {
+	m := &Matrix{
+		Durations: make([][]float64, len(sources)),
+		Distances: make([][]float64, len(sources)),
+	}
+	r.MatrixRows(context.Background(), sources, targets, func(i int, durations, distances []float64) error {
+		m.Durations[i], m.Distances[i] = durations, distances
+		return nil
+	})
+	return m
+}
+
+// MatrixRows computes the same costs as Matrix but hands each source's row
+// to fn as soon as it is ready, in no particular order. Calls to fn are
+// serialized. It stops early when ctx is done or fn fails.
+func (r *Router) MatrixRows(ctx context.Context, sources, targets []geo.Point, fn func(i int, durations, distances []float64) error) error {
+	dstSeeds := make([][]Seed, len(targets))
+	for j, p := range targets {
+		dstSeeds[j], _ = r.Snap(p)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type row struct {
+		i                    int
+		durations, distances []float64
+	}
+	work := make(chan int)
+	rows := make(chan row)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				durations, distances := r.oneToMany(sources[i], dstSeeds)
+				select {
+				case rows <- row{i, durations, distances}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(work)
+		for i := range sources {
+			select {
+			case work <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(rows)
+	}()
+
+	var err error
+	for rw := range rows {
+		if err == nil {
+			err = fn(rw.i, rw.durations, rw.distances)
+		}
+		if err != nil {
+			cancel()
+		}
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
+}
+
+// oneToMany runs Dijkstra from p until every target's seeds are settled.
+func (r *Router) oneToMany(p geo.Point, targets [][]Seed) ([]float64, []float64) {
+	g := r.g
+	durations := make([]float64, len(targets))
+	distances := make([]float64, len(targets))
+	for j := range targets {
+		durations[j], distances[j] = math.Inf(1), math.Inf(1)
+	}
+	src, err := r.Snap(p)
+	if err != nil {
+		return durations, distances
+	}
+
+	s := r.pool.Get().(*search)
+	defer func() {
+		s.reset()
+		r.pool.Put(s)
+	}()
+
+	pending := make(map[int32]bool)
+	for _, seeds := range targets {
+		for _, b := range seeds {
+			pending[g.Edges[b.Edge].From] = true
+		}
+	}
+	for i, a := range src {
+		e := &g.Edges[a.Edge]
+		d := (1 - a.Fraction) * e.TravelSeconds()
+		s.relax(e.To, d, seedVia(i), d)
+	}
+	for s.queue.Len() > 0 && len(pending) > 0 {
+		it := heap.Pop(&s.queue).(item)
+		n := it.node
+		if s.settled[n] {
+			continue
+		}
+		s.settled[n] = true
+		delete(pending, n)
+		from, to := g.Out(n)
+		for e := from; e < to; e++ {
+			d := s.dist[n] + g.Edges[e].TravelSeconds()
+			s.relax(g.Edges[e].To, d, e, d)
+		}
+	}
+
+	for j, seeds := range targets {
+		for _, b := range seeds {
+			for _, a := range src {
+				if a.Edge == b.Edge && b.Fraction >= a.Fraction {
+					e := &g.Edges[a.Edge]
+					if d := (b.Fraction - a.Fraction) * e.TravelSeconds(); d < durations[j] {
+						durations[j], distances[j] = d, (b.Fraction-a.Fraction)*e.Length
+					}
+				}
+			}
+			e := &g.Edges[b.Edge]
+			if !s.settled[e.From] {
+				continue
+			}
+			if d := s.dist[e.From] + b.Fraction*e.TravelSeconds(); d < durations[j] {
+				durations[j] = d
+				distances[j] = r.treeLength(s, src, e.From) + b.Fraction*e.Length
+			}
+		}
+	}
+	return durations, distances
+}
+
+// treeLength returns the driven meters from the source point to node n
+// along the search tree.
+func (r *Router) treeLength(s *search, src []Seed, n int32) float64 {
+	length := 0.0
+	for s.via[n] >= 0 {
+		e := &r.g.Edges[s.via[n]]
+		length += e.Length
+		n = e.From
+	}
+	a := src[seedIndex(s.via[n])]
+	return length + (1-a.Fraction)*r.g.Edges[a.Edge].Length
+}
@@ -0,0 +1,31 @@
+package routing
+
+import "github.com/nexus-logistics/ingestion-service/internal/geo"
+
+// Profile estimates travel costs without a road graph, from the
+// straight-line distance stretched by a detour factor and an average speed.
+type Profile struct {
+	SpeedKPH float64
+	// Detour is the typical ratio of road distance to straight-line
+	// distance.
+	Detour float64
+}
+
+// Profiles are the supported speed profiles by name.
+var Profiles = map[string]Profile{
+	"car":     {SpeedKPH: 50, Detour: 1.3},
+	"van":     {SpeedKPH: 45, Detour: 1.3},
+	"truck":   {SpeedKPH: 40, Detour: 1.35},
+	"bicycle": {SpeedKPH: 15, Detour: 1.2},
+	"foot":    {SpeedKPH: 5, Detour: 1.2},
+}
+
+// DefaultProfile is used when no profile is named.
+const DefaultProfile = "car"
+
+// Estimate returns the estimated travel time (seconds) and distance
+// (meters) from a to b.
+func (p Profile) Estimate(a, b geo.Point) (float64, float64) {
+	meters := geo.Distance(a, b) * p.Detour
+	return meters / (p.SpeedKPH / 3.6), meters
+}
@@ -0,0 +1,134 @@
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+const (
+	StatusOptimized = "OPTIMIZED"
+	StatusNoRoute   = "NO_ROUTE"
+)
+
+// RouteRequest is consumed from the route-requests topic. The field names
+// match the route service's Java model. The destination fields are
+// optional; without them the vehicle is routed to the nearest configured
+// destination.
+type RouteRequest struct {
+	VehicleID       string   `json:"vehicleId"`
+	CurrentLat      float64  `json:"currentLat"`
+	CurrentLong     float64  `json:"currentLong"`
+	DestinationLat  *float64 `json:"destinationLat,omitempty"`
+	DestinationLong *float64 `json:"destinationLong,omitempty"`
+	DestinationName string   `json:"destinationName,omitempty"`
+}
+
+// RouteUpdate is published to the route-updates topic. vehicle_id, status,
+// next_stop and eta_seconds keep their meaning from the route service.
+type RouteUpdate struct {
+	VehicleID       string     `json:"vehicle_id"`
+	Status          string     `json:"status"`
+	NextStop        string     `json:"next_stop,omitempty"`
+	ETASeconds      int64      `json:"eta_seconds"`
+	Destination     *geo.Point `json:"destination,omitempty"`
+	DistanceMeters  float64    `json:"distance_meters"`
+	DurationSeconds float64    `json:"duration_seconds"`
+	// Geometry is the route in encoded polyline format.
+	Geometry   string `json:"geometry,omitempty"`
+	Error      string `json:"error,omitempty"`
+	ComputedAt int64  `json:"computed_at"`
+}
+
+// Destination is a named place vehicles can be routed to, such as a
+// distribution center.
+type Destination struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+func (d Destination) Point() geo.Point {
+	return geo.Point{Lat: d.Latitude, Lon: d.Longitude}
+}
+
+// LoadDestinations reads a JSON array of destinations.
+func LoadDestinations(path string) ([]Destination, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read destinations: %w", err)
+	}
+	var dests []Destination
+	if err := json.Unmarshal(data, &dests); err != nil {
+		return nil, fmt.Errorf("failed to parse destinations: %w", err)
+	}
+	for _, d := range dests {
+		if d.Name == "" || !d.Point().Valid() {
+			return nil, fmt.Errorf("destination %q needs a name and valid coordinates", d.Name)
+		}
+	}
+	return dests, nil
+}
+
+// geometryToleranceMeters simplifies published route geometry.
+const geometryToleranceMeters = 5
+
+// Handle answers a route request, routing to the requested destination or
+// to whichever configured destination is fastest to reach.
+func (r *Router) Handle(req RouteRequest, dests []Destination) RouteUpdate {
+	update := RouteUpdate{VehicleID: req.VehicleID, Status: StatusNoRoute, ComputedAt: time.Now().Unix()}
+	from := geo.Point{Lat: req.CurrentLat, Lon: req.CurrentLong}
+
+	candidates := dests
+	if req.DestinationLat != nil && req.DestinationLong != nil {
+		candidates = []Destination{{Name: req.DestinationName, Latitude: *req.DestinationLat, Longitude: *req.DestinationLong}}
+	}
+	if len(candidates) == 0 {
+		update.Error = "no destination in request and none configured"
+		return update
+	}
+
+	src, err := r.Snap(from)
+	if err != nil {
+		update.Error = err.Error()
+		return update
+	}
+	var best *Route
+	var bestDest Destination
+	err = ErrNoRoute
+	for _, d := range candidates {
+		dst, snapErr := r.Snap(d.Point())
+		if snapErr != nil {
+			continue
+		}
+		route, routeErr := r.RouteSeeds(src, dst)
+		if routeErr != nil {
+			continue
+		}
+		if best == nil || route.DurationSeconds < best.DurationSeconds {
+			best, bestDest, err = route, d, nil
+		}
+	}
+	if err != nil {
+		if len(candidates) == 1 {
+			// Report why the single destination failed.
+			_, err = r.Route(from, candidates[0].Point())
+		}
+		update.Error = err.Error()
+		return update
+	}
+
+	dest := bestDest.Point()
+	update.Status = StatusOptimized
+	update.NextStop = bestDest.Name
+	update.Destination = &dest
+	update.DistanceMeters = best.DistanceMeters
+	update.DurationSeconds = best.DurationSeconds
+	update.ETASeconds = int64(math.Ceil(best.DurationSeconds))
+	update.Geometry = geo.EncodePolyline(geo.Simplify(best.Path, geometryToleranceMeters))
+	return update
+}
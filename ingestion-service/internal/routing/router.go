@@ -0,0 +1,332 @@
+// Package routing answers shortest-time queries on the road graph.
+package routing
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/roadgraph"
+)
+
+var (
+	// ErrNotOnRoad is returned when a point is farther than MaxSnapMeters
+	// from any road.
+	ErrNotOnRoad = errors.New("no road near point")
+	// ErrNoRoute is returned when the destination cannot be reached.
+	ErrNoRoute = errors.New("no route between points")
+)
+
+// MaxSnapMeters is how far from a road query points may be.
+const MaxSnapMeters = 1000
+
+// Route is a shortest-time path between two points.
+type Route struct {
+	DistanceMeters  float64
+	DurationSeconds float64
+	// Path runs from the origin's position on the road through every node
+	// to the destination's position on the road.
+	Path []geo.Point
+	// Edges are the graph edges driven, in order, and Fractions the share
+	// of each that is driven: the first and last are usually partial.
+	Edges     []int32
+	Fractions []float64
+}
+
+// Router finds routes on an immutable graph. It is safe for concurrent use.
+type Router struct {
+	g *roadgraph.Graph
+	// maxSpeed in m/s turns straight-line distance into a lower bound on
+	// travel time.
+	maxSpeed float64
+	pool     sync.Pool
+}
+
+func NewRouter(g *roadgraph.Graph) *Router {
+	r := &Router{g: g, maxSpeed: g.MaxSpeedKPH() / 3.6}
+	r.pool.New = func() interface{} { return newSearch(len(g.Nodes)) }
+	return r
+}
+
+func (r *Router) Graph() *roadgraph.Graph { return r.g }
+
+// Seed is a point's position on a directed edge. Searches start from or end
+// at seeds instead of nodes, so routes begin and end mid-edge.
+type Seed struct {
+	roadgraph.Candidate
+}
+
+// Snap returns the positions on the nearest road segment, one per driving
+// direction.
+func (r *Router) Snap(p geo.Point) ([]Seed, error) {
+	for radius := 50.0; radius <= MaxSnapMeters; radius *= 2 {
+		cands := r.g.Nearby(p, radius)
+		if len(cands) == 0 {
+			continue
+		}
+		var seeds []Seed
+		for _, c := range cands {
+			// Both directions of the closest segment, and any road
+			// equally close, such as at an intersection.
+			if c.Distance <= cands[0].Distance+1 {
+				seeds = append(seeds, Seed{c})
+			}
+		}
+		return seeds, nil
+	}
+	return nil, ErrNotOnRoad
+}
+
+// search holds the per-query state of one search direction, reused across
+// queries through the router's pool.
+type search struct {
+	dist []float64
+	// via is the edge used to reach each node, or seedVia(i) for nodes
+	// reached directly from seed i.
+	via     []int32
+	settled []bool
+	touched []int32
+	queue   queue
+}
+
+func newSearch(n int) *search {
+	s := &search{
+		dist:    make([]float64, n),
+		via:     make([]int32, n),
+		settled: make([]bool, n),
+	}
+	for i := range s.dist {
+		s.dist[i] = math.Inf(1)
+	}
+	return s
+}
+
+func (s *search) reset() {
+	for _, n := range s.touched {
+		s.dist[n] = math.Inf(1)
+		s.settled[n] = false
+	}
+	s.touched = s.touched[:0]
+	s.queue = s.queue[:0]
+}
+
+// relax records a tentative distance and queues the node with the given
+// priority.
+func (s *search) relax(n int32, d float64, via int32, key float64) bool {
+	if d >= s.dist[n] {
+		return false
+	}
+	if math.IsInf(s.dist[n], 1) {
+		s.touched = append(s.touched, n)
+	}
+	s.dist[n], s.via[n] = d, via
+	heap.Push(&s.queue, item{node: n, key: key})
+	return true
+}
+
+func seedVia(i int) int32     { return int32(-2 - i) }
+func seedIndex(via int32) int { return int(-2 - via) }
+
+type item struct {
+	node int32
+	key  float64
+}
+
+type queue []item
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].key < q[j].key }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(item)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
+
+func (q queue) top() float64 {
+	if len(q) == 0 {
+		return math.Inf(1)
+	}
+	return q[0].key
+}
+
+// Route returns the fastest route from one point to another.
+func (r *Router) Route(from, to geo.Point) (*Route, error) {
+	src, err := r.Snap(from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := r.Snap(to)
+	if err != nil {
+		return nil, err
+	}
+	return r.RouteSeeds(src, dst)
+}
+
+// RouteSeeds runs a bidirectional A* search between snapped points. Both
+// directions use the average of the forward and backward straight-line
+// potentials, which keeps the two searches consistent so the classic
+// bidirectional stopping rule stays exact.
+func (r *Router) RouteSeeds(src, dst []Seed) (*Route, error) {
+	g := r.g
+	s, t := src[0].Point, dst[0].Point
+	potential := func(n int32) float64 {
+		p := g.Nodes[n].Point
+		return (geo.Distance(p, t) - geo.Distance(s, p)) / (2 * r.maxSpeed)
+	}
+
+	fwd := r.pool.Get().(*search)
+	bwd := r.pool.Get().(*search)
+	defer func() {
+		fwd.reset()
+		bwd.reset()
+		r.pool.Put(fwd)
+		r.pool.Put(bwd)
+	}()
+
+	// best is the shortest complete route seen so far, either through a
+	// meeting node or along a single edge.
+	best := math.Inf(1)
+	meet := int32(-1)
+	var direct *Seed
+	var directEnd Seed
+
+	for _, a := range src {
+		for _, b := range dst {
+			if a.Edge == b.Edge && b.Fraction >= a.Fraction {
+				if d := (b.Fraction - a.Fraction) * g.Edges[a.Edge].TravelSeconds(); d < best {
+					best, direct, directEnd = d, &Seed{a.Candidate}, b
+				}
+			}
+		}
+	}
+	for i, a := range src {
+		e := &g.Edges[a.Edge]
+		d := (1 - a.Fraction) * e.TravelSeconds()
+		fwd.relax(e.To, d, seedVia(i), d+potential(e.To))
+	}
+	for i, b := range dst {
+		e := &g.Edges[b.Edge]
+		d := b.Fraction * e.TravelSeconds()
+		bwd.relax(e.From, d, seedVia(i), d-potential(e.From))
+	}
+	// Seeds on both sides of the same node already form a route.
+	for _, n := range fwd.touched {
+		if d := fwd.dist[n] + bwd.dist[n]; d < best {
+			best, meet, direct = d, n, nil
+		}
+	}
+
+	for fwd.queue.Len() > 0 || bwd.queue.Len() > 0 {
+		// A route through node v has duration dist_f(v)+dist_b(v), which
+		// equals key_f(v)+key_b(v) because the potentials cancel, so no
+		// unseen route can beat best once the queue tops add up to it.
+		if fwd.queue.top()+bwd.queue.top() >= best {
+			break
+		}
+		forward := fwd.queue.top() <= bwd.queue.top()
+		cur, other := fwd, bwd
+		if !forward {
+			cur, other = bwd, fwd
+		}
+		it := heap.Pop(&cur.queue).(item)
+		n := it.node
+		if cur.settled[n] {
+			continue
+		}
+		cur.settled[n] = true
+
+		var edges []int32
+		if forward {
+			from, to := g.Out(n)
+			for e := from; e < to; e++ {
+				edges = append(edges, e)
+			}
+		} else {
+			edges = g.In(n)
+		}
+		for _, ei := range edges {
+			e := &g.Edges[ei]
+			next := e.To
+			key := 0.0
+			d := cur.dist[n] + e.TravelSeconds()
+			if forward {
+				key = d + potential(next)
+			} else {
+				next = e.From
+				key = d - potential(next)
+			}
+			if cur.relax(next, d, ei, key) {
+				if total := d + other.dist[next]; total < best {
+					best, meet, direct = total, next, nil
+				}
+			}
+		}
+	}
+
+	if direct != nil {
+		a, b := direct, directEnd
+		e := &g.Edges[a.Edge]
+		return &Route{
+			DistanceMeters:  (b.Fraction - a.Fraction) * e.Length,
+			DurationSeconds: best,
+			Path:            []geo.Point{a.Point, b.Point},
+			Edges:           []int32{a.Edge},
+			Fractions:       []float64{b.Fraction - a.Fraction},
+		}, nil
+	}
+	if meet < 0 {
+		return nil, ErrNoRoute
+	}
+	return r.assemble(src, dst, fwd, bwd, meet, best), nil
+}
+
+// assemble walks the search trees from the meeting node back to the seeds.
+func (r *Router) assemble(src, dst []Seed, fwd, bwd *search, meet int32, duration float64) *Route {
+	g := r.g
+	var head []int32
+	n := meet
+	for fwd.via[n] >= 0 {
+		head = append(head, fwd.via[n])
+		n = g.Edges[fwd.via[n]].From
+	}
+	first := src[seedIndex(fwd.via[n])]
+	var tail []int32
+	n = meet
+	for bwd.via[n] >= 0 {
+		tail = append(tail, bwd.via[n])
+		n = g.Edges[bwd.via[n]].To
+	}
+	last := dst[seedIndex(bwd.via[n])]
+
+	route := &Route{DurationSeconds: duration}
+	route.Edges = append(route.Edges, first.Edge)
+	for i := len(head) - 1; i >= 0; i-- {
+		route.Edges = append(route.Edges, head[i])
+	}
+	route.Edges = append(route.Edges, tail...)
+	route.Edges = append(route.Edges, last.Edge)
+	route.Fractions = make([]float64, len(route.Edges))
+	for i := range route.Fractions {
+		route.Fractions[i] = 1
+	}
+	route.Fractions[0] = 1 - first.Fraction
+	route.Fractions[len(route.Fractions)-1] = last.Fraction
+
+	route.Path = append(route.Path, first.Point)
+	fe := &g.Edges[first.Edge]
+	route.DistanceMeters += (1 - first.Fraction) * fe.Length
+	route.Path = append(route.Path, g.Nodes[fe.To].Point)
+	for _, ei := range route.Edges[1 : len(route.Edges)-1] {
+		e := &g.Edges[ei]
+		route.DistanceMeters += e.Length
+		route.Path = append(route.Path, g.Nodes[e.To].Point)
+	}
+	route.DistanceMeters += last.Fraction * g.Edges[last.Edge].Length
+	route.Path = append(route.Path, last.Point)
+	return route
+}
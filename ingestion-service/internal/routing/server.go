@@ -0,0 +1,174 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+)
+
+const (
+	// MaxMatrixCells bounds ComputeMatrix; larger matrices must be streamed.
+	MaxMatrixCells = 10_000
+	// MaxStreamCells bounds StreamMatrix.
+	MaxStreamCells = 1_000_000
+
+	maxBands       = 10
+	maxBandSeconds = 3 * 60 * 60
+	// defaultResolution is the isochrone grid cell size, coarsened so an
+	// outline spans at most maxGridCells cells across.
+	defaultResolution = 100.0
+	maxGridCells      = 400
+)
+
+// MatrixServer implements the MatrixService gRPC API. Cells the road graph
+// cannot answer are estimated with the request's speed profile.
+type MatrixServer struct {
+	pb.UnimplementedMatrixServiceServer
+	router *Router
+}
+
+func NewMatrixServer(router *Router) *MatrixServer {
+	return &MatrixServer{router: router}
+}
+
+func (s *MatrixServer) ComputeMatrix(ctx context.Context, req *pb.MatrixRequest) (*pb.MatrixResponse, error) {
+	sources, targets, profile, err := matrixRequest(req, MaxMatrixCells)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.MatrixResponse{Rows: make([]*pb.MatrixRow, len(sources))}
+	err = s.rows(ctx, sources, targets, profile, func(row *pb.MatrixRow) error {
+		resp.Rows[row.SourceIndex] = row
+		return nil
+	})
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return resp, nil
+}
+
+func (s *MatrixServer) StreamMatrix(req *pb.MatrixRequest, stream pb.MatrixService_StreamMatrixServer) error {
+	sources, targets, profile, err := matrixRequest(req, MaxStreamCells)
+	if err != nil {
+		return err
+	}
+	err = s.rows(stream.Context(), sources, targets, profile, stream.Send)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+func (s *MatrixServer) rows(ctx context.Context, sources, targets []geo.Point, profile Profile, fn func(*pb.MatrixRow) error) error {
+	return s.router.MatrixRows(ctx, sources, targets, func(i int, durations, distances []float64) error {
+		row := &pb.MatrixRow{
+			SourceIndex:      int32(i),
+			DurationsSeconds: durations,
+			DistancesMeters:  distances,
+			Estimated:        make([]bool, len(targets)),
+		}
+		for j := range targets {
+			if math.IsInf(durations[j], 1) {
+				durations[j], distances[j] = profile.Estimate(sources[i], targets[j])
+				row.Estimated[j] = true
+			}
+		}
+		return fn(row)
+	})
+}
+
+// IsochroneServer implements the IsochroneService gRPC API.
+type IsochroneServer struct {
+	pb.UnimplementedIsochroneServiceServer
+	router *Router
+}
+
+func NewIsochroneServer(router *Router) *IsochroneServer {
+	return &IsochroneServer{router: router}
+}
+
+func (s *IsochroneServer) ComputeIsochrones(ctx context.Context, req *pb.IsochroneRequest) (*pb.IsochroneResponse, error) {
+	origin := geo.Point{Lat: req.GetOrigin().GetLatitude(), Lon: req.GetOrigin().GetLongitude()}
+	if req.GetOrigin() == nil || !origin.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "a valid origin is required")
+	}
+	if n := len(req.GetBandSeconds()); n == 0 || n > maxBands {
+		return nil, status.Errorf(codes.InvalidArgument, "between 1 and %d bands are required", maxBands)
+	}
+	bands := make([]float64, len(req.GetBandSeconds()))
+	for i, b := range req.GetBandSeconds() {
+		if b <= 0 || b > maxBandSeconds {
+			return nil, status.Errorf(codes.InvalidArgument, "bands must be between 1 and %d seconds", maxBandSeconds)
+		}
+		bands[i] = float64(b)
+	}
+
+	cell := req.GetResolutionMeters()
+	if cell <= 0 {
+		cell = defaultResolution
+	}
+	// The farthest the largest band can reach at top speed.
+	reach := 0.0
+	for _, b := range bands {
+		reach = math.Max(reach, b*s.router.g.MaxSpeedKPH()/3.6)
+	}
+	cell = math.Max(cell, 2*reach/maxGridCells)
+
+	isochrones, err := s.router.Isochrones(origin, bands, cell)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	data, err := IsochroneGeoJSON(isochrones)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.IsochroneResponse{FeatureCollection: data}, nil
+}
+
+func matrixRequest(req *pb.MatrixRequest, maxCells int) ([]geo.Point, []geo.Point, Profile, error) {
+	name := req.GetProfile()
+	if name == "" {
+		name = DefaultProfile
+	}
+	profile, ok := Profiles[name]
+	if !ok {
+		return nil, nil, Profile{}, status.Errorf(codes.InvalidArgument, "unknown profile %q", name)
+	}
+	sources, err := points(req.GetSources())
+	if err != nil {
+		return nil, nil, Profile{}, status.Errorf(codes.InvalidArgument, "sources: %v", err)
+	}
+	targets := sources
+	if len(req.GetTargets()) > 0 {
+		if targets, err = points(req.GetTargets()); err != nil {
+			return nil, nil, Profile{}, status.Errorf(codes.InvalidArgument, "targets: %v", err)
+		}
+	}
+	if len(sources) == 0 {
+		return nil, nil, Profile{}, status.Error(codes.InvalidArgument, "sources are required")
+	}
+	if cells := len(sources) * len(targets); cells > maxCells {
+		return nil, nil, Profile{}, status.Errorf(codes.InvalidArgument, "%d cells exceeds the limit of %d", cells, maxCells)
+	}
+	return sources, targets, profile, nil
+}
+
+func points(lls []*pb.LatLng) ([]geo.Point, error) {
+	pts := make([]geo.Point, len(lls))
+	for i, ll := range lls {
+		pts[i] = geo.Point{Lat: ll.GetLatitude(), Lon: ll.GetLongitude()}
+		if !pts[i].Valid() {
+			return nil, fmt.Errorf("point %d is not a valid coordinate", i)
+		}
+	}
+	return pts, nil
+}
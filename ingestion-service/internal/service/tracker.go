@@ -2,13 +2,20 @@ package service
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/nexus-logistics/ingestion-service/internal/anomaly"
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
 	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/tenant"
+	"github.com/nexus-logistics/ingestion-service/internal/topics"
 	pb "github.com/nexus-logistics/ingestion-service/pb"
 )
 
@@ -21,54 +28,207 @@ var (
 		Name: "ingestion_pings_produced_total",
 		Help: "The total number of pings successfully produced to Kafka",
 	})
+	tenantPings = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ingestion_tenant_pings_total",
+		Help: "Pings per tenant by outcome (produced, quarantined, failed, quota_exceeded, exceeds_burst, vehicle_denied)",
+	}, []string{"tenant", "result"})
+	anomalousPings = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ingestion_anomalous_pings_total",
+		Help: "Suspicious pings by reason and the action taken (quarantine or flag)",
+	}, []string{"reason", "action"})
+)
+
+const (
+	// tenantHeader is the Kafka header carrying the tenant ID.
+	tenantHeader = "tenant-id"
+	// anomalyHeader carries the reason a ping was quarantined or flagged.
+	anomalyHeader = "anomaly-reason"
 )
 
 type TrackerService struct {
 	pb.UnimplementedTrackerServiceServer
 	producer *kafka.Producer
+	router   *topics.Router
+	tenants  *tenant.Registry
+	// anomalies is nil when anomaly detection is off.
+	anomalies *anomaly.Detector
+	// pingLog is a sampled logger for per-ping records on the hot path.
+	pingLog *slog.Logger
 }
 
-func NewTrackerService(producer *kafka.Producer) *TrackerService {
+func NewTrackerService(producer *kafka.Producer, router *topics.Router, tenants *tenant.Registry, anomalies *anomaly.Detector, pingLog *slog.Logger) *TrackerService {
 	return &TrackerService{
-		producer: producer,
+		producer:  producer,
+		router:    router,
+		tenants:   tenants,
+		anomalies: anomalies,
+		pingLog:   pingLog,
 	}
 }
 
 type PingPayload struct {
-	VehicleID string  `json:"vehicle_id"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Timestamp int64   `json:"timestamp"`
+	Tenant     string            `json:"tenant,omitempty"`
+	VehicleID  string            `json:"vehicle_id"`
+	Latitude   float64           `json:"latitude"`
+	Longitude  float64           `json:"longitude"`
+	Timestamp  int64             `json:"timestamp"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Anomaly is set on suspicious pings; see internal/anomaly.
+	Anomaly string `json:"anomaly,omitempty"`
 }
 
-func (s *TrackerService) SendPing(ctx context.Context, req *pb.LocationPing) (*pb.PingResponse, error) {
-	// log.Printf("Received ping from vehicle: %s", req.VehicleId)
-	pingsReceived.Inc()
+// maxBatchSize bounds SendPingBatch so one request cannot hold a
+// transaction open for an unbounded number of records.
+const maxBatchSize = 1000
 
+func newPayload(tenantID string, req *pb.LocationPing) PingPayload {
 	payload := PingPayload{
-		VehicleID: req.VehicleId,
-		Latitude:  req.Latitude,
-		Longitude: req.Longitude,
-		Timestamp: req.Timestamp,
+		Tenant:     tenantID,
+		VehicleID:  req.VehicleId,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Timestamp:  req.Timestamp,
+		Attributes: req.Attributes,
+	}
+
+	// Use current time if timestamp is 0 or missing, though proto default is 0.
+	if payload.Timestamp == 0 {
+		payload.Timestamp = time.Now().Unix()
+	}
+	return payload
+}
+
+// messages builds one Kafka message per topic the routing rules select.
+// When anomaly detection is configured, suspicious pings go to the
+// quarantine topic instead, or are routed as usual with the reason attached
+// when the detector only flags them. It reports whether the ping was
+// quarantined.
+func (s *TrackerService) messages(ctx context.Context, tenantID string, req *pb.LocationPing) ([]kafka.Message, bool) {
+	payload := newPayload(tenantID, req)
+	headers := map[string]string{tenantHeader: tenantID}
+
+	reason := anomaly.ReasonNone
+	if s.anomalies != nil {
+		reason = s.anomalies.Check(tenantID+"/"+req.VehicleId, req.VehicleId,
+			geo.Point{Lat: req.Latitude, Lon: req.Longitude}, payload.Timestamp, req.Attributes)
+	}
+	if reason != anomaly.ReasonNone {
+		action := s.anomalies.Action()
+		anomalousPings.WithLabelValues(string(reason), string(action)).Inc()
+		s.pingLog.WarnContext(ctx, "Suspicious ping", "vehicle_id", req.VehicleId, "reason", reason, "action", action,
+			"latitude", req.Latitude, "longitude", req.Longitude)
+		payload.Anomaly = string(reason)
+		headers[anomalyHeader] = string(reason)
+		if action == anomaly.ActionQuarantine {
+			return []kafka.Message{{Topic: s.anomalies.QuarantineTopic(), Key: req.VehicleId, Value: payload, Headers: headers}}, true
+		}
 	}
 
-    // Use current time if timestamp is 0 or missing, though proto default is 0.
-    if payload.Timestamp == 0 {
-        payload.Timestamp = time.Now().Unix()
-    }
+	dests := s.router.Route(topics.Input{
+		Tenant:     tenantID,
+		VehicleID:  req.VehicleId,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Attributes: req.Attributes,
+	})
+	msgs := make([]kafka.Message, len(dests))
+	for i, d := range dests {
+		msgs[i] = kafka.Message{Topic: d.Topic, Key: req.VehicleId, Value: payload, Headers: headers}
+	}
+	return msgs, false
+}
+
+// admit applies the tenant's vehicle list and quota, returning a gRPC
+// status error when the pings must be rejected.
+func (s *TrackerService) admit(ctx context.Context, t string, pings []*pb.LocationPing) error {
+	for _, ping := range pings {
+		if err := s.tenants.CheckVehicle(t, ping.VehicleId); err != nil {
+			tenantPings.WithLabelValues(s.tenants.Label(t), "vehicle_denied").Add(float64(len(pings)))
+			slog.WarnContext(ctx, "Rejected ping for unregistered vehicle", "vehicle_id", ping.VehicleId)
+			return status.Errorf(codes.PermissionDenied, "vehicle %q is not registered for this tenant", ping.VehicleId)
+		}
+	}
+	if err := s.tenants.TakeQuota(t, len(pings)); errors.Is(err, tenant.ErrExceedsBurst) {
+		tenantPings.WithLabelValues(s.tenants.Label(t), "exceeds_burst").Add(float64(len(pings)))
+		return status.Errorf(codes.InvalidArgument, "batch of %d pings exceeds the tenant's quota burst", len(pings))
+	} else if err != nil {
+		tenantPings.WithLabelValues(s.tenants.Label(t), "quota_exceeded").Add(float64(len(pings)))
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+func (s *TrackerService) SendPing(ctx context.Context, req *pb.LocationPing) (*pb.PingResponse, error) {
+	s.pingLog.DebugContext(ctx, "Received ping", "latitude", req.Latitude, "longitude", req.Longitude, "timestamp", req.Timestamp)
+	pingsReceived.Inc()
 
-	err := s.producer.Produce(req.VehicleId, payload)
+	t := tenant.FromContext(ctx)
+	if err := s.admit(ctx, t, []*pb.LocationPing{req}); err != nil {
+		return nil, err
+	}
+
+	msgs, quarantined := s.messages(ctx, t, req)
+	err := s.producer.ProduceBatch(ctx, msgs)
 	if err != nil {
-		log.Printf("Failed to publish to Kafka: %v", err)
+		tenantPings.WithLabelValues(s.tenants.Label(t), "failed").Inc()
+		slog.ErrorContext(ctx, "Failed to publish to Kafka", "error", err)
 		return &pb.PingResponse{
 			Success: false,
 			Message: "Failed to process ping",
 		}, nil
 	}
-	pingsProduced.Inc()
+	if quarantined {
+		tenantPings.WithLabelValues(s.tenants.Label(t), "quarantined").Inc()
+	} else {
+		pingsProduced.Inc()
+		tenantPings.WithLabelValues(s.tenants.Label(t), "produced").Inc()
+	}
 
 	return &pb.PingResponse{
 		Success: true,
 		Message: "Ping received",
 	}, nil
 }
+
+func (s *TrackerService) SendPingBatch(ctx context.Context, req *pb.LocationPingBatch) (*pb.PingResponse, error) {
+	if len(req.Pings) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch of %d pings exceeds the limit of %d", len(req.Pings), maxBatchSize)
+	}
+	s.pingLog.DebugContext(ctx, "Received ping batch", "size", len(req.Pings))
+	pingsReceived.Add(float64(len(req.Pings)))
+
+	t := tenant.FromContext(ctx)
+	if err := s.admit(ctx, t, req.Pings); err != nil {
+		return nil, err
+	}
+
+	var msgs []kafka.Message
+	quarantined := 0
+	for _, ping := range req.Pings {
+		m, q := s.messages(ctx, t, ping)
+		msgs = append(msgs, m...)
+		if q {
+			quarantined++
+		}
+	}
+
+	if err := s.producer.ProduceBatch(ctx, msgs); err != nil {
+		tenantPings.WithLabelValues(s.tenants.Label(t), "failed").Add(float64(len(req.Pings)))
+		slog.ErrorContext(ctx, "Failed to publish batch to Kafka", "error", err, "size", len(req.Pings))
+		return &pb.PingResponse{
+			Success: false,
+			Message: "Failed to process ping batch",
+		}, nil
+	}
+	produced := len(req.Pings) - quarantined
+	pingsProduced.Add(float64(produced))
+	tenantPings.WithLabelValues(s.tenants.Label(t), "produced").Add(float64(produced))
+	if quarantined > 0 {
+		tenantPings.WithLabelValues(s.tenants.Label(t), "quarantined").Add(float64(quarantined))
+	}
+
+	return &pb.PingResponse{
+		Success: true,
+		Message: "Ping batch received",
+	}, nil
+}
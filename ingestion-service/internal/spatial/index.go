@@ -0,0 +1,322 @@
+// Package spatial indexes the latest position of every vehicle for
+// nearest-neighbour, radius and bounding-box queries.
+package spatial
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+// cellDegrees is the grid cell size, about 1.1 km north-south. Dense
+// fleets keep few vehicles per cell and sparse ones few empty cells within
+// a typical dispatch radius.
+const cellDegrees = 0.01
+
+// maxRings bounds the ring expansion of a nearest query before it falls
+// back to scanning every vehicle, which is cheaper for sparse fleets.
+const maxRings = 64
+
+// Vehicle is an indexed vehicle position.
+type Vehicle struct {
+	service.PingPayload
+	Point geo.Point
+}
+
+// Filter restricts query results. The zero value matches every vehicle.
+type Filter struct {
+	Tenant string
+	// Attributes must all be present with equal values.
+	Attributes map[string]string
+	// MinTimestamp excludes vehicles whose last ping is older (Unix
+	// seconds).
+	MinTimestamp int64
+}
+
+func (f *Filter) match(v *Vehicle) bool {
+	if f.Tenant != "" && v.Tenant != f.Tenant {
+		return false
+	}
+	if v.Timestamp < f.MinTimestamp {
+		return false
+	}
+	for k, want := range f.Attributes {
+		if got, ok := v.Attributes[k]; !ok || got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// Result is a vehicle matched by a query with its distance from the query
+// point, or 0 for bounding-box queries.
+type Result struct {
+	*Vehicle
+	Distance float64
+}
+
+type cell struct{ x, y int32 }
+
+func cellOf(p geo.Point) cell {
+	return cell{int32(math.Floor(p.Lon / cellDegrees)), int32(math.Floor(p.Lat / cellDegrees))}
+}
+
+// Index is a uniform grid over latitude and longitude holding each
+// vehicle's latest position. Updates only move a vehicle forward in time.
+// It is safe for concurrent use.
+type Index struct {
+	mu       sync.RWMutex
+	vehicles map[string]*Vehicle
+	cells    map[cell]map[string]*Vehicle
+}
+
+func NewIndex() *Index {
+	return &Index{
+		vehicles: make(map[string]*Vehicle),
+		cells:    make(map[cell]map[string]*Vehicle),
+	}
+}
+
+// Update indexes a ping unless the vehicle already has one at the same or
+// a later time, and reports whether it was applied.
+func (ix *Index) Update(p service.PingPayload) bool {
+	v := &Vehicle{PingPayload: p, Point: geo.Point{Lat: p.Latitude, Lon: p.Longitude}}
+	if !v.Point.Valid() {
+		return false
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if cur, ok := ix.vehicles[p.VehicleID]; ok {
+		if cur.Timestamp >= p.Timestamp {
+			return false
+		}
+		ix.unlink(cur)
+	}
+	ix.vehicles[p.VehicleID] = v
+	c := cellOf(v.Point)
+	members := ix.cells[c]
+	if members == nil {
+		members = make(map[string]*Vehicle)
+		ix.cells[c] = members
+	}
+	members[p.VehicleID] = v
+	return true
+}
+
+func (ix *Index) unlink(v *Vehicle) {
+	c := cellOf(v.Point)
+	delete(ix.cells[c], v.VehicleID)
+	if len(ix.cells[c]) == 0 {
+		delete(ix.cells, c)
+	}
+}
+
+// Get returns a vehicle's indexed position.
+func (ix *Index) Get(vehicleID string) (Vehicle, bool) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	v, ok := ix.vehicles[vehicleID]
+	if !ok {
+		return Vehicle{}, false
+	}
+	return *v, true
+}
+
+// Evict removes vehicles whose last ping is older than before (Unix
+// seconds) and returns how many were removed.
+func (ix *Index) Evict(before int64) int {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	n := 0
+	for id, v := range ix.vehicles {
+		if v.Timestamp < before {
+			ix.unlink(v)
+			delete(ix.vehicles, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of indexed vehicles.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.vehicles)
+}
+
+// Nearest returns up to k matching vehicles closest to p, nearest first. It
+// scans rings of cells outward until no unscanned cell can hold a vehicle
+// closer than the k-th found.
+func (ix *Index) Nearest(p geo.Point, k int, f Filter) []Result {
+	if k <= 0 {
+		return nil
+	}
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	var found []Result
+	center := cellOf(p)
+	for r := int32(0); ; r++ {
+		if r > maxRings {
+			return ix.scan(p, k, f)
+		}
+		ix.ring(center, r, func(members map[string]*Vehicle) {
+			for _, v := range members {
+				if f.match(v) {
+					found = append(found, Result{Vehicle: v, Distance: geo.Distance(p, v.Point)})
+				}
+			}
+		})
+		if len(found) >= k && kth(found, k) <= ringClearance(p, center, r) {
+			break
+		}
+		if len(found) == len(ix.vehicles) {
+			break
+		}
+	}
+	sortResults(found)
+	if len(found) > k {
+		found = found[:k]
+	}
+	return found
+}
+
+// scan is the brute-force nearest search.
+func (ix *Index) scan(p geo.Point, k int, f Filter) []Result {
+	var found []Result
+	for _, v := range ix.vehicles {
+		if f.match(v) {
+			found = append(found, Result{Vehicle: v, Distance: geo.Distance(p, v.Point)})
+		}
+	}
+	sortResults(found)
+	if len(found) > k {
+		found = found[:k]
+	}
+	return found
+}
+
+// ring calls fn for every non-empty cell at Chebyshev distance r from c.
+func (ix *Index) ring(c cell, r int32, fn func(map[string]*Vehicle)) {
+	visit := func(x, y int32) {
+		if members, ok := ix.cells[cell{x, y}]; ok {
+			fn(members)
+		}
+	}
+	if r == 0 {
+		visit(c.x, c.y)
+		return
+	}
+	for x := c.x - r; x <= c.x+r; x++ {
+		visit(x, c.y-r)
+		visit(x, c.y+r)
+	}
+	for y := c.y - r + 1; y <= c.y+r-1; y++ {
+		visit(c.x-r, y)
+		visit(c.x+r, y)
+	}
+}
+
+// ringClearance is a lower bound on the distance from p to any cell beyond
+// ring r around c.
+func ringClearance(p geo.Point, c cell, r int32) float64 {
+	minLat := float64(c.y-r) * cellDegrees
+	maxLat := float64(c.y+r+1) * cellDegrees
+	minLon := float64(c.x-r) * cellDegrees
+	maxLon := float64(c.x+r+1) * cellDegrees
+	d := math.Min(
+		geo.Distance(p, geo.Point{Lat: minLat, Lon: p.Lon}),
+		geo.Distance(p, geo.Point{Lat: maxLat, Lon: p.Lon}),
+	)
+	// Meridians converge, so measure the east-west gap at the latitude
+	// farthest from the equator within the searched square.
+	lat := math.Max(math.Abs(minLat), math.Abs(maxLat))
+	lat = math.Min(lat, 90)
+	d = math.Min(d, geo.Distance(geo.Point{Lat: lat, Lon: p.Lon}, geo.Point{Lat: lat, Lon: minLon}))
+	d = math.Min(d, geo.Distance(geo.Point{Lat: lat, Lon: p.Lon}, geo.Point{Lat: lat, Lon: maxLon}))
+	return d
+}
+
+// kth returns the k-th smallest distance in found.
+func kth(found []Result, k int) float64 {
+	ds := make([]float64, len(found))
+	for i, r := range found {
+		ds[i] = r.Distance
+	}
+	sort.Float64s(ds)
+	return ds[k-1]
+}
+
+// Within returns up to limit matching vehicles within radius meters of p,
+// nearest first.
+func (ix *Index) Within(p geo.Point, radius float64, limit int, f Filter) []Result {
+	box := geo.Around(p, radius)
+	var found []Result
+	ix.mu.RLock()
+	ix.box(box, func(v *Vehicle) {
+		if d := geo.Distance(p, v.Point); d <= radius && f.match(v) {
+			found = append(found, Result{Vehicle: v, Distance: d})
+		}
+	})
+	ix.mu.RUnlock()
+	sortResults(found)
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+// InBox returns up to limit matching vehicles inside box, ordered by
+// vehicle ID so results are stable.
+func (ix *Index) InBox(box geo.BBox, limit int, f Filter) []Result {
+	var found []Result
+	ix.mu.RLock()
+	ix.box(box, func(v *Vehicle) {
+		if box.Contains(v.Point) && f.match(v) {
+			found = append(found, Result{Vehicle: v})
+		}
+	})
+	ix.mu.RUnlock()
+	sort.Slice(found, func(i, j int) bool { return found[i].VehicleID < found[j].VehicleID })
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+// box calls fn for every vehicle in the cells overlapping b. Boxes larger
+// than the populated area are walked by cell list instead of by grid.
+func (ix *Index) box(b geo.BBox, fn func(*Vehicle)) {
+	lo, hi := cellOf(geo.Point{Lat: b.MinLat, Lon: b.MinLon}), cellOf(geo.Point{Lat: b.MaxLat, Lon: b.MaxLon})
+	span := int64(hi.x-lo.x+1) * int64(hi.y-lo.y+1)
+	if span > int64(len(ix.cells)) {
+		for c, members := range ix.cells {
+			if c.x >= lo.x && c.x <= hi.x && c.y >= lo.y && c.y <= hi.y {
+				for _, v := range members {
+					fn(v)
+				}
+			}
+		}
+		return
+	}
+	for x := lo.x; x <= hi.x; x++ {
+		for y := lo.y; y <= hi.y; y++ {
+			for _, v := range ix.cells[cell{x, y}] {
+				fn(v)
+			}
+		}
+	}
+}
+
+func sortResults(rs []Result) {
+	sort.Slice(rs, func(i, j int) bool {
+		if rs[i].Distance != rs[j].Distance {
+			return rs[i].Distance < rs[j].Distance
+		}
+		return rs[i].VehicleID < rs[j].VehicleID
+	})
+}
@@ -0,0 +1,118 @@
+package spatial
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+)
+
+const (
+	maxK            = 100
+	maxRadiusMeters = 100_000
+	defaultLimit    = 100
+	maxLimit        = 1000
+)
+
+// Server implements the SpatialIndexService gRPC API.
+type Server struct {
+	pb.UnimplementedSpatialIndexServiceServer
+	index *Index
+	now   func() time.Time
+}
+
+func NewServer(index *Index) *Server {
+	return &Server{index: index, now: time.Now}
+}
+
+func (s *Server) FindNearest(ctx context.Context, req *pb.FindNearestRequest) (*pb.FindVehiclesResponse, error) {
+	p, err := point(req.GetPoint())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetK() <= 0 || req.GetK() > maxK {
+		return nil, status.Errorf(codes.InvalidArgument, "k must be between 1 and %d", maxK)
+	}
+	return response(s.index.Nearest(p, int(req.GetK()), s.filter(req.GetFilter()))), nil
+}
+
+func (s *Server) FindWithinRadius(ctx context.Context, req *pb.FindWithinRadiusRequest) (*pb.FindVehiclesResponse, error) {
+	p, err := point(req.GetPoint())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetRadiusMeters() <= 0 || req.GetRadiusMeters() > maxRadiusMeters {
+		return nil, status.Errorf(codes.InvalidArgument, "radius_meters must be positive and at most %d", maxRadiusMeters)
+	}
+	limit, err := resultLimit(req.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	return response(s.index.Within(p, req.GetRadiusMeters(), limit, s.filter(req.GetFilter()))), nil
+}
+
+func (s *Server) FindInBoundingBox(ctx context.Context, req *pb.FindInBoundingBoxRequest) (*pb.FindVehiclesResponse, error) {
+	b := req.GetBounds()
+	lo, err := point(b.GetMin())
+	if err != nil {
+		return nil, err
+	}
+	hi, err := point(b.GetMax())
+	if err != nil {
+		return nil, err
+	}
+	if lo.Lat > hi.Lat || lo.Lon > hi.Lon {
+		return nil, status.Error(codes.InvalidArgument, "bounds min must be south-west of max")
+	}
+	limit, err := resultLimit(req.GetLimit())
+	if err != nil {
+		return nil, err
+	}
+	box := geo.BBox{MinLat: lo.Lat, MinLon: lo.Lon, MaxLat: hi.Lat, MaxLon: hi.Lon}
+	return response(s.index.InBox(box, limit, s.filter(req.GetFilter()))), nil
+}
+
+func (s *Server) filter(f *pb.VehicleFilter) Filter {
+	out := Filter{Tenant: f.GetTenant(), Attributes: f.GetAttributes()}
+	if age := f.GetMaxAgeSeconds(); age > 0 {
+		out.MinTimestamp = s.now().Unix() - age
+	}
+	return out
+}
+
+func point(ll *pb.LatLng) (geo.Point, error) {
+	p := geo.Point{Lat: ll.GetLatitude(), Lon: ll.GetLongitude()}
+	if ll == nil || !p.Valid() {
+		return p, status.Error(codes.InvalidArgument, "a valid coordinate is required")
+	}
+	return p, nil
+}
+
+func resultLimit(limit int32) (int, error) {
+	if limit == 0 {
+		return defaultLimit, nil
+	}
+	if limit < 0 || limit > maxLimit {
+		return 0, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxLimit)
+	}
+	return int(limit), nil
+}
+
+func response(results []Result) *pb.FindVehiclesResponse {
+	resp := &pb.FindVehiclesResponse{Vehicles: make([]*pb.VehiclePosition, len(results))}
+	for i, r := range results {
+		resp.Vehicles[i] = &pb.VehiclePosition{
+			VehicleId:      r.VehicleID,
+			Tenant:         r.Tenant,
+			Position:       &pb.LatLng{Latitude: r.Point.Lat, Longitude: r.Point.Lon},
+			Timestamp:      r.Timestamp,
+			Attributes:     r.Attributes,
+			DistanceMeters: r.Distance,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,337 @@
+// Package stops turns a vehicle's position stream into stop episodes: runs
+// of consecutive slow pings that stay within a small radius for long enough.
+package stops
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+)
+
+type EventType string
+
+const (
+	EventStopStarted EventType = "stop_started"
+	EventStopEnded   EventType = "stop_ended"
+)
+
+// Event is published to the vehicle-stops topic. A stop_started event is
+// emitted once a stop has lasted MinDuration; the matching stop_ended event
+// carries the final centroid, radius and duration.
+type Event struct {
+	Type EventType `json:"type"`
+	// StopID is the same on the started and ended events of one stop.
+	StopID    string `json:"stop_id"`
+	VehicleID string `json:"vehicle_id"`
+	Tenant    string `json:"tenant,omitempty"`
+	// Latitude and Longitude are the centroid of the stop's pings.
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// RadiusMeters bounds the distance of every ping from the centroid.
+	RadiusMeters float64 `json:"radius_meters"`
+	// StartTime and EndTime are Unix timestamps of the first and last ping
+	// of the stop so far.
+	StartTime       int64 `json:"start_time"`
+	EndTime         int64 `json:"end_time"`
+	DurationSeconds int64 `json:"duration_seconds"`
+	Pings           int   `json:"pings"`
+}
+
+type Config struct {
+	// RadiusMeters is how far from the stop's centroid a ping may be and
+	// still belong to the stop.
+	RadiusMeters float64
+	// MinDuration is how long pings must stay within the radius before the
+	// episode counts as a stop.
+	MinDuration time.Duration
+	// MaxSpeedKPH is the highest average speed since the episode's first
+	// ping at which a ping can belong to a stop. Averaging over the episode
+	// rather than between consecutive pings keeps GPS noise from reading
+	// as movement.
+	MaxSpeedKPH float64
+	// JitterMeters is discounted from the distance moved before the speed
+	// check, to allow for the noise of the two fixes compared.
+	JitterMeters float64
+	// ReorderWindow is how long pings are buffered so that out-of-order
+	// pings can be put back in order. Pings arriving later than that are
+	// dropped.
+	ReorderWindow time.Duration
+}
+
+// DefaultConfig suits road vehicles reporting every few seconds.
+func DefaultConfig() Config {
+	return Config{
+		RadiusMeters:  75,
+		MinDuration:   2 * time.Minute,
+		MaxSpeedKPH:   5,
+		JitterMeters:  15,
+		ReorderWindow: 30 * time.Second,
+	}
+}
+
+// Detector tracks the current episode of every vehicle. It is safe for
+// concurrent use.
+type Detector struct {
+	cfg Config
+
+	mu       sync.Mutex
+	vehicles map[string]*vehicleState
+}
+
+type ping struct {
+	p   geo.Point
+	ts  int64
+	pos kafka.Position
+}
+
+type vehicleState struct {
+	tenant string
+	// pending pings, ordered by timestamp, not yet released by the
+	// reorder window.
+	pending []ping
+	maxTS   int64
+	arrived time.Time
+
+	// processed is the last ping run through stop detection.
+	processed *ping
+	episode   *episode
+}
+
+// episode is a run of pings within the radius of their centroid.
+type episode struct {
+	first      geo.Point
+	start, end int64
+	latSum     float64
+	lonSum     float64
+	n          int
+	bounds     geo.BBox
+	started    bool
+}
+
+func (e *episode) add(pg ping) {
+	if e.n == 0 {
+		e.first = pg.p
+		e.start = pg.ts
+		e.bounds = geo.EmptyBBox()
+	}
+	e.end = pg.ts
+	e.latSum += pg.p.Lat
+	e.lonSum += pg.p.Lon
+	e.n++
+	e.bounds.Extend(pg.p)
+}
+
+func (e *episode) centroid() geo.Point {
+	return geo.Point{Lat: e.latSum / float64(e.n), Lon: e.lonSum / float64(e.n)}
+}
+
+// radius is the distance from the centroid to the farthest corner of the
+// pings' bounding box, which bounds the distance to every ping.
+func (e *episode) radius() float64 {
+	c := e.centroid()
+	r := 0.0
+	for _, corner := range []geo.Point{
+		{Lat: e.bounds.MinLat, Lon: e.bounds.MinLon},
+		{Lat: e.bounds.MinLat, Lon: e.bounds.MaxLon},
+		{Lat: e.bounds.MaxLat, Lon: e.bounds.MinLon},
+		{Lat: e.bounds.MaxLat, Lon: e.bounds.MaxLon},
+	} {
+		r = math.Max(r, geo.Distance(c, corner))
+	}
+	return r
+}
+
+func (st *vehicleState) clone() *vehicleState {
+	c := &vehicleState{}
+	if st == nil {
+		return c
+	}
+	*c = *st
+	c.pending = append([]ping(nil), st.pending...)
+	if st.episode != nil {
+		ep := *st.episode
+		c.episode = &ep
+	}
+	return c
+}
+
+func NewDetector(cfg Config) *Detector {
+	return &Detector{cfg: cfg, vehicles: make(map[string]*vehicleState)}
+}
+
+// Transition is the effect of a ping, or of a flush, on the detector: the
+// events it causes, the pings it releases from the reorder window and the
+// state that follows.
+type Transition struct {
+	Events []Event
+	// Released holds the record positions of the pings run through stop
+	// detection. Their offsets can be committed once Events are published.
+	Released []kafka.Position
+
+	changes []change
+}
+
+type change struct {
+	vehicleID  string
+	base, next *vehicleState
+}
+
+// Add computes the effect of buffering a ping, read from the record at
+// pos, without changing any state, so that a failed publish can be retried
+// against the same state. Call Apply once the events are published, and
+// before the next Add or Flush. It reports false for pings that arrived too
+// late to be put in order.
+func (d *Detector) Add(vehicleID, tenant string, p geo.Point, ts int64, pos kafka.Position, now time.Time) (*Transition, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	base := d.vehicles[vehicleID]
+	if base != nil && base.processed != nil && ts < base.processed.ts {
+		return nil, false
+	}
+	st := base.clone()
+	st.tenant = tenant
+	st.arrived = now
+
+	i := sort.Search(len(st.pending), func(i int) bool { return st.pending[i].ts > ts })
+	st.pending = append(st.pending, ping{})
+	copy(st.pending[i+1:], st.pending[i:])
+	st.pending[i] = ping{p: p, ts: ts, pos: pos}
+	if ts > st.maxTS {
+		st.maxTS = ts
+	}
+
+	t := &Transition{changes: []change{{vehicleID, base, st}}}
+	d.release(t, vehicleID, st, st.maxTS-int64(d.cfg.ReorderWindow/time.Second))
+	return t, true
+}
+
+// Flush computes the release of the buffered pings of vehicles that have
+// not sent anything for the reorder window, so a vehicle going silent does
+// not hold back its last pings indefinitely. Like Add, it changes no state
+// until the transition is applied.
+func (d *Detector) Flush(now time.Time) *Transition {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	t := &Transition{}
+	for id, base := range d.vehicles {
+		if len(base.pending) > 0 && now.Sub(base.arrived) >= d.cfg.ReorderWindow {
+			st := base.clone()
+			t.changes = append(t.changes, change{id, base, st})
+			d.release(t, id, st, math.MaxInt64)
+		}
+	}
+	return t
+}
+
+// Apply makes a transition's state current. Vehicles whose state changed or
+// was evicted since the transition was computed are left alone.
+func (d *Detector) Apply(t *Transition) {
+	if t == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, c := range t.changes {
+		if d.vehicles[c.vehicleID] != c.base {
+			continue
+		}
+		d.vehicles[c.vehicleID] = c.next
+	}
+}
+
+// release processes pending pings up to and including the watermark.
+func (d *Detector) release(t *Transition, vehicleID string, st *vehicleState, watermark int64) {
+	n := sort.Search(len(st.pending), func(i int) bool { return st.pending[i].ts > watermark })
+	for _, pg := range st.pending[:n] {
+		if ev := d.process(vehicleID, st, pg); ev != nil {
+			t.Events = append(t.Events, *ev)
+		}
+		t.Released = append(t.Released, pg.pos)
+	}
+	st.pending = append(st.pending[:0], st.pending[n:]...)
+}
+
+// process runs one in-order ping through stop detection.
+func (d *Detector) process(vehicleID string, st *vehicleState, pg ping) *Event {
+	st.processed = &pg
+
+	ep := st.episode
+	if ep != nil && d.belongs(ep, pg) {
+		ep.add(pg)
+		if !ep.started && time.Duration(ep.end-ep.start)*time.Second >= d.cfg.MinDuration {
+			ep.started = true
+			ev := d.event(EventStopStarted, vehicleID, st.tenant, ep)
+			return &ev
+		}
+		return nil
+	}
+
+	// The ping left the episode: close it and start a new one here.
+	st.episode = &episode{}
+	st.episode.add(pg)
+	if ep != nil && ep.started {
+		ev := d.event(EventStopEnded, vehicleID, st.tenant, ep)
+		return &ev
+	}
+	return nil
+}
+
+func (d *Detector) belongs(ep *episode, pg ping) bool {
+	if geo.Distance(ep.centroid(), pg.p) > d.cfg.RadiusMeters {
+		return false
+	}
+	if pg.ts > ep.start {
+		moved := math.Max(0, geo.Distance(ep.first, pg.p)-d.cfg.JitterMeters)
+		speed := moved / float64(pg.ts-ep.start)
+		if speed > d.cfg.MaxSpeedKPH/3.6 {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *Detector) event(typ EventType, vehicleID, tenant string, ep *episode) Event {
+	c := ep.centroid()
+	return Event{
+		Type:            typ,
+		StopID:          fmt.Sprintf("%s:%d", vehicleID, ep.start),
+		VehicleID:       vehicleID,
+		Tenant:          tenant,
+		Latitude:        c.Lat,
+		Longitude:       c.Lon,
+		RadiusMeters:    ep.radius(),
+		StartTime:       ep.start,
+		EndTime:         ep.end,
+		DurationSeconds: ep.end - ep.start,
+		Pings:           ep.n,
+	}
+}
+
+// Evict forgets vehicles whose last processed ping is older than before
+// (Unix seconds). An open stop of an evicted vehicle never gets its
+// stop_ended event.
+func (d *Detector) Evict(before int64) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for id, st := range d.vehicles {
+		if len(st.pending) == 0 && st.processed != nil && st.processed.ts < before {
+			delete(d.vehicles, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Vehicles returns the number of vehicles with tracked state.
+func (d *Detector) Vehicles() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.vehicles)
+}
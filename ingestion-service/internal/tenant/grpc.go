@@ -0,0 +1,46 @@
+package tenant
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+)
+
+const (
+	// Header carries the tenant ID from trusted callers.
+	Header = "x-tenant-id"
+	// apiKeyHeader carries the caller's API key as "Bearer <key>".
+	apiKeyHeader = "authorization"
+)
+
+// UnaryServerInterceptor identifies the calling tenant, stores it in the
+// request context and adds it to the request's log attributes.
+func UnaryServerInterceptor(r *Registry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		var apiKey, header string
+		if vals := md.Get(apiKeyHeader); len(vals) > 0 {
+			apiKey = strings.TrimSpace(strings.TrimPrefix(vals[0], "Bearer "))
+		}
+		if vals := md.Get(Header); len(vals) > 0 {
+			header = vals[0]
+		}
+
+		id, err := r.Resolve(apiKey, header)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		ctx = NewContext(ctx, id)
+		ctx = logging.WithAttrs(ctx, slog.String("tenant", id))
+		return handler(ctx, req)
+	}
+}
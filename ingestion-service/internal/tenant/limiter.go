@@ -0,0 +1,41 @@
+package tenant
+
+import (
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket refilled at rate tokens per second up to burst.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	b := float64(burst)
+	if b < rate {
+		b = rate
+	}
+	return &limiter{rate: rate, burst: b, tokens: b}
+}
+
+// allow takes n tokens if they are all available.
+func (l *limiter) allow(now time.Time, n int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	if l.tokens < float64(n) {
+		return false
+	}
+	l.tokens -= float64(n)
+	return true
+}
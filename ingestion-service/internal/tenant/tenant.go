@@ -0,0 +1,232 @@
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config is the JSON tenant configuration.
+//
+//	{
+//	  "default_tenant": "default",
+//	  "trust_tenant_header": false,
+//	  "max_metric_tenants": 50,
+//	  "tenants": [
+//	    {"id": "acme", "api_keys": ["..."],
+//	     "quota": {"pings_per_second": 500, "burst": 1000},
+//	     "allowed_vehicle_prefixes": ["acme-"]}
+//	  ]
+//	}
+type Config struct {
+	// DefaultTenant is assigned to callers that present no identity. When
+	// empty, such calls are rejected.
+	DefaultTenant string `json:"default_tenant"`
+	// TrustTenantHeader accepts the x-tenant-id metadata from callers that
+	// did not authenticate with an API key. Only enable it behind a trusted
+	// gateway.
+	TrustTenantHeader bool `json:"trust_tenant_header"`
+	// MaxMetricTenants caps how many distinct tenant label values metrics
+	// use; further tenants are reported as "other".
+	MaxMetricTenants int      `json:"max_metric_tenants"`
+	Tenants          []Tenant `json:"tenants"`
+}
+
+type Tenant struct {
+	ID      string   `json:"id"`
+	APIKeys []string `json:"api_keys"`
+	Quota   Quota    `json:"quota"`
+	// AllowedVehicles and AllowedVehiclePrefixes restrict which vehicle IDs
+	// the tenant may report. Both empty allows any vehicle.
+	AllowedVehicles        []string `json:"allowed_vehicles"`
+	AllowedVehiclePrefixes []string `json:"allowed_vehicle_prefixes"`
+}
+
+// Quota limits a tenant's ping rate. A zero PingsPerSecond means unlimited.
+type Quota struct {
+	PingsPerSecond float64 `json:"pings_per_second"`
+	Burst          int     `json:"burst"`
+}
+
+// OtherLabel is the metric label used for tenants beyond MaxMetricTenants.
+const OtherLabel = "other"
+
+const defaultMaxMetricTenants = 50
+
+var (
+	ErrUnknownTenant  = errors.New("unknown tenant")
+	ErrQuotaExceeded  = errors.New("tenant quota exceeded")
+	ErrExceedsBurst   = errors.New("batch exceeds the tenant's quota burst")
+	ErrVehicleDenied  = errors.New("vehicle is not registered for tenant")
+	ErrUnidentified   = errors.New("caller did not identify a tenant")
+	errDuplicateKey   = errors.New("api key is assigned to more than one tenant")
+	errDuplicateEntry = errors.New("tenant is defined more than once")
+)
+
+// Registry holds the configured tenants and their runtime quota state.
+type Registry struct {
+	cfg     Config
+	byID    map[string]*entry
+	byKey   map[string]*entry
+	labelMu sync.Mutex
+	labels  map[string]bool
+}
+
+type entry struct {
+	Tenant
+	vehicles map[string]bool
+	limiter  *limiter
+}
+
+// OpenRegistry accepts any tenant named by the header and assigns "default"
+// otherwise; it is used when no tenant configuration is provided.
+func OpenRegistry() *Registry {
+	r, _ := NewRegistry(Config{DefaultTenant: "default", TrustTenantHeader: true})
+	return r
+}
+
+func NewRegistry(cfg Config) (*Registry, error) {
+	if cfg.MaxMetricTenants <= 0 {
+		cfg.MaxMetricTenants = defaultMaxMetricTenants
+	}
+	r := &Registry{
+		cfg:    cfg,
+		byID:   make(map[string]*entry),
+		byKey:  make(map[string]*entry),
+		labels: make(map[string]bool),
+	}
+	for _, t := range cfg.Tenants {
+		if t.ID == "" {
+			return nil, errors.New("tenant without id")
+		}
+		if _, ok := r.byID[t.ID]; ok {
+			return nil, fmt.Errorf("%w: %s", errDuplicateEntry, t.ID)
+		}
+		e := &entry{Tenant: t, vehicles: make(map[string]bool)}
+		for _, v := range t.AllowedVehicles {
+			e.vehicles[v] = true
+		}
+		if t.Quota.PingsPerSecond > 0 {
+			e.limiter = newLimiter(t.Quota.PingsPerSecond, t.Quota.Burst)
+		}
+		for _, k := range t.APIKeys {
+			if _, ok := r.byKey[k]; ok {
+				return nil, fmt.Errorf("%w: tenant %s", errDuplicateKey, t.ID)
+			}
+			r.byKey[k] = e
+		}
+		r.byID[t.ID] = e
+	}
+	return r, nil
+}
+
+// Load reads a JSON tenant configuration from path.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenant config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse tenant config: %w", err)
+	}
+	return NewRegistry(cfg)
+}
+
+// Resolve identifies the tenant from an API key or, when trusted, from the
+// tenant header. apiKey takes precedence.
+func (r *Registry) Resolve(apiKey, header string) (string, error) {
+	if apiKey != "" {
+		e, ok := r.byKey[apiKey]
+		if !ok {
+			return "", ErrUnknownTenant
+		}
+		return e.ID, nil
+	}
+	if header != "" && r.cfg.TrustTenantHeader {
+		// With a tenant list configured, only known tenants are accepted.
+		if len(r.byID) > 0 {
+			if _, ok := r.byID[header]; !ok {
+				return "", ErrUnknownTenant
+			}
+		}
+		return header, nil
+	}
+	if r.cfg.DefaultTenant != "" {
+		return r.cfg.DefaultTenant, nil
+	}
+	return "", ErrUnidentified
+}
+
+// CheckVehicle returns ErrVehicleDenied if the tenant has a vehicle list
+// that does not include vehicleID. Tenants without configuration (default
+// or trusted header) are not restricted.
+func (r *Registry) CheckVehicle(tenant, vehicleID string) error {
+	e, ok := r.byID[tenant]
+	if !ok || (len(e.vehicles) == 0 && len(e.AllowedVehiclePrefixes) == 0) {
+		return nil
+	}
+	if e.vehicles[vehicleID] {
+		return nil
+	}
+	for _, p := range e.AllowedVehiclePrefixes {
+		if strings.HasPrefix(vehicleID, p) {
+			return nil
+		}
+	}
+	return ErrVehicleDenied
+}
+
+// TakeQuota consumes n pings of the tenant's quota, or returns
+// ErrQuotaExceeded without consuming anything. Batches larger than the
+// burst could never be admitted and get ErrExceedsBurst instead.
+func (r *Registry) TakeQuota(tenant string, n int) error {
+	e, ok := r.byID[tenant]
+	if !ok || e.limiter == nil {
+		return nil
+	}
+	if float64(n) > e.limiter.burst {
+		return ErrExceedsBurst
+	}
+	if !e.limiter.allow(time.Now(), n) {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// Label returns the metric label value for a tenant. Configured tenants
+// always get their own label; others do until MaxMetricTenants distinct
+// values have been handed out.
+func (r *Registry) Label(tenant string) string {
+	if _, ok := r.byID[tenant]; ok {
+		return tenant
+	}
+	r.labelMu.Lock()
+	defer r.labelMu.Unlock()
+	if r.labels[tenant] {
+		return tenant
+	}
+	if len(r.byID)+len(r.labels) >= r.cfg.MaxMetricTenants {
+		return OtherLabel
+	}
+	r.labels[tenant] = true
+	return tenant
+}
+
+type tenantKey struct{}
+
+// NewContext returns a context carrying the tenant ID.
+func NewContext(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// FromContext returns the tenant ID stored by the interceptor.
+func FromContext(ctx context.Context) string {
+	t, _ := ctx.Value(tenantKey{}).(string)
+	return t
+}
@@ -0,0 +1,211 @@
+package topics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultRoute is the route name reported when no rule matched.
+const DefaultRoute = "default"
+
+var routedPings = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ingestion_topic_route_pings_total",
+	Help: "Pings routed to a topic, by matching route and target topic",
+}, []string{"route", "topic"})
+
+// Config is the JSON routing configuration. Routes are evaluated in order;
+// a ping is sent to the topics of every matching route up to and including
+// the first matching route that does not set "continue". Pings matching no
+// route go to DefaultTopics.
+//
+//	{
+//	  "default_topics": ["vehicle-locations"],
+//	  "routes": [
+//	    {"name": "eu", "match": {"bbox": {"min_lat": 34, "min_lon": -25, "max_lat": 72, "max_lon": 45}},
+//	     "topics": ["vehicle-locations-eu"]},
+//	    {"name": "cold-chain", "match": {"attributes": {"vehicle_class": "reefer"}},
+//	     "topics": ["vehicle-locations", "cold-chain-priority"]}
+//	  ]
+//	}
+type Config struct {
+	DefaultTopics []string `json:"default_topics"`
+	Routes        []Route  `json:"routes"`
+}
+
+type Route struct {
+	Name   string   `json:"name"`
+	Match  Match    `json:"match"`
+	Topics []string `json:"topics"`
+	// Continue keeps evaluating later routes after this one matched, so a
+	// ping can be copied to the topics of several routes.
+	Continue bool `json:"continue"`
+}
+
+// Match holds the conditions of a route. Every condition that is set must
+// hold; list conditions match when any element matches. An empty Match
+// matches every ping.
+type Match struct {
+	Tenants         []string          `json:"tenants,omitempty"`
+	VehiclePrefixes []string          `json:"vehicle_prefixes,omitempty"`
+	BBox            *BBox             `json:"bbox,omitempty"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+}
+
+// BBox is a latitude/longitude rectangle. MinLon greater than MaxLon
+// describes a box crossing the antimeridian.
+type BBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+// Contains reports whether the point lies inside the box, edges included.
+func (b BBox) Contains(lat, lon float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLon <= b.MaxLon {
+		return lon >= b.MinLon && lon <= b.MaxLon
+	}
+	return lon >= b.MinLon || lon <= b.MaxLon
+}
+
+// Input is what the router knows about a ping.
+type Input struct {
+	Tenant     string
+	VehicleID  string
+	Latitude   float64
+	Longitude  float64
+	Attributes map[string]string
+}
+
+// Destination is a target topic and the route that selected it.
+type Destination struct {
+	Route string
+	Topic string
+}
+
+type Router struct {
+	defaults []string
+	routes   []Route
+}
+
+// NewRouter validates cfg and builds a router from it.
+func NewRouter(cfg Config) (*Router, error) {
+	if len(cfg.DefaultTopics) == 0 {
+		return nil, fmt.Errorf("routing config needs at least one default topic")
+	}
+	seen := make(map[string]bool)
+	for i, r := range cfg.Routes {
+		if r.Name == "" {
+			return nil, fmt.Errorf("route %d has no name", i)
+		}
+		if r.Name == DefaultRoute || seen[r.Name] {
+			return nil, fmt.Errorf("route name %q is reserved or duplicated", r.Name)
+		}
+		seen[r.Name] = true
+		if len(r.Topics) == 0 {
+			return nil, fmt.Errorf("route %q has no topics", r.Name)
+		}
+		if b := r.Match.BBox; b != nil && b.MinLat > b.MaxLat {
+			return nil, fmt.Errorf("route %q has a bbox with min_lat > max_lat", r.Name)
+		}
+	}
+	return &Router{defaults: cfg.DefaultTopics, routes: cfg.Routes}, nil
+}
+
+// StaticRouter sends everything to topic; it is used when no routing
+// configuration is provided.
+func StaticRouter(topic string) *Router {
+	return &Router{defaults: []string{topic}}
+}
+
+// LoadRouter reads a JSON routing configuration from path.
+func LoadRouter(path string) (*Router, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routing config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse routing config: %w", err)
+	}
+	return NewRouter(cfg)
+}
+
+// Route returns the destinations for a ping, each topic at most once, and
+// counts them per route.
+func (r *Router) Route(in Input) []Destination {
+	var dests []Destination
+	add := func(route string, topics []string) {
+	next:
+		for _, t := range topics {
+			for _, d := range dests {
+				if d.Topic == t {
+					continue next
+				}
+			}
+			dests = append(dests, Destination{Route: route, Topic: t})
+		}
+	}
+
+	for _, route := range r.routes {
+		if !route.Match.matches(in) {
+			continue
+		}
+		add(route.Name, route.Topics)
+		if !route.Continue {
+			break
+		}
+	}
+	if len(dests) == 0 {
+		add(DefaultRoute, r.defaults)
+	}
+
+	for _, d := range dests {
+		routedPings.WithLabelValues(d.Route, d.Topic).Inc()
+	}
+	return dests
+}
+
+func (m Match) matches(in Input) bool {
+	if len(m.Tenants) > 0 && !contains(m.Tenants, in.Tenant) {
+		return false
+	}
+	if len(m.VehiclePrefixes) > 0 {
+		ok := false
+		for _, p := range m.VehiclePrefixes {
+			if strings.HasPrefix(in.VehicleID, p) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if m.BBox != nil && !m.BBox.Contains(in.Latitude, in.Longitude) {
+		return false
+	}
+	for k, v := range m.Attributes {
+		if got, ok := in.Attributes[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
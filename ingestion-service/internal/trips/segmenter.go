@@ -0,0 +1,352 @@
+// Package trips segments each vehicle's ping stream into trips and keeps
+// the completed ones for querying.
+package trips
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// EndReason says why a trip ended.
+type EndReason string
+
+const (
+	EndIgnitionOff EndReason = "ignition_off"
+	EndLongStop    EndReason = "long_stop"
+	EndGap         EndReason = "gap"
+)
+
+// IgnitionAttribute is the ping attribute carrying "on" or "off" for
+// vehicles whose telematics unit reports ignition state.
+const IgnitionAttribute = "ignition"
+
+// Trip is published to the trips topic when it completes.
+type Trip struct {
+	TripID          string    `json:"trip_id"`
+	VehicleID       string    `json:"vehicle_id"`
+	Tenant          string    `json:"tenant,omitempty"`
+	StartTime       int64     `json:"start_time"`
+	EndTime         int64     `json:"end_time"`
+	Start           geo.Point `json:"start"`
+	End             geo.Point `json:"end"`
+	DistanceMeters  float64   `json:"distance_meters"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	MaxSpeedKPH     float64   `json:"max_speed_kph"`
+	AvgSpeedKPH     float64   `json:"avg_speed_kph"`
+	// Polyline is the simplified path in encoded polyline format.
+	Polyline  string    `json:"polyline"`
+	EndReason EndReason `json:"end_reason"`
+}
+
+type Config struct {
+	// StopRadiusMeters is how far a vehicle may drift while still counted
+	// as stationary; moving farther from its parked position starts a trip.
+	StopRadiusMeters float64
+	// LongStop ends a trip when the vehicle stays within StopRadiusMeters
+	// for this long. The trip ends when the stop began.
+	LongStop time.Duration
+	// MaxGap ends a trip at its last ping when no ping arrives for this
+	// long, measured in event time.
+	MaxGap time.Duration
+	// MinDistanceMeters discards shorter trips, such as ignition cycles
+	// without moving.
+	MinDistanceMeters float64
+	// SimplifyMeters is the tolerance of the published polyline.
+	SimplifyMeters float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		StopRadiusMeters:  100,
+		LongStop:          10 * time.Minute,
+		MaxGap:            30 * time.Minute,
+		MinDistanceMeters: 200,
+		SimplifyMeters:    10,
+	}
+}
+
+// Segmenter tracks the open trip of every vehicle. It is safe for
+// concurrent use. Pings older than the vehicle's last ping are ignored.
+type Segmenter struct {
+	cfg Config
+
+	mu         sync.Mutex
+	vehicles   map[string]*vehicleState
+	partitions map[int32]*partitionClock
+}
+
+// partitionClock follows the event time of a source partition.
+type partitionClock struct {
+	// maxTS is the newest ping timestamp read from the partition, and
+	// arrived when the partition last delivered a ping.
+	maxTS   int64
+	arrived time.Time
+}
+
+type ping struct {
+	p  geo.Point
+	ts int64
+}
+
+type vehicleState struct {
+	tenant string
+	// partition is the source partition of the vehicle's pings.
+	partition int32
+	last      ping
+	// parked is where the vehicle has been stationary since parked.ts.
+	parked ping
+	// parkedIdx is the index of parked in trip.path while a trip is open.
+	parkedIdx int
+	ignition  string
+	trip      *openTrip
+}
+
+type openTrip struct {
+	path     []ping
+	maxSpeed float64
+}
+
+// clone copies the state for a transition. The copy shares the open
+// trip's path array: appending to it writes past the original's length,
+// which the original never reads.
+func (st *vehicleState) clone() *vehicleState {
+	c := *st
+	if st.trip != nil {
+		t := *st.trip
+		c.trip = &t
+	}
+	return &c
+}
+
+func NewSegmenter(cfg Config) *Segmenter {
+	return &Segmenter{
+		cfg:        cfg,
+		vehicles:   make(map[string]*vehicleState),
+		partitions: make(map[int32]*partitionClock),
+	}
+}
+
+// Transition is the effect of a ping, or of expiry, on the segmenter: the
+// trips it completes and the state that follows.
+type Transition struct {
+	Trips []Trip
+
+	changes []change
+}
+
+// change replaces a vehicle's state; a nil next forgets the vehicle.
+type change struct {
+	vehicleID  string
+	base, next *vehicleState
+}
+
+// Add computes the effect of one ping, read from the given source
+// partition at now, without changing any vehicle's state, so that a failed
+// publish can be retried against the same state. Call Apply once the
+// completed trips are saved, and before the next Add or Expire. It reports
+// false for out-of-order pings.
+func (s *Segmenter) Add(vehicleID, tenant string, p geo.Point, ts int64, attrs map[string]string, partition int32, now time.Time) (*Transition, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clock, ok := s.partitions[partition]
+	if !ok {
+		clock = &partitionClock{}
+		s.partitions[partition] = clock
+	}
+	clock.maxTS = max(clock.maxTS, ts)
+	clock.arrived = now
+
+	pg := ping{p: p, ts: ts}
+	base, ok := s.vehicles[vehicleID]
+	if !ok {
+		st := &vehicleState{tenant: tenant, partition: partition, last: pg, parked: pg, ignition: attrs[IgnitionAttribute]}
+		return &Transition{changes: []change{{vehicleID, nil, st}}}, true
+	}
+	if ts < base.last.ts {
+		return nil, false
+	}
+	st := base.clone()
+	st.tenant = tenant
+	st.partition = partition
+	t := &Transition{changes: []change{{vehicleID, base, st}}}
+
+	if st.trip != nil && time.Duration(ts-st.last.ts)*time.Second > s.cfg.MaxGap {
+		t.Trips = s.end(vehicleID, st, len(st.trip.path)-1, EndGap, t.Trips)
+		st.parked = st.last
+	}
+
+	ignition := attrs[IgnitionAttribute]
+	ignitionOn := ignition == "on" && st.ignition != "on"
+	ignitionOff := ignition == "off" && st.ignition != "off"
+	if ignition != "" {
+		st.ignition = ignition
+	}
+
+	moved := geo.Distance(st.parked.p, p) > s.cfg.StopRadiusMeters
+	if st.trip == nil {
+		switch {
+		case moved:
+			// The trip starts at the last ping before departure, which
+			// is still at the parked position.
+			st.trip = &openTrip{path: []ping{st.last}}
+		case ignitionOn:
+			st.trip = &openTrip{path: []ping{pg}}
+			st.parked = pg
+		}
+		st.parkedIdx = 0
+	}
+	if st.trip != nil {
+		s.extend(st.trip, pg)
+		if moved {
+			st.parked = pg
+			st.parkedIdx = len(st.trip.path) - 1
+		}
+		switch {
+		case ignitionOff:
+			t.Trips = s.end(vehicleID, st, len(st.trip.path)-1, EndIgnitionOff, t.Trips)
+			st.parked = pg
+		case time.Duration(ts-st.parked.ts)*time.Second >= s.cfg.LongStop:
+			t.Trips = s.end(vehicleID, st, st.parkedIdx, EndLongStop, t.Trips)
+		}
+	} else if moved {
+		st.parked = pg
+	}
+	st.last = pg
+	return t, true
+}
+
+func (s *Segmenter) extend(t *openTrip, pg ping) {
+	prev := t.path[len(t.path)-1]
+	if prev == pg {
+		return
+	}
+	if dt := pg.ts - prev.ts; dt > 0 {
+		t.maxSpeed = math.Max(t.maxSpeed, geo.Distance(prev.p, pg.p)/float64(dt))
+	}
+	t.path = append(t.path, pg)
+}
+
+// end closes the open trip at path[endIdx] and appends it to done unless it
+// is too short to count.
+func (s *Segmenter) end(vehicleID string, st *vehicleState, endIdx int, reason EndReason, done []Trip) []Trip {
+	path := st.trip.path[:endIdx+1]
+	maxSpeed := st.trip.maxSpeed
+	st.trip = nil
+
+	points := make([]geo.Point, len(path))
+	dist := 0.0
+	for i, pg := range path {
+		points[i] = pg.p
+		if i > 0 {
+			dist += geo.Distance(path[i-1].p, pg.p)
+		}
+	}
+	if dist < s.cfg.MinDistanceMeters {
+		return done
+	}
+
+	first, last := path[0], path[len(path)-1]
+	t := Trip{
+		TripID:          fmt.Sprintf("%s:%d", vehicleID, first.ts),
+		VehicleID:       vehicleID,
+		Tenant:          st.tenant,
+		StartTime:       first.ts,
+		EndTime:         last.ts,
+		Start:           first.p,
+		End:             last.p,
+		DistanceMeters:  dist,
+		DurationSeconds: last.ts - first.ts,
+		MaxSpeedKPH:     maxSpeed * 3.6,
+		Polyline:        geo.EncodePolyline(geo.Simplify(points, s.cfg.SimplifyMeters)),
+		EndReason:       reason,
+	}
+	if t.DurationSeconds > 0 {
+		t.AvgSpeedKPH = dist / float64(t.DurationSeconds) * 3.6
+	}
+	return append(done, t)
+}
+
+// Expire computes the end of the open trips of vehicles that have sent
+// nothing for MaxGap, and the forgetting of vehicles silent since before
+// idleBefore (Unix seconds). Like Add, it changes no state until the
+// transition is applied.
+//
+// The gap is measured against the event time of the vehicle's partition:
+// its newest ping's timestamp, advanced by the time since the partition
+// last delivered a ping. A consumer catching up on lag therefore judges
+// trips by the pings' own times, while an idle partition's trips still
+// end.
+func (s *Segmenter) Expire(now time.Time, idleBefore int64) *Transition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &Transition{}
+	for id, base := range s.vehicles {
+		next := base
+		if base.trip != nil && base.last.ts < s.watermark(base.partition, now)-int64(s.cfg.MaxGap/time.Second) {
+			next = base.clone()
+			t.Trips = s.end(id, next, len(next.trip.path)-1, EndGap, t.Trips)
+			next.parked = next.last
+		}
+		if next.trip == nil && next.last.ts < idleBefore {
+			next = nil
+		}
+		if next != base {
+			t.changes = append(t.changes, change{id, base, next})
+		}
+	}
+	return t
+}
+
+// watermark returns the event time of a partition at now.
+func (s *Segmenter) watermark(partition int32, now time.Time) int64 {
+	clock, ok := s.partitions[partition]
+	if !ok {
+		return now.Unix()
+	}
+	return clock.maxTS + int64(now.Sub(clock.arrived)/time.Second)
+}
+
+// Apply makes a transition's state current. Vehicles whose state changed
+// since the transition was computed are left alone.
+func (s *Segmenter) Apply(t *Transition) {
+	if t == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range t.changes {
+		if s.vehicles[c.vehicleID] != c.base {
+			continue
+		}
+		if c.next == nil {
+			delete(s.vehicles, c.vehicleID)
+		} else {
+			s.vehicles[c.vehicleID] = c.next
+		}
+	}
+}
+
+// Vehicles returns the number of vehicles with tracked state.
+func (s *Segmenter) Vehicles() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.vehicles)
+}
+
+// OpenTrips returns the number of trips in progress.
+func (s *Segmenter) OpenTrips() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, st := range s.vehicles {
+		if st.trip != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,61 @@
+package trips
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// Server implements the TripService gRPC API.
+type Server struct {
+	pb.UnimplementedTripServiceServer
+	store Store
+}
+
+func NewServer(store Store) *Server {
+	return &Server{store: store}
+}
+
+func (s *Server) ListRecentTrips(ctx context.Context, req *pb.ListRecentTripsRequest) (*pb.ListRecentTripsResponse, error) {
+	if req.GetVehicleId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "vehicle_id is required")
+	}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	trips, err := s.store.Recent(ctx, req.GetVehicleId(), req.GetSince(), limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.ListRecentTripsResponse{}
+	for _, t := range trips {
+		resp.Trips = append(resp.Trips, &pb.Trip{
+			TripId:          t.TripID,
+			VehicleId:       t.VehicleID,
+			Tenant:          t.Tenant,
+			StartTime:       t.StartTime,
+			EndTime:         t.EndTime,
+			Start:           &pb.LatLng{Latitude: t.Start.Lat, Longitude: t.Start.Lon},
+			End:             &pb.LatLng{Latitude: t.End.Lat, Longitude: t.End.Lon},
+			DistanceMeters:  t.DistanceMeters,
+			DurationSeconds: t.DurationSeconds,
+			MaxSpeedKph:     t.MaxSpeedKPH,
+			AvgSpeedKph:     t.AvgSpeedKPH,
+			Polyline:        t.Polyline,
+			EndReason:       string(t.EndReason),
+		})
+	}
+	return resp, nil
+}
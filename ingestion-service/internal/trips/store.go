@@ -0,0 +1,103 @@
+package trips
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// Store keeps completed trips for the recent-trips query.
+type Store interface {
+	Add(ctx context.Context, t Trip) error
+	// Recent returns up to limit trips of a vehicle that ended at or after
+	// since, newest first.
+	Recent(ctx context.Context, vehicleID string, since int64, limit int) ([]Trip, error)
+}
+
+// MemoryStore keeps the last trips of each vehicle in process memory. It is
+// used when no database is configured and starts empty after a restart.
+type MemoryStore struct {
+	perVehicle int
+
+	mu    sync.Mutex
+	trips map[string][]Trip
+}
+
+func NewMemoryStore(perVehicle int) *MemoryStore {
+	return &MemoryStore{perVehicle: perVehicle, trips: make(map[string][]Trip)}
+}
+
+func (s *MemoryStore) Add(ctx context.Context, t Trip) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := append(s.trips[t.VehicleID], t)
+	if len(ts) > s.perVehicle {
+		ts = append(ts[:0], ts[len(ts)-s.perVehicle:]...)
+	}
+	s.trips[t.VehicleID] = ts
+	return nil
+}
+
+func (s *MemoryStore) Recent(ctx context.Context, vehicleID string, since int64, limit int) ([]Trip, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ts := s.trips[vehicleID]
+	var out []Trip
+	for i := len(ts) - 1; i >= 0 && len(out) < limit; i-- {
+		if ts[i].EndTime >= since {
+			out = append(out, ts[i])
+		}
+	}
+	return out, nil
+}
+
+// PostgresStore keeps trips in the trips table for billing and reports.
+// The table is created by tracking-service/migrations/init.sql.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Add is idempotent on the trip ID, so reprocessing pings after a restart
+// does not duplicate trips.
+func (s *PostgresStore) Add(ctx context.Context, t Trip) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO trips (trip_id, vehicle_id, tenant, start_time, end_time,
+			start_latitude, start_longitude, end_latitude, end_longitude,
+			distance_meters, duration_seconds, max_speed_kph, avg_speed_kph, polyline, end_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (trip_id) DO NOTHING`,
+		t.TripID, t.VehicleID, t.Tenant, t.StartTime, t.EndTime,
+		t.Start.Lat, t.Start.Lon, t.End.Lat, t.End.Lon,
+		t.DistanceMeters, t.DurationSeconds, t.MaxSpeedKPH, t.AvgSpeedKPH, t.Polyline, string(t.EndReason))
+	return err
+}
+
+func (s *PostgresStore) Recent(ctx context.Context, vehicleID string, since int64, limit int) ([]Trip, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT trip_id, vehicle_id, COALESCE(tenant, ''), start_time, end_time,
+			start_latitude, start_longitude, end_latitude, end_longitude,
+			distance_meters, duration_seconds, max_speed_kph, avg_speed_kph, polyline, end_reason
+		FROM trips WHERE vehicle_id = $1 AND end_time >= $2
+		ORDER BY end_time DESC LIMIT $3`, vehicleID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Trip
+	for rows.Next() {
+		var t Trip
+		var reason string
+		if err := rows.Scan(&t.TripID, &t.VehicleID, &t.Tenant, &t.StartTime, &t.EndTime,
+			&t.Start.Lat, &t.Start.Lon, &t.End.Lat, &t.End.Lon,
+			&t.DistanceMeters, &t.DurationSeconds, &t.MaxSpeedKPH, &t.AvgSpeedKPH, &t.Polyline, &reason); err != nil {
+			return nil, err
+		}
+		t.EndReason = EndReason(reason)
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,156 @@
+// Package vrp plans delivery routes for a fleet: which vehicle serves which
+// orders and in what sequence, respecting vehicle capacities, delivery time
+// windows, service times and driver shifts.
+package vrp
+
+import (
+	"fmt"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// Vehicle is a truck with a driver shift. Times are Unix seconds.
+type Vehicle struct {
+	ID    string
+	Start geo.Point
+	// End is where the route must finish; nil leaves the route open at its
+	// last stop.
+	End *geo.Point
+	// Capacity per load dimension (for example kg and m³). Dimensions an
+	// order uses beyond len(Capacity) are unlimited.
+	Capacity []float64
+	// ShiftStart is when the vehicle may leave. ShiftEnd, if set, is when
+	// it must be back (or done, for open routes).
+	ShiftStart int64
+	ShiftEnd   int64
+	// MaxShiftSeconds, if set, bounds the time from departure to the end
+	// of the route, such as a driver's maximum working time.
+	MaxShiftSeconds int64
+}
+
+// Order is a delivery to one location.
+type Order struct {
+	ID             string
+	Location       geo.Point
+	Demand         []float64
+	ServiceSeconds int64
+	// WindowStart and WindowEnd bound when service may start; zero leaves
+	// that side open. Vehicles arriving early wait.
+	WindowStart int64
+	WindowEnd   int64
+}
+
+type Problem struct {
+	Vehicles []Vehicle
+	Orders   []Order
+}
+
+// Validate checks IDs and numeric fields.
+func (p *Problem) Validate() error {
+	if len(p.Vehicles) == 0 {
+		return fmt.Errorf("no vehicles")
+	}
+	ids := make(map[string]bool)
+	for _, v := range p.Vehicles {
+		if v.ID == "" || ids[v.ID] {
+			return fmt.Errorf("vehicle ids must be unique and non-empty (%q)", v.ID)
+		}
+		ids[v.ID] = true
+		if !v.Start.Valid() || (v.End != nil && !v.End.Valid()) {
+			return fmt.Errorf("vehicle %s has invalid coordinates", v.ID)
+		}
+		if v.ShiftEnd != 0 && v.ShiftEnd < v.ShiftStart {
+			return fmt.Errorf("vehicle %s shift ends before it starts", v.ID)
+		}
+	}
+	ids = make(map[string]bool)
+	for _, o := range p.Orders {
+		if o.ID == "" || ids[o.ID] {
+			return fmt.Errorf("order ids must be unique and non-empty (%q)", o.ID)
+		}
+		ids[o.ID] = true
+		if !o.Location.Valid() {
+			return fmt.Errorf("order %s has invalid coordinates", o.ID)
+		}
+		if o.ServiceSeconds < 0 {
+			return fmt.Errorf("order %s has a negative service time", o.ID)
+		}
+		if o.WindowEnd != 0 && o.WindowEnd < o.WindowStart {
+			return fmt.Errorf("order %s time window ends before it starts", o.ID)
+		}
+	}
+	return nil
+}
+
+// Points returns the locations the cost matrices passed to Solve must
+// cover, in order: every vehicle's start, every vehicle's end (its start
+// for open routes), then every order.
+func (p *Problem) Points() []geo.Point {
+	points := make([]geo.Point, 0, 2*len(p.Vehicles)+len(p.Orders))
+	for _, v := range p.Vehicles {
+		points = append(points, v.Start)
+	}
+	for _, v := range p.Vehicles {
+		if v.End != nil {
+			points = append(points, *v.End)
+		} else {
+			points = append(points, v.Start)
+		}
+	}
+	for _, o := range p.Orders {
+		points = append(points, o.Location)
+	}
+	return points
+}
+
+// Reason explains why an order was left unassigned.
+type Reason string
+
+const (
+	// ReasonUnreachable: no vehicle can drive to the order.
+	ReasonUnreachable Reason = "unreachable"
+	// ReasonCapacity: the demand exceeds every vehicle's capacity.
+	ReasonCapacity Reason = "capacity"
+	// ReasonTimeWindow: no vehicle can arrive within the time window.
+	ReasonTimeWindow Reason = "time_window"
+	// ReasonShiftLimit: serving the order does not fit in any shift.
+	ReasonShiftLimit Reason = "shift_limit"
+	// ReasonFleetFull: the order fits a vehicle on its own but not
+	// together with the orders already planned.
+	ReasonFleetFull Reason = "fleet_full"
+	// ReasonTimeLimit: planning ran out of time before placing the order.
+	ReasonTimeLimit Reason = "time_limit"
+)
+
+type Stop struct {
+	OrderID      string
+	Arrival      int64
+	ServiceStart int64
+	Departure    int64
+}
+
+type Route struct {
+	VehicleID string
+	Stops     []Stop
+	// Departure is when the vehicle leaves its start; it is delayed past
+	// ShiftStart when the first stop would otherwise mean waiting.
+	Departure int64
+	// Finish is the arrival at the end location, or the departure from the
+	// last stop of an open route.
+	Finish          int64
+	DistanceMeters  float64
+	DurationSeconds float64 // driving time
+	Load            []float64
+}
+
+type Unassigned struct {
+	OrderID string
+	Reason  Reason
+}
+
+type Solution struct {
+	Routes               []Route
+	Unassigned           []Unassigned
+	TotalDistanceMeters  float64
+	TotalDurationSeconds float64
+}
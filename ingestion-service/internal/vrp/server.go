@@ -0,0 +1,144 @@
+package vrp
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+)
+
+const (
+	defaultTimeLimit = 5 * time.Second
+	maxTimeLimit     = time.Minute
+	// maxPoints bounds the size of one request: every vehicle's start and
+	// end and every order. The cost matrix has its square in cells, which
+	// matches the streaming matrix's cap.
+	maxPoints = 1000
+	// maxVehicles bounds the fleet, whose routes local search compares
+	// pairwise.
+	maxVehicles = 200
+)
+
+// CostFunc returns the travel time (seconds) and distance (meters) matrices
+// between points. It returns ctx's error if ctx is done first.
+type CostFunc func(ctx context.Context, points []geo.Point) (durations, distances [][]float64, err error)
+
+// Server implements the PlanningService gRPC API.
+type Server struct {
+	pb.UnimplementedPlanningServiceServer
+	costs CostFunc
+}
+
+func NewServer(costs CostFunc) *Server {
+	return &Server{costs: costs}
+}
+
+func (s *Server) PlanRoutes(ctx context.Context, req *pb.PlanRoutesRequest) (*pb.PlanRoutesResponse, error) {
+	if len(req.GetVehicles()) > maxVehicles {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d vehicles per request", maxVehicles)
+	}
+	if n := 2*len(req.GetVehicles()) + len(req.GetOrders()); n > maxPoints {
+		return nil, status.Errorf(codes.InvalidArgument, "%d points (two per vehicle, one per order) exceeds the limit of %d", n, maxPoints)
+	}
+	p := fromProto(req)
+	if err := p.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := time.Duration(req.GetTimeLimitMs()) * time.Millisecond
+	if limit <= 0 {
+		limit = defaultTimeLimit
+	}
+	if limit > maxTimeLimit {
+		limit = maxTimeLimit
+	}
+	// Stop when the caller gives up. The limit covers computing the cost
+	// matrix as well as planning.
+	if dl, ok := ctx.Deadline(); ok && time.Until(dl) < limit {
+		limit = time.Until(dl)
+	}
+	deadline := time.Now().Add(limit)
+	costCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	durations, distances, err := s.costs(costCtx, p.Points())
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	sol, err := Solve(ctx, p, durations, distances, Options{TimeLimit: time.Until(deadline)})
+	if ctx.Err() != nil {
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return toProto(sol), nil
+}
+
+func point(p *pb.LatLng) geo.Point {
+	return geo.Point{Lat: p.GetLatitude(), Lon: p.GetLongitude()}
+}
+
+func fromProto(req *pb.PlanRoutesRequest) *Problem {
+	p := &Problem{}
+	for _, v := range req.GetVehicles() {
+		veh := Vehicle{
+			ID:              v.GetId(),
+			Start:           point(v.GetStart()),
+			Capacity:        v.GetCapacity(),
+			ShiftStart:      v.GetShiftStart(),
+			ShiftEnd:        v.GetShiftEnd(),
+			MaxShiftSeconds: v.GetMaxShiftSeconds(),
+		}
+		if v.GetEnd() != nil {
+			end := point(v.GetEnd())
+			veh.End = &end
+		}
+		p.Vehicles = append(p.Vehicles, veh)
+	}
+	for _, o := range req.GetOrders() {
+		p.Orders = append(p.Orders, Order{
+			ID:             o.GetId(),
+			Location:       point(o.GetLocation()),
+			Demand:         o.GetDemand(),
+			ServiceSeconds: o.GetServiceSeconds(),
+			WindowStart:    o.GetTimeWindowStart(),
+			WindowEnd:      o.GetTimeWindowEnd(),
+		})
+	}
+	return p
+}
+
+func toProto(sol *Solution) *pb.PlanRoutesResponse {
+	resp := &pb.PlanRoutesResponse{
+		TotalDistanceMeters: sol.TotalDistanceMeters,
+		TotalDrivingSeconds: sol.TotalDurationSeconds,
+	}
+	for _, r := range sol.Routes {
+		route := &pb.PlannedRoute{
+			VehicleId:      r.VehicleID,
+			DepartureTime:  r.Departure,
+			FinishTime:     r.Finish,
+			DistanceMeters: r.DistanceMeters,
+			DrivingSeconds: r.DurationSeconds,
+			Load:           r.Load,
+		}
+		for _, st := range r.Stops {
+			route.Stops = append(route.Stops, &pb.PlannedStop{
+				OrderId:       st.OrderID,
+				ArrivalTime:   st.Arrival,
+				ServiceStart:  st.ServiceStart,
+				DepartureTime: st.Departure,
+			})
+		}
+		resp.Routes = append(resp.Routes, route)
+	}
+	for _, u := range sol.Unassigned {
+		resp.Unassigned = append(resp.Unassigned, &pb.UnassignedOrder{OrderId: u.OrderID, Reason: string(u.Reason)})
+	}
+	return resp
+}
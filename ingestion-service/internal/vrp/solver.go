@@ -0,0 +1,622 @@
+package vrp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Options tune the search.
+type Options struct {
+	// TimeLimit bounds construction and local search together. Orders not
+	// yet placed when it runs out are left unassigned with ReasonTimeLimit.
+	TimeLimit time.Duration
+}
+
+// Solve plans routes minimizing total driving time. durations (seconds) and
+// distances (meters) are square matrices over p.Points(); +Inf marks pairs
+// with no route.
+//
+// Orders are first inserted by regret insertion, which places the orders
+// with the fewest good options first. Local search then improves the plan
+// with relocate and or-opt (moving runs of up to three stops), 2-opt within
+// a route and swaps between routes, and retries unassigned orders. Solve
+// returns ctx's error if ctx is done first.
+func Solve(ctx context.Context, p *Problem, durations, distances [][]float64, opts Options) (*Solution, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	n := len(p.Points())
+	if len(durations) != n || len(distances) != n {
+		return nil, fmt.Errorf("cost matrices must be %dx%d", n, n)
+	}
+	s := &solver{
+		ctx:      ctx,
+		p:        p,
+		dur:      durations,
+		dist:     distances,
+		routes:   make([][]int, len(p.Vehicles)),
+		deadline: time.Now().Add(opts.TimeLimit),
+		timedOut: make(map[int]bool),
+	}
+	s.construct()
+	s.improve()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.solution(), nil
+}
+
+type solver struct {
+	ctx      context.Context
+	p        *Problem
+	dur      [][]float64
+	dist     [][]float64
+	routes   [][]int // order indices per vehicle
+	deadline time.Time
+	// timedOut holds the orders construction had no time to place.
+	timedOut map[int]bool
+}
+
+func (s *solver) startIdx(v int) int { return v }
+func (s *solver) endIdx(v int) int   { return len(s.p.Vehicles) + v }
+func (s *solver) orderIdx(o int) int { return 2*len(s.p.Vehicles) + o }
+
+// failure stages, ordered by how far a route got before failing; used to
+// explain unassigned orders.
+const (
+	stageOK = iota
+	stageCapacity
+	stageUnreachable
+	stageTimeWindow
+	stageShift
+)
+
+var stageReasons = map[int]Reason{
+	stageUnreachable: ReasonUnreachable,
+	stageCapacity:    ReasonCapacity,
+	stageTimeWindow:  ReasonTimeWindow,
+	stageShift:       ReasonShiftLimit,
+}
+
+// schedule is the simulated timeline of one route.
+type schedule struct {
+	departure float64
+	arrivals  []float64
+	starts    []float64
+	finish    float64
+	travel    float64
+	distance  float64
+	load      []float64
+}
+
+// simulate drives vehicle v through seq and returns its schedule, or the
+// stage at which the route became infeasible.
+func (s *solver) simulate(v int, seq []int) (*schedule, int) {
+	veh := &s.p.Vehicles[v]
+
+	load := make([]float64, len(veh.Capacity))
+	for _, o := range seq {
+		for d, q := range s.p.Orders[o].Demand {
+			if d < len(load) {
+				load[d] += q
+			}
+		}
+	}
+
+	for d, q := range load {
+		if q > veh.Capacity[d] {
+			return nil, stageCapacity
+		}
+	}
+
+	sc := &schedule{
+		departure: float64(veh.ShiftStart),
+		arrivals:  make([]float64, len(seq)),
+		starts:    make([]float64, len(seq)),
+		load:      load,
+	}
+	t := sc.departure
+	prev := s.startIdx(v)
+	for i, o := range seq {
+		next := s.orderIdx(o)
+		d := s.dur[prev][next]
+		if math.IsInf(d, 1) {
+			return nil, stageUnreachable
+		}
+		sc.travel += d
+		sc.distance += s.dist[prev][next]
+		t += d
+		sc.arrivals[i] = t
+		ord := &s.p.Orders[o]
+		if ord.WindowStart != 0 && t < float64(ord.WindowStart) {
+			if i == 0 {
+				// Leave later instead of waiting at the first stop.
+				wait := float64(ord.WindowStart) - t
+				sc.departure += wait
+				sc.arrivals[i] += wait
+			}
+			t = float64(ord.WindowStart)
+		}
+		if ord.WindowEnd != 0 && t > float64(ord.WindowEnd) {
+			return nil, stageTimeWindow
+		}
+		sc.starts[i] = t
+		t += float64(ord.ServiceSeconds)
+		prev = next
+	}
+	if veh.End != nil {
+		d := s.dur[prev][s.endIdx(v)]
+		if math.IsInf(d, 1) {
+			return nil, stageUnreachable
+		}
+		sc.travel += d
+		sc.distance += s.dist[prev][s.endIdx(v)]
+		t += d
+	}
+	sc.finish = t
+	if veh.ShiftEnd != 0 && t > float64(veh.ShiftEnd) {
+		return nil, stageShift
+	}
+	if veh.MaxShiftSeconds != 0 && t-sc.departure > float64(veh.MaxShiftSeconds) {
+		return nil, stageShift
+	}
+	return sc, stageOK
+}
+
+// cost is the objective of one route, +Inf when infeasible.
+func (s *solver) cost(v int, seq []int) float64 {
+	if len(seq) == 0 {
+		return 0
+	}
+	sc, stage := s.simulate(v, seq)
+	if stage != stageOK {
+		return math.Inf(1)
+	}
+	return sc.travel
+}
+
+func insertAt(seq []int, pos, o int) []int {
+	out := make([]int, 0, len(seq)+1)
+	out = append(out, seq[:pos]...)
+	out = append(out, o)
+	return append(out, seq[pos:]...)
+}
+
+// routeState is what insertion needs to know about a route to check a new
+// stop in constant time.
+type routeState struct {
+	v      int
+	seq    []int
+	load   []float64
+	starts []float64 // service start at each stop
+	// latest[i] is the latest service start at stop i that keeps the rest
+	// of the route within its time windows and shift end.
+	latest []float64
+	// shiftEnd is the latest finish, +Inf if unbounded.
+	shiftEnd float64
+}
+
+// prepare computes vehicle v's route state, or nil if its route is
+// infeasible.
+func (s *solver) prepare(v int) *routeState {
+	seq := s.routes[v]
+	veh := &s.p.Vehicles[v]
+	sc, stage := s.simulate(v, seq)
+	if len(seq) == 0 {
+		// An unused vehicle does not drive, even if its end is unreachable.
+		sc, stage = &schedule{load: make([]float64, len(veh.Capacity))}, stageOK
+	}
+	if stage != stageOK {
+		return nil
+	}
+	rs := &routeState{v: v, seq: seq, load: sc.load, starts: sc.starts, latest: make([]float64, len(seq)), shiftEnd: math.Inf(1)}
+	if veh.ShiftEnd != 0 {
+		rs.shiftEnd = float64(veh.ShiftEnd)
+	}
+	// Walk back from the end: the latest arrival at the next node bounds
+	// the latest start here. Waiting for a window never helps, so arriving
+	// by the latest start is enough.
+	bound, next := rs.shiftEnd, -1
+	if veh.End != nil {
+		next = s.endIdx(v)
+	}
+	for i := len(seq) - 1; i >= 0; i-- {
+		node, ord := s.orderIdx(seq[i]), &s.p.Orders[seq[i]]
+		l := bound - float64(ord.ServiceSeconds)
+		if next >= 0 {
+			l -= s.dur[node][next]
+		}
+		if ord.WindowEnd != 0 {
+			l = min(l, float64(ord.WindowEnd))
+		}
+		rs.latest[i] = l
+		bound, next = l, node
+	}
+	return rs
+}
+
+// bestInsertion returns the cheapest position for order o in vehicle v's
+// route and the cost increase, or +Inf if it fits nowhere.
+func (s *solver) bestInsertion(v, o int) (int, float64) {
+	return s.bestInsertionInto(s.prepare(v), o)
+}
+
+// bestInsertionInto is bestInsertion against a prepared route. Positions
+// are screened in constant time each; the cheapest that passes is then
+// confirmed by simulation, which also covers MaxShiftSeconds.
+func (s *solver) bestInsertionInto(rs *routeState, o int) (int, float64) {
+	if rs == nil {
+		return -1, math.Inf(1)
+	}
+	v, seq := rs.v, rs.seq
+	veh, ord := &s.p.Vehicles[v], &s.p.Orders[o]
+	for d, q := range ord.Demand {
+		if d < len(rs.load) && rs.load[d]+q > veh.Capacity[d] {
+			return -1, math.Inf(1)
+		}
+	}
+
+	type candidate struct {
+		pos   int
+		delta float64
+	}
+	var candidates []candidate
+	node := s.orderIdx(o)
+	for pos := 0; pos <= len(seq); pos++ {
+		prev, t := s.startIdx(v), float64(veh.ShiftStart)
+		if pos > 0 {
+			prev = s.orderIdx(seq[pos-1])
+			t = rs.starts[pos-1] + float64(s.p.Orders[seq[pos-1]].ServiceSeconds)
+		}
+		in := s.dur[prev][node]
+		if math.IsInf(in, 1) {
+			continue
+		}
+		start := t + in
+		if ord.WindowStart != 0 {
+			start = max(start, float64(ord.WindowStart))
+		}
+		if ord.WindowEnd != 0 && start > float64(ord.WindowEnd) {
+			continue
+		}
+		leave := start + float64(ord.ServiceSeconds)
+
+		next, bound := -1, rs.shiftEnd
+		if pos < len(seq) {
+			next, bound = s.orderIdx(seq[pos]), rs.latest[pos]
+		} else if veh.End != nil {
+			next = s.endIdx(v)
+		}
+		delta := in
+		if next >= 0 {
+			out := s.dur[node][next]
+			if math.IsInf(out, 1) {
+				continue
+			}
+			leave += out
+			delta += out
+			if len(seq) > 0 {
+				delta -= s.dur[prev][next]
+			}
+		}
+		if leave > bound {
+			continue
+		}
+		candidates = append(candidates, candidate{pos, delta})
+	}
+
+	// The screen is exact up to rounding and MaxShiftSeconds, so the
+	// cheapest candidate nearly always passes and sorting is rarely needed.
+	cheapest := -1
+	for i, c := range candidates {
+		if cheapest < 0 || c.delta < candidates[cheapest].delta {
+			cheapest = i
+		}
+	}
+	if cheapest < 0 {
+		return -1, math.Inf(1)
+	}
+	if c := candidates[cheapest]; s.feasible(v, insertAt(seq, c.pos, o)) {
+		return c.pos, c.delta
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].delta < candidates[j].delta })
+	for _, c := range candidates[1:] {
+		if s.feasible(v, insertAt(seq, c.pos, o)) {
+			return c.pos, c.delta
+		}
+	}
+	return -1, math.Inf(1)
+}
+
+func (s *solver) feasible(v int, seq []int) bool {
+	_, stage := s.simulate(v, seq)
+	return stage == stageOK
+}
+
+// construct builds the initial plan by regret-2 insertion. Orders still
+// pending when time runs out are marked timed out.
+func (s *solver) construct() {
+	nv, no := len(s.p.Vehicles), len(s.p.Orders)
+	type insertion struct {
+		pos   int
+		delta float64
+	}
+	// best[o][v] caches the cheapest insertion of o into v; only the
+	// column of the vehicle that changed is recomputed.
+	best := make([][]insertion, no)
+	pending := make(map[int]bool, no)
+	defer func() {
+		for o := range pending {
+			s.timedOut[o] = true
+		}
+	}()
+	states := make([]*routeState, nv)
+	for v := range states {
+		states[v] = s.prepare(v)
+	}
+	for o := 0; o < no; o++ {
+		pending[o] = true
+	}
+	for o := 0; o < no; o++ {
+		if s.expired() {
+			return
+		}
+		best[o] = make([]insertion, nv)
+		for v := 0; v < nv; v++ {
+			pos, d := s.bestInsertionInto(states[v], o)
+			best[o][v] = insertion{pos, d}
+		}
+	}
+
+	for len(pending) > 0 {
+		if s.expired() {
+			return
+		}
+		pick, pickV := -1, -1
+		pickRegret, pickDelta := math.Inf(-1), math.Inf(1)
+		for o := range pending {
+			first, second, fv := math.Inf(1), math.Inf(1), -1
+			for v, ins := range best[o] {
+				switch {
+				case ins.delta < first:
+					first, second, fv = ins.delta, first, v
+				case ins.delta < second:
+					second = ins.delta
+				}
+			}
+			if fv < 0 {
+				continue
+			}
+			// An order with a single feasible vehicle has infinite
+			// regret and is placed before its only option disappears.
+			regret := second - first
+			if regret > pickRegret || (regret == pickRegret && (first < pickDelta || (first == pickDelta && o < pick))) {
+				pick, pickV, pickRegret, pickDelta = o, fv, regret, first
+			}
+		}
+		if pick < 0 {
+			// The rest fit nowhere; they are unassigned, not timed out.
+			clear(pending)
+			return
+		}
+		s.routes[pickV] = insertAt(s.routes[pickV], best[pick][pickV].pos, pick)
+		delete(pending, pick)
+		rs := s.prepare(pickV)
+		for o := range pending {
+			pos, d := s.bestInsertionInto(rs, o)
+			best[o][pickV] = insertion{pos, d}
+		}
+	}
+}
+
+func (s *solver) expired() bool {
+	return s.ctx.Err() != nil || time.Now().After(s.deadline)
+}
+
+// improve runs local search moves until none improves or time runs out.
+func (s *solver) improve() {
+	for !s.expired() {
+		improved := s.moveSegments() || s.twoOpt() || s.swap() || s.insertUnassigned()
+		if !improved {
+			return
+		}
+	}
+}
+
+const epsilon = 1e-6
+
+// moveSegments tries moving runs of one to three consecutive stops, kept
+// or reversed, to any other position in any route (relocate and or-opt).
+func (s *solver) moveSegments() bool {
+	for v1 := range s.routes {
+		for k := 1; k <= 3; k++ {
+			for i := 0; i+k <= len(s.routes[v1]); i++ {
+				if s.expired() {
+					return false
+				}
+				seq1 := s.routes[v1]
+				seg := append([]int(nil), seq1[i:i+k]...)
+				rest := append(append([]int(nil), seq1[:i]...), seq1[i+k:]...)
+				base1 := s.cost(v1, seq1)
+				restCost := s.cost(v1, rest)
+				for v2 := range s.routes {
+					target := s.routes[v2]
+					if v2 == v1 {
+						target = rest
+					}
+					base2 := s.cost(v2, s.routes[v2])
+					for pos := 0; pos <= len(target); pos++ {
+						for _, reversed := range []bool{false, true} {
+							if reversed && k == 1 {
+								continue
+							}
+							ins := seg
+							if reversed {
+								ins = reverse(seg)
+							}
+							cand := append(append(append([]int(nil), target[:pos]...), ins...), target[pos:]...)
+							var before, after float64
+							if v2 == v1 {
+								before, after = base1, s.cost(v1, cand)
+							} else {
+								before, after = base1+base2, restCost+s.cost(v2, cand)
+							}
+							if after < before-epsilon {
+								if v2 == v1 {
+									s.routes[v1] = cand
+								} else {
+									s.routes[v1], s.routes[v2] = rest, cand
+								}
+								return true
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// twoOpt reverses a section of a route when that shortens it.
+func (s *solver) twoOpt() bool {
+	for v, seq := range s.routes {
+		base := s.cost(v, seq)
+		for i := 0; i < len(seq)-1; i++ {
+			for j := i + 1; j < len(seq); j++ {
+				if s.expired() {
+					return false
+				}
+				cand := append(append(append([]int(nil), seq[:i]...), reverse(seq[i:j+1])...), seq[j+1:]...)
+				if s.cost(v, cand) < base-epsilon {
+					s.routes[v] = cand
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// swap exchanges two stops of different routes.
+func (s *solver) swap() bool {
+	for v1 := range s.routes {
+		for v2 := v1 + 1; v2 < len(s.routes); v2++ {
+			seq1, seq2 := s.routes[v1], s.routes[v2]
+			base := s.cost(v1, seq1) + s.cost(v2, seq2)
+			for i := range seq1 {
+				for j := range seq2 {
+					if s.expired() {
+						return false
+					}
+					c1 := append([]int(nil), seq1...)
+					c2 := append([]int(nil), seq2...)
+					c1[i], c2[j] = seq2[j], seq1[i]
+					if s.cost(v1, c1)+s.cost(v2, c2) < base-epsilon {
+						s.routes[v1], s.routes[v2] = c1, c2
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// insertUnassigned adds any unassigned order that now fits somewhere.
+func (s *solver) insertUnassigned() bool {
+	for _, o := range s.unassigned() {
+		bestV, bestPos, bestDelta := -1, -1, math.Inf(1)
+		for v := range s.routes {
+			if pos, d := s.bestInsertion(v, o); d < bestDelta {
+				bestV, bestPos, bestDelta = v, pos, d
+			}
+		}
+		if bestV >= 0 {
+			s.routes[bestV] = insertAt(s.routes[bestV], bestPos, o)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *solver) unassigned() []int {
+	assigned := make([]bool, len(s.p.Orders))
+	for _, seq := range s.routes {
+		for _, o := range seq {
+			assigned[o] = true
+		}
+	}
+	var out []int
+	for o, ok := range assigned {
+		if !ok {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// reason explains why order o could not be planned: the constraint that
+// stopped the vehicle that came closest to serving it on its own, or
+// ReasonFleetFull if some vehicle could serve it alone.
+func (s *solver) reason(o int) Reason {
+	if s.timedOut[o] {
+		return ReasonTimeLimit
+	}
+	furthest := stageOK
+	for v := range s.p.Vehicles {
+		_, stage := s.simulate(v, []int{o})
+		if stage == stageOK {
+			return ReasonFleetFull
+		}
+		if stage > furthest {
+			furthest = stage
+		}
+	}
+	return stageReasons[furthest]
+}
+
+func reverse(seq []int) []int {
+	out := make([]int, len(seq))
+	for i, o := range seq {
+		out[len(seq)-1-i] = o
+	}
+	return out
+}
+
+func (s *solver) solution() *Solution {
+	sol := &Solution{}
+	for v, seq := range s.routes {
+		if len(seq) == 0 {
+			continue
+		}
+		sc, _ := s.simulate(v, seq)
+		r := Route{
+			VehicleID:       s.p.Vehicles[v].ID,
+			Departure:       int64(sc.departure),
+			Finish:          int64(math.Ceil(sc.finish)),
+			DistanceMeters:  sc.distance,
+			DurationSeconds: sc.travel,
+			Load:            sc.load,
+		}
+		for i, o := range seq {
+			ord := &s.p.Orders[o]
+			r.Stops = append(r.Stops, Stop{
+				OrderID:      ord.ID,
+				Arrival:      int64(math.Ceil(sc.arrivals[i])),
+				ServiceStart: int64(math.Ceil(sc.starts[i])),
+				Departure:    int64(math.Ceil(sc.starts[i])) + ord.ServiceSeconds,
+			})
+		}
+		sol.Routes = append(sol.Routes, r)
+		sol.TotalDistanceMeters += sc.distance
+		sol.TotalDurationSeconds += sc.travel
+	}
+	for _, o := range s.unassigned() {
+		sol.Unassigned = append(sol.Unassigned, Unassigned{OrderID: s.p.Orders[o].ID, Reason: s.reason(o)})
+	}
+	return sol
+}
@@ -0,0 +1,193 @@
+package vrp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+)
+
+// randomProblem builds a problem with a mix of open and closed routes,
+// shift limits, time windows and some unreachable pairs, and its cost
+// matrices.
+func randomProblem(rng *rand.Rand, nv, no int) (*Problem, [][]float64, [][]float64) {
+	p := &Problem{}
+	for v := 0; v < nv; v++ {
+		veh := Vehicle{
+			ID:         fmt.Sprintf("v%d", v),
+			Start:      geo.Point{Lat: rng.Float64(), Lon: rng.Float64()},
+			Capacity:   []float64{float64(5 + rng.Intn(10))},
+			ShiftStart: 1000,
+		}
+		if rng.Intn(2) == 0 {
+			end := geo.Point{Lat: rng.Float64(), Lon: rng.Float64()}
+			veh.End = &end
+		}
+		if rng.Intn(2) == 0 {
+			veh.ShiftEnd = 1000 + int64(rng.Intn(8000))
+		}
+		if rng.Intn(3) == 0 {
+			veh.MaxShiftSeconds = int64(1000 + rng.Intn(5000))
+		}
+		p.Vehicles = append(p.Vehicles, veh)
+	}
+	for o := 0; o < no; o++ {
+		ord := Order{
+			ID:             fmt.Sprintf("o%d", o),
+			Location:       geo.Point{Lat: rng.Float64(), Lon: rng.Float64()},
+			Demand:         []float64{float64(rng.Intn(3))},
+			ServiceSeconds: int64(rng.Intn(300)),
+		}
+		if rng.Intn(2) == 0 {
+			ord.WindowStart = 1000 + int64(rng.Intn(4000))
+			ord.WindowEnd = ord.WindowStart + int64(rng.Intn(3000))
+		}
+		p.Orders = append(p.Orders, ord)
+	}
+	points := p.Points()
+	durations := make([][]float64, len(points))
+	distances := make([][]float64, len(points))
+	for i := range points {
+		durations[i] = make([]float64, len(points))
+		distances[i] = make([]float64, len(points))
+		for j := range points {
+			d := geo.Distance(points[i], points[j]) / 40
+			if i != j && rng.Intn(40) == 0 {
+				d = math.Inf(1)
+			}
+			durations[i][j], distances[i][j] = d, 10*d
+		}
+	}
+	return p, durations, distances
+}
+
+func newTestSolver(p *Problem, durations, distances [][]float64) *solver {
+	return &solver{
+		ctx:      context.Background(),
+		p:        p,
+		dur:      durations,
+		dist:     distances,
+		routes:   make([][]int, len(p.Vehicles)),
+		deadline: time.Now().Add(time.Hour),
+		timedOut: make(map[int]bool),
+	}
+}
+
+// TestBestInsertionMatchesSimulation checks the constant-time screen and
+// the latest-start bounds of prepare against simulating every position.
+func TestBestInsertionMatchesSimulation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 300; trial++ {
+		p, durations, distances := randomProblem(rng, 1+rng.Intn(3), 3+rng.Intn(15))
+		s := newTestSolver(p, durations, distances)
+		s.construct()
+
+		for v, seq := range s.routes {
+			inRoute := make(map[int]bool)
+			for _, o := range seq {
+				inRoute[o] = true
+			}
+			base := s.cost(v, seq)
+			for o := range p.Orders {
+				if inRoute[o] {
+					continue
+				}
+				wantPos, wantDelta := -1, math.Inf(1)
+				for pos := 0; pos <= len(seq); pos++ {
+					if c := s.cost(v, insertAt(seq, pos, o)); c-base < wantDelta {
+						wantPos, wantDelta = pos, c-base
+					}
+				}
+				pos, delta := s.bestInsertion(v, o)
+				if math.IsInf(wantDelta, 1) != math.IsInf(delta, 1) ||
+					(!math.IsInf(delta, 1) && math.Abs(wantDelta-delta) > 1e-6) {
+					t.Errorf("trial %d: inserting order %d into vehicle %d: got position %d (+%v), want %d (+%v)",
+						trial, o, v, pos, delta, wantPos, wantDelta)
+				}
+			}
+		}
+	}
+}
+
+func TestSolveRoutesAreFeasible(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for trial := 0; trial < 50; trial++ {
+		p, durations, distances := randomProblem(rng, 1+rng.Intn(4), 5+rng.Intn(30))
+		sol, err := Solve(context.Background(), p, durations, distances, Options{TimeLimit: 20 * time.Millisecond})
+		if err != nil {
+			t.Fatal(err)
+		}
+		planned := len(sol.Unassigned)
+		for _, r := range sol.Routes {
+			planned += len(r.Stops)
+		}
+		if planned != len(p.Orders) {
+			t.Errorf("trial %d: %d orders planned or unassigned, want %d", trial, planned, len(p.Orders))
+		}
+		for _, u := range sol.Unassigned {
+			if u.Reason == ReasonTimeLimit {
+				t.Errorf("trial %d: order %s timed out", trial, u.OrderID)
+			}
+		}
+	}
+}
+
+func TestSolveTimeLimitCoversConstruction(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	p := &Problem{}
+	for v := 0; v < 40; v++ {
+		p.Vehicles = append(p.Vehicles, Vehicle{
+			ID:       fmt.Sprintf("v%d", v),
+			Start:    geo.Point{Lat: rng.Float64(), Lon: rng.Float64()},
+			Capacity: []float64{100},
+			ShiftEnd: 40000,
+		})
+	}
+	for o := 0; o < 900; o++ {
+		p.Orders = append(p.Orders, Order{
+			ID:             fmt.Sprintf("o%d", o),
+			Location:       geo.Point{Lat: rng.Float64(), Lon: rng.Float64()},
+			Demand:         []float64{1},
+			ServiceSeconds: 60,
+		})
+	}
+	points := p.Points()
+	durations := make([][]float64, len(points))
+	for i := range points {
+		durations[i] = make([]float64, len(points))
+		for j := range points {
+			durations[i][j] = geo.Distance(points[i], points[j]) / 40
+		}
+	}
+
+	start := time.Now()
+	sol, err := Solve(context.Background(), p, durations, durations, Options{TimeLimit: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Solve took %v with a 50ms limit", elapsed)
+	}
+	timedOut := 0
+	for _, u := range sol.Unassigned {
+		if u.Reason == ReasonTimeLimit {
+			timedOut++
+		}
+	}
+	if timedOut == 0 {
+		t.Error("no orders left unassigned for time_limit")
+	}
+}
+
+func TestSolveCancelled(t *testing.T) {
+	p, durations, distances := randomProblem(rand.New(rand.NewSource(4)), 2, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Solve(ctx, p, durations, distances, Options{TimeLimit: time.Second}); err != context.Canceled {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
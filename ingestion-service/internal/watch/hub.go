@@ -0,0 +1,292 @@
+// Package watch fans live vehicle positions out to streaming subscribers.
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	"github.com/nexus-logistics/ingestion-service/internal/service"
+)
+
+var (
+	subscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "watch_subscribers",
+		Help: "Open WatchVehicles streams",
+	})
+	droppedUpdates = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "watch_updates_dropped_total",
+		Help: "Updates dropped because a subscriber's buffer was full",
+	})
+	slowDisconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "watch_slow_disconnects_total",
+		Help: "Subscribers disconnected for not keeping up",
+	})
+)
+
+// ErrSlowConsumer ends a subscription whose buffer overflowed under the
+// Disconnect policy.
+var ErrSlowConsumer = errors.New("subscriber is not keeping up with updates")
+
+// ErrOffsetExpired is returned when a subscription resumes from an offset
+// older than the hub retains.
+var ErrOffsetExpired = errors.New("resume offset is no longer retained")
+
+// Policy decides what happens when a subscriber's buffer is full.
+type Policy int
+
+const (
+	// DropOldest discards the oldest buffered update to make room. The
+	// subscriber is told how many were dropped with the next update.
+	DropOldest Policy = iota
+	// Disconnect ends the subscription with ErrSlowConsumer.
+	Disconnect
+)
+
+// ParsePolicy parses "drop" or "disconnect".
+func ParsePolicy(s string) (Policy, error) {
+	switch s {
+	case "drop":
+		return DropOldest, nil
+	case "disconnect":
+		return Disconnect, nil
+	}
+	return 0, fmt.Errorf("unknown slow consumer policy %q", s)
+}
+
+// Update is a ping with its position in the location stream.
+type Update struct {
+	service.PingPayload
+	Partition int32
+	Offset    int64
+	// Dropped is set on delivery to the updates dropped for the subscriber
+	// since the previous one.
+	Dropped int
+	// LeftBounds marks a vehicle that moved out of the subscriber's bounds.
+	LeftBounds bool
+}
+
+// Filter selects the updates a subscriber receives. Unset fields match
+// everything.
+type Filter struct {
+	VehicleIDs map[string]bool
+	Bounds     *geo.BBox
+	Tenant     string
+}
+
+func (f *Filter) matchVehicle(p *service.PingPayload) bool {
+	if f.Tenant != "" && p.Tenant != f.Tenant {
+		return false
+	}
+	return len(f.VehicleIDs) == 0 || f.VehicleIDs[p.VehicleID]
+}
+
+type Config struct {
+	// BufferSize is how many updates a subscriber may fall behind by.
+	BufferSize int
+	// Retain is how many recent updates are kept per partition for
+	// resuming subscribers.
+	Retain int
+	Policy Policy
+}
+
+// Hub delivers published updates to every matching subscriber. It is safe
+// for concurrent use.
+type Hub struct {
+	cfg Config
+
+	mu       sync.Mutex
+	subs     map[*Subscription]struct{}
+	retained map[int32][]Update
+}
+
+func NewHub(cfg Config) *Hub {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = 256
+	}
+	if cfg.Retain <= 0 {
+		cfg.Retain = 10_000
+	}
+	return &Hub{
+		cfg:      cfg,
+		subs:     make(map[*Subscription]struct{}),
+		retained: make(map[int32][]Update),
+	}
+}
+
+// Publish records an update and offers it to every subscriber. Updates for a
+// partition must be published in offset order.
+func (h *Hub) Publish(u Update) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	r := append(h.retained[u.Partition], u)
+	// Trim in halves so retention costs amortised constant time.
+	if len(r) >= 2*h.cfg.Retain {
+		r = append([]Update(nil), r[len(r)-h.cfg.Retain:]...)
+	}
+	h.retained[u.Partition] = r
+	for s := range h.subs {
+		s.offer(u)
+	}
+}
+
+// Subscribe registers a subscriber. resume maps partitions to the last
+// offset the subscriber received; retained updates after them are
+// replayed before live ones.
+func (h *Hub) Subscribe(f Filter, resume map[int32]int64) (*Subscription, error) {
+	s := &Subscription{
+		hub:    h,
+		filter: f,
+		after:  resume,
+		wake:   make(chan struct{}, 1),
+	}
+	if f.Bounds != nil {
+		s.inside = make(map[string]bool)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for partition, offset := range resume {
+		r := h.retained[partition]
+		// A subscriber ahead of this hub, as after reconnecting to another
+		// replica, skips what it has seen as it arrives.
+		if len(r) == 0 || offset >= r[len(r)-1].Offset {
+			continue
+		}
+		if offset < r[0].Offset-1 {
+			return nil, fmt.Errorf("%w: partition %d offset %d, oldest is %d", ErrOffsetExpired, partition, offset, r[0].Offset)
+		}
+		for _, u := range r {
+			if u, ok := s.match(u); ok {
+				s.replay = append(s.replay, u)
+			}
+		}
+	}
+	h.subs[s] = struct{}{}
+	subscribers.Inc()
+	return s, nil
+}
+
+// Subscription is one subscriber's view of the hub. Next must only be
+// called from one goroutine.
+type Subscription struct {
+	hub    *Hub
+	filter Filter
+	after  map[int32]int64
+	// inside tracks which vehicles were last reported within the bounds
+	// so their leaving can be reported once.
+	inside map[string]bool
+	wake   chan struct{}
+
+	// replay holds resumed updates; it is only touched by Subscribe and
+	// Next.
+	replay []Update
+
+	mu      sync.Mutex
+	queue   []Update
+	dropped int
+	err     error
+}
+
+// match applies the filter and resume offsets to u and returns it as the
+// subscriber should see it. It is called with the hub lock held.
+func (s *Subscription) match(u Update) (Update, bool) {
+	if last, ok := s.after[u.Partition]; ok && u.Offset <= last {
+		return u, false
+	}
+	if !s.filter.matchVehicle(&u.PingPayload) {
+		return u, false
+	}
+	if s.filter.Bounds == nil {
+		return u, true
+	}
+	if s.filter.Bounds.Contains(geo.Point{Lat: u.Latitude, Lon: u.Longitude}) {
+		s.inside[u.VehicleID] = true
+		return u, true
+	}
+	if s.inside[u.VehicleID] {
+		delete(s.inside, u.VehicleID)
+		u.LeftBounds = true
+		return u, true
+	}
+	return u, false
+}
+
+func (s *Subscription) offer(u Update) {
+	u, ok := s.match(u)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+	if len(s.queue) >= s.hub.cfg.BufferSize {
+		if s.hub.cfg.Policy == Disconnect {
+			s.err = ErrSlowConsumer
+			s.queue = nil
+			slowDisconnects.Inc()
+			s.signal()
+			return
+		}
+		s.queue = s.queue[1:]
+		s.dropped++
+		droppedUpdates.Inc()
+	}
+	s.queue = append(s.queue, u)
+	s.signal()
+}
+
+func (s *Subscription) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Next blocks until an update is available and returns it, or returns an
+// error once ctx is done or the subscriber was disconnected.
+func (s *Subscription) Next(ctx context.Context) (Update, error) {
+	if len(s.replay) > 0 {
+		u := s.replay[0]
+		s.replay = s.replay[1:]
+		return u, nil
+	}
+	for {
+		s.mu.Lock()
+		if s.err != nil {
+			err := s.err
+			s.mu.Unlock()
+			return Update{}, err
+		}
+		if len(s.queue) > 0 {
+			u := s.queue[0]
+			s.queue = s.queue[1:]
+			u.Dropped, s.dropped = s.dropped, 0
+			s.mu.Unlock()
+			return u, nil
+		}
+		s.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return Update{}, ctx.Err()
+		case <-s.wake:
+		}
+	}
+}
+
+// Close unregisters the subscriber.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	if _, ok := s.hub.subs[s]; ok {
+		delete(s.hub.subs, s)
+		subscribers.Dec()
+	}
+}
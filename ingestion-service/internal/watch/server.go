@@ -0,0 +1,97 @@
+package watch
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/nexus-logistics/ingestion-service/internal/geo"
+	pb "github.com/nexus-logistics/ingestion-service/pb"
+)
+
+const maxVehicleIDs = 1000
+
+// Server implements the VehicleWatchService gRPC API.
+type Server struct {
+	pb.UnimplementedVehicleWatchServiceServer
+	hub *Hub
+}
+
+func NewServer(hub *Hub) *Server {
+	return &Server{hub: hub}
+}
+
+func (s *Server) WatchVehicles(req *pb.WatchVehiclesRequest, stream pb.VehicleWatchService_WatchVehiclesServer) error {
+	f, err := filter(req)
+	if err != nil {
+		return err
+	}
+	var resume map[int32]int64
+	if len(req.GetResumeFrom()) > 0 {
+		resume = make(map[int32]int64, len(req.GetResumeFrom()))
+		for _, po := range req.GetResumeFrom() {
+			resume[po.GetPartition()] = po.GetOffset()
+		}
+	}
+
+	sub, err := s.hub.Subscribe(f, resume)
+	if errors.Is(err, ErrOffsetExpired) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer sub.Close()
+
+	for {
+		u, err := sub.Next(stream.Context())
+		if errors.Is(err, ErrSlowConsumer) {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if err != nil {
+			return status.FromContextError(err).Err()
+		}
+		if err := stream.Send(update(u)); err != nil {
+			return err
+		}
+	}
+}
+
+func filter(req *pb.WatchVehiclesRequest) (Filter, error) {
+	f := Filter{Tenant: req.GetTenant()}
+	if ids := req.GetVehicleIds(); len(ids) > 0 {
+		if len(ids) > maxVehicleIDs {
+			return f, status.Errorf(codes.InvalidArgument, "at most %d vehicle_ids", maxVehicleIDs)
+		}
+		f.VehicleIDs = make(map[string]bool, len(ids))
+		for _, id := range ids {
+			f.VehicleIDs[id] = true
+		}
+	}
+	if b := req.GetBounds(); b != nil {
+		lo := geo.Point{Lat: b.GetMin().GetLatitude(), Lon: b.GetMin().GetLongitude()}
+		hi := geo.Point{Lat: b.GetMax().GetLatitude(), Lon: b.GetMax().GetLongitude()}
+		if b.GetMin() == nil || b.GetMax() == nil || !lo.Valid() || !hi.Valid() {
+			return f, status.Error(codes.InvalidArgument, "bounds need valid min and max coordinates")
+		}
+		if lo.Lat > hi.Lat || lo.Lon > hi.Lon {
+			return f, status.Error(codes.InvalidArgument, "bounds min must be south-west of max")
+		}
+		f.Bounds = &geo.BBox{MinLat: lo.Lat, MinLon: lo.Lon, MaxLat: hi.Lat, MaxLon: hi.Lon}
+	}
+	return f, nil
+}
+
+func update(u Update) *pb.VehicleUpdate {
+	return &pb.VehicleUpdate{
+		VehicleId:  u.VehicleID,
+		Tenant:     u.Tenant,
+		Position:   &pb.LatLng{Latitude: u.Latitude, Longitude: u.Longitude},
+		Timestamp:  u.Timestamp,
+		Attributes: u.Attributes,
+		Offset:     &pb.PartitionOffset{Partition: u.Partition, Offset: u.Offset},
+		Dropped:    uint32(u.Dropped),
+		LeftBounds: u.LeftBounds,
+	}
+}
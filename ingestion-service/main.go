@@ -1,20 +1,30 @@
 package main
 
 import (
-	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/nexus-logistics/ingestion-service/internal/anomaly"
 	"github.com/nexus-logistics/ingestion-service/internal/kafka"
+	"github.com/nexus-logistics/ingestion-service/internal/logging"
+	"github.com/nexus-logistics/ingestion-service/internal/metrics"
 	"github.com/nexus-logistics/ingestion-service/internal/service"
+	"github.com/nexus-logistics/ingestion-service/internal/tenant"
+	"github.com/nexus-logistics/ingestion-service/internal/topics"
 	pb "github.com/nexus-logistics/ingestion-service/pb"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
-    "google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
+	// Logging
+	logLevel := logging.Setup(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+
 	// Configuration
 	kafkaBrokers := os.Getenv("KAFKA_BROKERS")
 	if kafkaBrokers == "" {
@@ -23,38 +33,124 @@ func main() {
 	topic := "vehicle-locations"
 	port := ":50051"
 
+	// KAFKA_PRODUCER_MODE selects the delivery guarantee:
+	//   at-least-once (default) - acks=all with retries; may duplicate or reorder
+	//   idempotent              - no duplicates, per-partition order preserved
+	//   transactional           - idempotent, and SendPingBatch commits atomically
+	producerMode, err := kafka.ParseMode(os.Getenv("KAFKA_PRODUCER_MODE"))
+	if err != nil {
+		slog.Error("Invalid producer mode", "error", err)
+		os.Exit(1)
+	}
+	// The transactional id must be stable per instance and unique across
+	// instances; the hostname is the pod name under Kubernetes.
+	transactionalID := os.Getenv("KAFKA_TRANSACTIONAL_ID")
+	if transactionalID == "" {
+		hostname, _ := os.Hostname()
+		transactionalID = "ingestion-service-" + hostname
+	}
+
+	// Topic routing rules (JSON, see internal/topics). Without a rules file
+	// every ping goes to the default topic.
+	router := topics.StaticRouter(topic)
+	if path := os.Getenv("TOPIC_ROUTES_FILE"); path != "" {
+		router, err = topics.LoadRouter(path)
+		if err != nil {
+			slog.Error("Failed to load topic routes", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Loaded topic routes", "file", path)
+	}
+
+	// Tenants (JSON, see internal/tenant). Without a tenant file callers may
+	// name their tenant in x-tenant-id metadata and are not restricted.
+	tenants := tenant.OpenRegistry()
+	if path := os.Getenv("TENANTS_FILE"); path != "" {
+		tenants, err = tenant.Load(path)
+		if err != nil {
+			slog.Error("Failed to load tenants", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Loaded tenants", "file", path)
+	}
+
+	// Impossible-movement and spoofing checks (JSON, see internal/anomaly).
+	// They are opt-in: without a config file every ping is routed as usual.
+	var anomalies *anomaly.Detector
+	if path := os.Getenv("ANOMALY_CONFIG_FILE"); path != "" {
+		anomalies, err = anomaly.Load(path)
+		if err != nil {
+			slog.Error("Failed to configure anomaly detection", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("Loaded anomaly config", "file", path)
+		go func() {
+			// Forget vehicles that have been silent for a day.
+			for range time.Tick(time.Hour) {
+				anomalies.Evict(time.Now().Add(-24 * time.Hour).Unix())
+			}
+		}()
+	}
+
+	// Per-ping logs are sampled: the first N each second, then every Mth.
+	sampleFirst := envInt("LOG_SAMPLE_FIRST", 10)
+	sampleThereafter := envInt("LOG_SAMPLE_THEREAFTER", 100)
+
 	// Initialize Kafka Producer
-	log.Printf("Connecting to Kafka at %s...", kafkaBrokers)
-	producer, err := kafka.NewProducer(kafkaBrokers, topic)
+	slog.Info("Connecting to Kafka", "brokers", kafkaBrokers, "mode", producerMode)
+	producer, err := kafka.NewProducer(kafka.Config{
+		Brokers:         kafkaBrokers,
+		Topic:           topic,
+		Mode:            producerMode,
+		TransactionalID: transactionalID,
+	})
 	if err != nil {
-		log.Fatalf("Failed to initialize Kafka producer: %v", err)
+		slog.Error("Failed to initialize Kafka producer", "error", err)
+		os.Exit(1)
 	}
 	defer producer.Close()
 
 	// Initialize gRPC Server
 	lis, err := net.Listen("tcp", port)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		slog.Error("Failed to listen", "error", err)
+		os.Exit(1)
 	}
 
-	s := grpc.NewServer()
-	trackerService := service.NewTrackerService(producer)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor(),
+			metrics.UnaryServerInterceptor(),
+			tenant.UnaryServerInterceptor(tenants),
+		),
+	)
+	pingLog := logging.Sampled(slog.Default(), time.Second, sampleFirst, sampleThereafter)
+	trackerService := service.NewTrackerService(producer, router, tenants, anomalies, pingLog)
 	pb.RegisterTrackerServiceServer(s, trackerService)
-    
-    // valid for debugging with grpcurl
-    reflection.Register(s)
+
+	// valid for debugging with grpcurl
+	reflection.Register(s)
 
 	// Start Metrics Server (Prometheus)
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
-		log.Printf("Metrics server listening on :9090")
+		http.Handle("/loglevel", logging.LevelHandler(logLevel))
+		slog.Info("Metrics server listening", "addr", ":9090")
 		if err := http.ListenAndServe(":9090", nil); err != nil {
-			log.Printf("Failed to start metrics server: %v", err)
+			slog.Error("Failed to start metrics server", "error", err)
 		}
 	}()
 
-	log.Printf("Ingestion Service listening on %s", port)
+	slog.Info("Ingestion Service listening", "addr", port)
 	if err := s.Serve(lis); err != nil {
-		log.Fatalf("Failed to serve: %v", err)
+		slog.Error("Failed to serve", "error", err)
+		os.Exit(1)
+	}
+}
+
+func envInt(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
 	}
+	return def
 }
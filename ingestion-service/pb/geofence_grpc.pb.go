@@ -0,0 +1,341 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: geofence.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	GeofenceService_CreateGeofence_FullMethodName       = "/geofence.GeofenceService/CreateGeofence"
+	GeofenceService_UpdateGeofence_FullMethodName       = "/geofence.GeofenceService/UpdateGeofence"
+	GeofenceService_DeleteGeofence_FullMethodName       = "/geofence.GeofenceService/DeleteGeofence"
+	GeofenceService_GetGeofence_FullMethodName          = "/geofence.GeofenceService/GetGeofence"
+	GeofenceService_ListGeofences_FullMethodName        = "/geofence.GeofenceService/ListGeofences"
+	GeofenceService_ListGeofenceVersions_FullMethodName = "/geofence.GeofenceService/ListGeofenceVersions"
+	GeofenceService_ImportGeoJSON_FullMethodName        = "/geofence.GeofenceService/ImportGeoJSON"
+)
+
+// GeofenceServiceClient is the client API for GeofenceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GeofenceServiceClient interface {
+	CreateGeofence(ctx context.Context, in *CreateGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error)
+	// Replaces a geofence. Fails with ABORTED if expected_version is set and
+	// is not the current version.
+	UpdateGeofence(ctx context.Context, in *UpdateGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error)
+	DeleteGeofence(ctx context.Context, in *DeleteGeofenceRequest, opts ...grpc.CallOption) (*DeleteGeofenceResponse, error)
+	GetGeofence(ctx context.Context, in *GetGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error)
+	ListGeofences(ctx context.Context, in *ListGeofencesRequest, opts ...grpc.CallOption) (*ListGeofencesResponse, error)
+	// Returns every stored version of a geofence, newest first.
+	ListGeofenceVersions(ctx context.Context, in *ListGeofenceVersionsRequest, opts ...grpc.CallOption) (*ListGeofencesResponse, error)
+	// Creates or updates one geofence per feature of a GeoJSON
+	// FeatureCollection (see internal/geofence for the expected properties).
+	ImportGeoJSON(ctx context.Context, in *ImportGeoJSONRequest, opts ...grpc.CallOption) (*ImportGeoJSONResponse, error)
+}
+
+type geofenceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGeofenceServiceClient(cc grpc.ClientConnInterface) GeofenceServiceClient {
+	return &geofenceServiceClient{cc}
+}
+
+func (c *geofenceServiceClient) CreateGeofence(ctx context.Context, in *CreateGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error) {
+	out := new(Geofence)
+	err := c.cc.Invoke(ctx, GeofenceService_CreateGeofence_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) UpdateGeofence(ctx context.Context, in *UpdateGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error) {
+	out := new(Geofence)
+	err := c.cc.Invoke(ctx, GeofenceService_UpdateGeofence_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) DeleteGeofence(ctx context.Context, in *DeleteGeofenceRequest, opts ...grpc.CallOption) (*DeleteGeofenceResponse, error) {
+	out := new(DeleteGeofenceResponse)
+	err := c.cc.Invoke(ctx, GeofenceService_DeleteGeofence_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) GetGeofence(ctx context.Context, in *GetGeofenceRequest, opts ...grpc.CallOption) (*Geofence, error) {
+	out := new(Geofence)
+	err := c.cc.Invoke(ctx, GeofenceService_GetGeofence_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) ListGeofences(ctx context.Context, in *ListGeofencesRequest, opts ...grpc.CallOption) (*ListGeofencesResponse, error) {
+	out := new(ListGeofencesResponse)
+	err := c.cc.Invoke(ctx, GeofenceService_ListGeofences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) ListGeofenceVersions(ctx context.Context, in *ListGeofenceVersionsRequest, opts ...grpc.CallOption) (*ListGeofencesResponse, error) {
+	out := new(ListGeofencesResponse)
+	err := c.cc.Invoke(ctx, GeofenceService_ListGeofenceVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geofenceServiceClient) ImportGeoJSON(ctx context.Context, in *ImportGeoJSONRequest, opts ...grpc.CallOption) (*ImportGeoJSONResponse, error) {
+	out := new(ImportGeoJSONResponse)
+	err := c.cc.Invoke(ctx, GeofenceService_ImportGeoJSON_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GeofenceServiceServer is the server API for GeofenceService service.
+// All implementations must embed UnimplementedGeofenceServiceServer
+// for forward compatibility
+type GeofenceServiceServer interface {
+	CreateGeofence(context.Context, *CreateGeofenceRequest) (*Geofence, error)
+	// Replaces a geofence. Fails with ABORTED if expected_version is set and
+	// is not the current version.
+	UpdateGeofence(context.Context, *UpdateGeofenceRequest) (*Geofence, error)
+	DeleteGeofence(context.Context, *DeleteGeofenceRequest) (*DeleteGeofenceResponse, error)
+	GetGeofence(context.Context, *GetGeofenceRequest) (*Geofence, error)
+	ListGeofences(context.Context, *ListGeofencesRequest) (*ListGeofencesResponse, error)
+	// Returns every stored version of a geofence, newest first.
+	ListGeofenceVersions(context.Context, *ListGeofenceVersionsRequest) (*ListGeofencesResponse, error)
+	// Creates or updates one geofence per feature of a GeoJSON
+	// FeatureCollection (see internal/geofence for the expected properties).
+	ImportGeoJSON(context.Context, *ImportGeoJSONRequest) (*ImportGeoJSONResponse, error)
+	mustEmbedUnimplementedGeofenceServiceServer()
+}
+
+// UnimplementedGeofenceServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGeofenceServiceServer struct {
+}
+
+func (UnimplementedGeofenceServiceServer) CreateGeofence(context.Context, *CreateGeofenceRequest) (*Geofence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGeofence not implemented")
+}
+func (UnimplementedGeofenceServiceServer) UpdateGeofence(context.Context, *UpdateGeofenceRequest) (*Geofence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateGeofence not implemented")
+}
+func (UnimplementedGeofenceServiceServer) DeleteGeofence(context.Context, *DeleteGeofenceRequest) (*DeleteGeofenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGeofence not implemented")
+}
+func (UnimplementedGeofenceServiceServer) GetGeofence(context.Context, *GetGeofenceRequest) (*Geofence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGeofence not implemented")
+}
+func (UnimplementedGeofenceServiceServer) ListGeofences(context.Context, *ListGeofencesRequest) (*ListGeofencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGeofences not implemented")
+}
+func (UnimplementedGeofenceServiceServer) ListGeofenceVersions(context.Context, *ListGeofenceVersionsRequest) (*ListGeofencesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGeofenceVersions not implemented")
+}
+func (UnimplementedGeofenceServiceServer) ImportGeoJSON(context.Context, *ImportGeoJSONRequest) (*ImportGeoJSONResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportGeoJSON not implemented")
+}
+func (UnimplementedGeofenceServiceServer) mustEmbedUnimplementedGeofenceServiceServer() {}
+
+// UnsafeGeofenceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GeofenceServiceServer will
+// result in compilation errors.
+type UnsafeGeofenceServiceServer interface {
+	mustEmbedUnimplementedGeofenceServiceServer()
+}
+
+func RegisterGeofenceServiceServer(s grpc.ServiceRegistrar, srv GeofenceServiceServer) {
+	s.RegisterService(&GeofenceService_ServiceDesc, srv)
+}
+
+func _GeofenceService_CreateGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGeofenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).CreateGeofence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeofenceService_CreateGeofence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).CreateGeofence(ctx, req.(*CreateGeofenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_UpdateGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGeofenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).UpdateGeofence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeofenceService_UpdateGeofence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).UpdateGeofence(ctx, req.(*UpdateGeofenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_DeleteGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGeofenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).DeleteGeofence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeofenceService_DeleteGeofence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).DeleteGeofence(ctx, req.(*DeleteGeofenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_GetGeofence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGeofenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).GetGeofence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeofenceService_GetGeofence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).GetGeofence(ctx, req.(*GetGeofenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_ListGeofences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGeofencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).ListGeofences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeofenceService_ListGeofences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).ListGeofences(ctx, req.(*ListGeofencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_ListGeofenceVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGeofenceVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).ListGeofenceVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeofenceService_ListGeofenceVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).ListGeofenceVersions(ctx, req.(*ListGeofenceVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeofenceService_ImportGeoJSON_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportGeoJSONRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeofenceServiceServer).ImportGeoJSON(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeofenceService_ImportGeoJSON_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeofenceServiceServer).ImportGeoJSON(ctx, req.(*ImportGeoJSONRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GeofenceService_ServiceDesc is the grpc.ServiceDesc for GeofenceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GeofenceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "geofence.GeofenceService",
+	HandlerType: (*GeofenceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGeofence",
+			Handler:    _GeofenceService_CreateGeofence_Handler,
+		},
+		{
+			MethodName: "UpdateGeofence",
+			Handler:    _GeofenceService_UpdateGeofence_Handler,
+		},
+		{
+			MethodName: "DeleteGeofence",
+			Handler:    _GeofenceService_DeleteGeofence_Handler,
+		},
+		{
+			MethodName: "GetGeofence",
+			Handler:    _GeofenceService_GetGeofence_Handler,
+		},
+		{
+			MethodName: "ListGeofences",
+			Handler:    _GeofenceService_ListGeofences_Handler,
+		},
+		{
+			MethodName: "ListGeofenceVersions",
+			Handler:    _GeofenceService_ListGeofenceVersions_Handler,
+		},
+		{
+			MethodName: "ImportGeoJSON",
+			Handler:    _GeofenceService_ImportGeoJSON_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "geofence.proto",
+}
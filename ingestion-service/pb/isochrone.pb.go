@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: isochrone.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IsochroneRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Must be within 1 km of a road.
+	Origin *LatLng `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	// Travel times in seconds, at most 10 bands of up to 3 hours each.
+	BandSeconds []int32 `protobuf:"varint,2,rep,packed,name=band_seconds,json=bandSeconds,proto3" json:"band_seconds,omitempty"`
+	// Grid cell size used to trace the outlines. Defaults to 100 meters and
+	// is coarsened for large areas.
+	ResolutionMeters float64 `protobuf:"fixed64,3,opt,name=resolution_meters,json=resolutionMeters,proto3" json:"resolution_meters,omitempty"`
+}
+
+func (x *IsochroneRequest) Reset() {
+	*x = IsochroneRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_isochrone_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsochroneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsochroneRequest) ProtoMessage() {}
+
+func (x *IsochroneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_isochrone_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsochroneRequest.ProtoReflect.Descriptor instead.
+func (*IsochroneRequest) Descriptor() ([]byte, []int) {
+	return file_isochrone_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IsochroneRequest) GetOrigin() *LatLng {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *IsochroneRequest) GetBandSeconds() []int32 {
+	if x != nil {
+		return x.BandSeconds
+	}
+	return nil
+}
+
+func (x *IsochroneRequest) GetResolutionMeters() float64 {
+	if x != nil {
+		return x.ResolutionMeters
+	}
+	return 0
+}
+
+type IsochroneResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A GeoJSON FeatureCollection with one MultiPolygon feature per band,
+	// smallest first, with a "seconds" property. Each band includes the
+	// smaller ones.
+	FeatureCollection []byte `protobuf:"bytes,1,opt,name=feature_collection,json=featureCollection,proto3" json:"feature_collection,omitempty"`
+}
+
+func (x *IsochroneResponse) Reset() {
+	*x = IsochroneResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_isochrone_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsochroneResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsochroneResponse) ProtoMessage() {}
+
+func (x *IsochroneResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_isochrone_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsochroneResponse.ProtoReflect.Descriptor instead.
+func (*IsochroneResponse) Descriptor() ([]byte, []int) {
+	return file_isochrone_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IsochroneResponse) GetFeatureCollection() []byte {
+	if x != nil {
+		return x.FeatureCollection
+	}
+	return nil
+}
+
+var File_isochrone_proto protoreflect.FileDescriptor
+
+var file_isochrone_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x69, 0x73, 0x6f, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x69, 0x73, 0x6f, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x65, 0x1a, 0x09, 0x67, 0x65,
+	0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x87, 0x01, 0x0a, 0x10, 0x49, 0x73, 0x6f, 0x63,
+	0x68, 0x72, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x06,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67,
+	0x65, 0x6f, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x6e, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x62, 0x61, 0x6e, 0x64, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x10, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x22, 0x42, 0x0a, 0x11, 0x49, 0x73, 0x6f, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x11, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x32, 0x64, 0x0a, 0x10, 0x49, 0x73, 0x6f, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x11, 0x43, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x65, 0x49, 0x73, 0x6f, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x1b,
+	0x2e, 0x69, 0x73, 0x6f, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x65, 0x2e, 0x49, 0x73, 0x6f, 0x63, 0x68,
+	0x72, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x73,
+	0x6f, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x65, 0x2e, 0x49, 0x73, 0x6f, 0x63, 0x68, 0x72, 0x6f, 0x6e,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x78, 0x75, 0x73, 0x2d,
+	0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_isochrone_proto_rawDescOnce sync.Once
+	file_isochrone_proto_rawDescData = file_isochrone_proto_rawDesc
+)
+
+func file_isochrone_proto_rawDescGZIP() []byte {
+	file_isochrone_proto_rawDescOnce.Do(func() {
+		file_isochrone_proto_rawDescData = protoimpl.X.CompressGZIP(file_isochrone_proto_rawDescData)
+	})
+	return file_isochrone_proto_rawDescData
+}
+
+var file_isochrone_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_isochrone_proto_goTypes = []interface{}{
+	(*IsochroneRequest)(nil),  // 0: isochrone.IsochroneRequest
+	(*IsochroneResponse)(nil), // 1: isochrone.IsochroneResponse
+	(*LatLng)(nil),            // 2: geo.LatLng
+}
+var file_isochrone_proto_depIdxs = []int32{
+	2, // 0: isochrone.IsochroneRequest.origin:type_name -> geo.LatLng
+	0, // 1: isochrone.IsochroneService.ComputeIsochrones:input_type -> isochrone.IsochroneRequest
+	1, // 2: isochrone.IsochroneService.ComputeIsochrones:output_type -> isochrone.IsochroneResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_isochrone_proto_init() }
+func file_isochrone_proto_init() {
+	if File_isochrone_proto != nil {
+		return
+	}
+	file_geo_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_isochrone_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsochroneRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_isochrone_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsochroneResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_isochrone_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_isochrone_proto_goTypes,
+		DependencyIndexes: file_isochrone_proto_depIdxs,
+		MessageInfos:      file_isochrone_proto_msgTypes,
+	}.Build()
+	File_isochrone_proto = out.File
+	file_isochrone_proto_rawDesc = nil
+	file_isochrone_proto_goTypes = nil
+	file_isochrone_proto_depIdxs = nil
+}
@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: isochrone.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	IsochroneService_ComputeIsochrones_FullMethodName = "/isochrone.IsochroneService/ComputeIsochrones"
+)
+
+// IsochroneServiceClient is the client API for IsochroneService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IsochroneServiceClient interface {
+	ComputeIsochrones(ctx context.Context, in *IsochroneRequest, opts ...grpc.CallOption) (*IsochroneResponse, error)
+}
+
+type isochroneServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIsochroneServiceClient(cc grpc.ClientConnInterface) IsochroneServiceClient {
+	return &isochroneServiceClient{cc}
+}
+
+func (c *isochroneServiceClient) ComputeIsochrones(ctx context.Context, in *IsochroneRequest, opts ...grpc.CallOption) (*IsochroneResponse, error) {
+	out := new(IsochroneResponse)
+	err := c.cc.Invoke(ctx, IsochroneService_ComputeIsochrones_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IsochroneServiceServer is the server API for IsochroneService service.
+// All implementations must embed UnimplementedIsochroneServiceServer
+// for forward compatibility
+type IsochroneServiceServer interface {
+	ComputeIsochrones(context.Context, *IsochroneRequest) (*IsochroneResponse, error)
+	mustEmbedUnimplementedIsochroneServiceServer()
+}
+
+// UnimplementedIsochroneServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedIsochroneServiceServer struct {
+}
+
+func (UnimplementedIsochroneServiceServer) ComputeIsochrones(context.Context, *IsochroneRequest) (*IsochroneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComputeIsochrones not implemented")
+}
+func (UnimplementedIsochroneServiceServer) mustEmbedUnimplementedIsochroneServiceServer() {}
+
+// UnsafeIsochroneServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IsochroneServiceServer will
+// result in compilation errors.
+type UnsafeIsochroneServiceServer interface {
+	mustEmbedUnimplementedIsochroneServiceServer()
+}
+
+func RegisterIsochroneServiceServer(s grpc.ServiceRegistrar, srv IsochroneServiceServer) {
+	s.RegisterService(&IsochroneService_ServiceDesc, srv)
+}
+
+func _IsochroneService_ComputeIsochrones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsochroneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IsochroneServiceServer).ComputeIsochrones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IsochroneService_ComputeIsochrones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IsochroneServiceServer).ComputeIsochrones(ctx, req.(*IsochroneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IsochroneService_ServiceDesc is the grpc.ServiceDesc for IsochroneService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IsochroneService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "isochrone.IsochroneService",
+	HandlerType: (*IsochroneServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ComputeIsochrones",
+			Handler:    _IsochroneService_ComputeIsochrones_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "isochrone.proto",
+}
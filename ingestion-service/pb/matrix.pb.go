@@ -0,0 +1,350 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: matrix.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MatrixRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sources []*LatLng `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	// Defaults to the sources.
+	Targets []*LatLng `protobuf:"bytes,2,rep,name=targets,proto3" json:"targets,omitempty"`
+	// Speed profile for estimated cells: "car" (default), "van", "truck",
+	// "bicycle" or "foot".
+	Profile string `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *MatrixRequest) Reset() {
+	*x = MatrixRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_matrix_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatrixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatrixRequest) ProtoMessage() {}
+
+func (x *MatrixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatrixRequest.ProtoReflect.Descriptor instead.
+func (*MatrixRequest) Descriptor() ([]byte, []int) {
+	return file_matrix_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MatrixRequest) GetSources() []*LatLng {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *MatrixRequest) GetTargets() []*LatLng {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *MatrixRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+type MatrixResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One row per source, in request order.
+	Rows []*MatrixRow `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *MatrixResponse) Reset() {
+	*x = MatrixResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_matrix_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatrixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatrixResponse) ProtoMessage() {}
+
+func (x *MatrixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatrixResponse.ProtoReflect.Descriptor instead.
+func (*MatrixResponse) Descriptor() ([]byte, []int) {
+	return file_matrix_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MatrixResponse) GetRows() []*MatrixRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type MatrixRow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceIndex int32 `protobuf:"varint,1,opt,name=source_index,json=sourceIndex,proto3" json:"source_index,omitempty"`
+	// One entry per target, in request order.
+	DurationsSeconds []float64 `protobuf:"fixed64,2,rep,packed,name=durations_seconds,json=durationsSeconds,proto3" json:"durations_seconds,omitempty"`
+	DistancesMeters  []float64 `protobuf:"fixed64,3,rep,packed,name=distances_meters,json=distancesMeters,proto3" json:"distances_meters,omitempty"`
+	// Set for cells that could not be routed over the road graph, because an
+	// end is too far from any road or no path exists. Their costs are
+	// estimated from the straight-line distance and the speed profile.
+	Estimated []bool `protobuf:"varint,4,rep,packed,name=estimated,proto3" json:"estimated,omitempty"`
+}
+
+func (x *MatrixRow) Reset() {
+	*x = MatrixRow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_matrix_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatrixRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatrixRow) ProtoMessage() {}
+
+func (x *MatrixRow) ProtoReflect() protoreflect.Message {
+	mi := &file_matrix_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatrixRow.ProtoReflect.Descriptor instead.
+func (*MatrixRow) Descriptor() ([]byte, []int) {
+	return file_matrix_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MatrixRow) GetSourceIndex() int32 {
+	if x != nil {
+		return x.SourceIndex
+	}
+	return 0
+}
+
+func (x *MatrixRow) GetDurationsSeconds() []float64 {
+	if x != nil {
+		return x.DurationsSeconds
+	}
+	return nil
+}
+
+func (x *MatrixRow) GetDistancesMeters() []float64 {
+	if x != nil {
+		return x.DistancesMeters
+	}
+	return nil
+}
+
+func (x *MatrixRow) GetEstimated() []bool {
+	if x != nil {
+		return x.Estimated
+	}
+	return nil
+}
+
+var File_matrix_proto protoreflect.FileDescriptor
+
+var file_matrix_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x1a, 0x09, 0x67, 0x65, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x77, 0x0a, 0x0d, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x25, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x65, 0x6f, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67,
+	0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x07, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x65, 0x6f,
+	0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x37, 0x0a, 0x0e, 0x4d, 0x61,
+	0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04,
+	0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x61, 0x74,
+	0x72, 0x69, 0x78, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72,
+	0x6f, 0x77, 0x73, 0x22, 0xa4, 0x01, 0x0a, 0x09, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x6f,
+	0x77, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x5f, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0f, 0x64, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x08, 0x52,
+	0x09, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x32, 0x8f, 0x01, 0x0a, 0x0d, 0x4d,
+	0x61, 0x74, 0x72, 0x69, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x40, 0x0a, 0x0d,
+	0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x12, 0x15, 0x2e,
+	0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x2e, 0x4d, 0x61,
+	0x74, 0x72, 0x69, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c,
+	0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x12, 0x15,
+	0x2e, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x2e, 0x4d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x6d, 0x61, 0x74, 0x72, 0x69, 0x78, 0x2e, 0x4d,
+	0x61, 0x74, 0x72, 0x69, 0x78, 0x52, 0x6f, 0x77, 0x22, 0x00, 0x30, 0x01, 0x42, 0x31, 0x5a, 0x2f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x78, 0x75, 0x73,
+	0x2d, 0x6c, 0x6f, 0x67, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2f, 0x69, 0x6e, 0x67, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_matrix_proto_rawDescOnce sync.Once
+	file_matrix_proto_rawDescData = file_matrix_proto_rawDesc
+)
+
+func file_matrix_proto_rawDescGZIP() []byte {
+	file_matrix_proto_rawDescOnce.Do(func() {
+		file_matrix_proto_rawDescData = protoimpl.X.CompressGZIP(file_matrix_proto_rawDescData)
+	})
+	return file_matrix_proto_rawDescData
+}
+
+var file_matrix_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_matrix_proto_goTypes = []interface{}{
+	(*MatrixRequest)(nil),  // 0: matrix.MatrixRequest
+	(*MatrixResponse)(nil), // 1: matrix.MatrixResponse
+	(*MatrixRow)(nil),      // 2: matrix.MatrixRow
+	(*LatLng)(nil),         // 3: geo.LatLng
+}
+var file_matrix_proto_depIdxs = []int32{
+	3, // 0: matrix.MatrixRequest.sources:type_name -> geo.LatLng
+	3, // 1: matrix.MatrixRequest.targets:type_name -> geo.LatLng
+	2, // 2: matrix.MatrixResponse.rows:type_name -> matrix.MatrixRow
+	0, // 3: matrix.MatrixService.ComputeMatrix:input_type -> matrix.MatrixRequest
+	0, // 4: matrix.MatrixService.StreamMatrix:input_type -> matrix.MatrixRequest
+	1, // 5: matrix.MatrixService.ComputeMatrix:output_type -> matrix.MatrixResponse
+	2, // 6: matrix.MatrixService.StreamMatrix:output_type -> matrix.MatrixRow
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_matrix_proto_init() }
+func file_matrix_proto_init() {
+	if File_matrix_proto != nil {
+		return
+	}
+	file_geo_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_matrix_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatrixRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_matrix_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatrixResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_matrix_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatrixRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_matrix_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_matrix_proto_goTypes,
+		DependencyIndexes: file_matrix_proto_depIdxs,
+		MessageInfos:      file_matrix_proto_msgTypes,
+	}.Build()
+	File_matrix_proto = out.File
+	file_matrix_proto_rawDesc = nil
+	file_matrix_proto_goTypes = nil
+	file_matrix_proto_depIdxs = nil
+}
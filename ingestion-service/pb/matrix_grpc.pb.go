@@ -0,0 +1,182 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: matrix.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MatrixService_ComputeMatrix_FullMethodName = "/matrix.MatrixService/ComputeMatrix"
+	MatrixService_StreamMatrix_FullMethodName  = "/matrix.MatrixService/StreamMatrix"
+)
+
+// MatrixServiceClient is the client API for MatrixService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MatrixServiceClient interface {
+	// Returns the whole matrix at once. Limited to 10,000 cells; use
+	// StreamMatrix for larger requests.
+	ComputeMatrix(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error)
+	// Streams the matrix one source row at a time, in completion order.
+	// Limited to 1,000,000 cells.
+	StreamMatrix(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (MatrixService_StreamMatrixClient, error)
+}
+
+type matrixServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatrixServiceClient(cc grpc.ClientConnInterface) MatrixServiceClient {
+	return &matrixServiceClient{cc}
+}
+
+func (c *matrixServiceClient) ComputeMatrix(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error) {
+	out := new(MatrixResponse)
+	err := c.cc.Invoke(ctx, MatrixService_ComputeMatrix_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matrixServiceClient) StreamMatrix(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (MatrixService_StreamMatrixClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MatrixService_ServiceDesc.Streams[0], MatrixService_StreamMatrix_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &matrixServiceStreamMatrixClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MatrixService_StreamMatrixClient interface {
+	Recv() (*MatrixRow, error)
+	grpc.ClientStream
+}
+
+type matrixServiceStreamMatrixClient struct {
+	grpc.ClientStream
+}
+
+func (x *matrixServiceStreamMatrixClient) Recv() (*MatrixRow, error) {
+	m := new(MatrixRow)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MatrixServiceServer is the server API for MatrixService service.
+// All implementations must embed UnimplementedMatrixServiceServer
+// for forward compatibility
+type MatrixServiceServer interface {
+	// Returns the whole matrix at once. Limited to 10,000 cells; use
+	// StreamMatrix for larger requests.
+	ComputeMatrix(context.Context, *MatrixRequest) (*MatrixResponse, error)
+	// Streams the matrix one source row at a time, in completion order.
+	// Limited to 1,000,000 cells.
+	StreamMatrix(*MatrixRequest, MatrixService_StreamMatrixServer) error
+	mustEmbedUnimplementedMatrixServiceServer()
+}
+
+// UnimplementedMatrixServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMatrixServiceServer struct {
+}
+
+func (UnimplementedMatrixServiceServer) ComputeMatrix(context.Context, *MatrixRequest) (*MatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ComputeMatrix not implemented")
+}
+func (UnimplementedMatrixServiceServer) StreamMatrix(*MatrixRequest, MatrixService_StreamMatrixServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMatrix not implemented")
+}
+func (UnimplementedMatrixServiceServer) mustEmbedUnimplementedMatrixServiceServer() {}
+
+// UnsafeMatrixServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatrixServiceServer will
+// result in compilation errors.
+type UnsafeMatrixServiceServer interface {
+	mustEmbedUnimplementedMatrixServiceServer()
+}
+
+func RegisterMatrixServiceServer(s grpc.ServiceRegistrar, srv MatrixServiceServer) {
+	s.RegisterService(&MatrixService_ServiceDesc, srv)
+}
+
+func _MatrixService_ComputeMatrix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatrixServiceServer).ComputeMatrix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatrixService_ComputeMatrix_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatrixServiceServer).ComputeMatrix(ctx, req.(*MatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatrixService_StreamMatrix_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MatrixRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatrixServiceServer).StreamMatrix(m, &matrixServiceStreamMatrixServer{stream})
+}
+
+type MatrixService_StreamMatrixServer interface {
+	Send(*MatrixRow) error
+	grpc.ServerStream
+}
+
+type matrixServiceStreamMatrixServer struct {
+	grpc.ServerStream
+}
+
+func (x *matrixServiceStreamMatrixServer) Send(m *MatrixRow) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// MatrixService_ServiceDesc is the grpc.ServiceDesc for MatrixService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MatrixService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "matrix.MatrixService",
+	HandlerType: (*MatrixServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ComputeMatrix",
+			Handler:    _MatrixService_ComputeMatrix_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMatrix",
+			Handler:       _MatrixService_StreamMatrix_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "matrix.proto",
+}
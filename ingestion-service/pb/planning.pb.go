@@ -0,0 +1,818 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: planning.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Vehicle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start *LatLng `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// Where the route must end; unset leaves the route open at its last stop.
+	End *LatLng `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// Capacity per load dimension; dimensions not listed are unlimited.
+	Capacity   []float64 `protobuf:"fixed64,4,rep,packed,name=capacity,proto3" json:"capacity,omitempty"`
+	ShiftStart int64     `protobuf:"varint,5,opt,name=shift_start,json=shiftStart,proto3" json:"shift_start,omitempty"` // Unix timestamp
+	ShiftEnd   int64     `protobuf:"varint,6,opt,name=shift_end,json=shiftEnd,proto3" json:"shift_end,omitempty"`       // Unix timestamp, 0 for no limit
+	// Maximum time from departure to the end of the route, 0 for no limit.
+	MaxShiftSeconds int64 `protobuf:"varint,7,opt,name=max_shift_seconds,json=maxShiftSeconds,proto3" json:"max_shift_seconds,omitempty"`
+}
+
+func (x *Vehicle) Reset() {
+	*x = Vehicle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_planning_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vehicle) ProtoMessage() {}
+
+func (x *Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_planning_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vehicle.ProtoReflect.Descriptor instead.
+func (*Vehicle) Descriptor() ([]byte, []int) {
+	return file_planning_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vehicle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vehicle) GetStart() *LatLng {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *Vehicle) GetEnd() *LatLng {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *Vehicle) GetCapacity() []float64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return nil
+}
+
+func (x *Vehicle) GetShiftStart() int64 {
+	if x != nil {
+		return x.ShiftStart
+	}
+	return 0
+}
+
+func (x *Vehicle) GetShiftEnd() int64 {
+	if x != nil {
+		return x.ShiftEnd
+	}
+	return 0
+}
+
+func (x *Vehicle) GetMaxShiftSeconds() int64 {
+	if x != nil {
+		return x.MaxShiftSeconds
+	}
+	return 0
+}
+
+type Order struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Location       *LatLng   `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Demand         []float64 `protobuf:"fixed64,3,rep,packed,name=demand,proto3" json:"demand,omitempty"`
+	ServiceSeconds int64     `protobuf:"varint,4,opt,name=service_seconds,json=serviceSeconds,proto3" json:"service_seconds,omitempty"`
+	// Bounds on when service may start (Unix timestamps); 0 leaves a side open.
+	TimeWindowStart int64 `protobuf:"varint,5,opt,name=time_window_start,json=timeWindowStart,proto3" json:"time_window_start,omitempty"`
+	TimeWindowEnd   int64 `protobuf:"varint,6,opt,name=time_window_end,json=timeWindowEnd,proto3" json:"time_window_end,omitempty"`
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_planning_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_planning_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_planning_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetLocation() *LatLng {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Order) GetDemand() []float64 {
+	if x != nil {
+		return x.Demand
+	}
+	return nil
+}
+
+func (x *Order) GetServiceSeconds() int64 {
+	if x != nil {
+		return x.ServiceSeconds
+	}
+	return 0
+}
+
+func (x *Order) GetTimeWindowStart() int64 {
+	if x != nil {
+		return x.TimeWindowStart
+	}
+	return 0
+}
+
+func (x *Order) GetTimeWindowEnd() int64 {
+	if x != nil {
+		return x.TimeWindowEnd
+	}
+	return 0
+}
+
+type PlanRoutesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vehicles []*Vehicle `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	Orders   []*Order   `protobuf:"bytes,2,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Time allowed for planning, including computing travel costs; defaults
+	// to 5 seconds. Orders not placed in time are returned as time_limit.
+	TimeLimitMs int32 `protobuf:"varint,3,opt,name=time_limit_ms,json=timeLimitMs,proto3" json:"time_limit_ms,omitempty"`
+}
+
+func (x *PlanRoutesRequest) Reset() {
+	*x = PlanRoutesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_planning_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanRoutesRequest) ProtoMessage() {}
+
+func (x *PlanRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_planning_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanRoutesRequest.ProtoReflect.Descriptor instead.
+func (*PlanRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_planning_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlanRoutesRequest) GetVehicles() []*Vehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *PlanRoutesRequest) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *PlanRoutesRequest) GetTimeLimitMs() int32 {
+	if x != nil {
+		return x.TimeLimitMs
+	}
+	return 0
+}
+
+type PlannedStop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId       string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ArrivalTime   int64  `protobuf:"varint,2,opt,name=arrival_time,json=arrivalTime,proto3" json:"arrival_time,omitempty"`
+	ServiceStart  int64  `protobuf:"varint,3,opt,name=service_start,json=serviceStart,proto3" json:"service_start,omitempty"`
+	DepartureTime int64  `protobuf:"varint,4,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
+}
+
+func (x *PlannedStop) Reset() {
+	*x = PlannedStop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_planning_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlannedStop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlannedStop) ProtoMessage() {}
+
+func (x *PlannedStop) ProtoReflect() protoreflect.Message {
+	mi := &file_planning_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlannedStop.ProtoReflect.Descriptor instead.
+func (*PlannedStop) Descriptor() ([]byte, []int) {
+	return file_planning_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlannedStop) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PlannedStop) GetArrivalTime() int64 {
+	if x != nil {
+		return x.ArrivalTime
+	}
+	return 0
+}
+
+func (x *PlannedStop) GetServiceStart() int64 {
+	if x != nil {
+		return x.ServiceStart
+	}
+	return 0
+}
+
+func (x *PlannedStop) GetDepartureTime() int64 {
+	if x != nil {
+		return x.DepartureTime
+	}
+	return 0
+}
+
+type PlannedRoute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VehicleId      string         `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	Stops          []*PlannedStop `protobuf:"bytes,2,rep,name=stops,proto3" json:"stops,omitempty"`
+	DepartureTime  int64          `protobuf:"varint,3,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
+	FinishTime     int64          `protobuf:"varint,4,opt,name=finish_time,json=finishTime,proto3" json:"finish_time,omitempty"`
+	DistanceMeters float64        `protobuf:"fixed64,5,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	DrivingSeconds float64        `protobuf:"fixed64,6,opt,name=driving_seconds,json=drivingSeconds,proto3" json:"driving_seconds,omitempty"`
+	Load           []float64      `protobuf:"fixed64,7,rep,packed,name=load,proto3" json:"load,omitempty"`
+}
+
+func (x *PlannedRoute) Reset() {
+	*x = PlannedRoute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_planning_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlannedRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlannedRoute) ProtoMessage() {}
+
+func (x *PlannedRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_planning_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlannedRoute.ProtoReflect.Descriptor instead.
+func (*PlannedRoute) Descriptor() ([]byte, []int) {
+	return file_planning_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PlannedRoute) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *PlannedRoute) GetStops() []*PlannedStop {
+	if x != nil {
+		return x.Stops
+	}
+	return nil
+}
+
+func (x *PlannedRoute) GetDepartureTime() int64 {
+	if x != nil {
+		return x.DepartureTime
+	}
+	return 0
+}
+
+func (x *PlannedRoute) GetFinishTime() int64 {
+	if x != nil {
+		return x.FinishTime
+	}
+	return 0
+}
+
+func (x *PlannedRoute) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *PlannedRoute) GetDrivingSeconds() float64 {
+	if x != nil {
+		return x.DrivingSeconds
+	}
+	return 0
+}
+
+func (x *PlannedRoute) GetLoad() []float64 {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+type UnassignedOrder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// unreachable, capacity, time_window, shift_limit, fleet_full or time_limit
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *UnassignedOrder) Reset() {
+	*x = UnassignedOrder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_planning_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnassignedOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignedOrder) ProtoMessage() {}
+
+func (x *UnassignedOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_planning_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignedOrder.ProtoReflect.Descriptor instead.
+func (*UnassignedOrder) Descriptor() ([]byte, []int) {
+	return file_planning_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UnassignedOrder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UnassignedOrder) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PlanRoutesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes              []*PlannedRoute    `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+	Unassigned          []*UnassignedOrder `protobuf:"bytes,2,rep,name=unassigned,proto3" json:"unassigned,omitempty"`
+	TotalDistanceMeters float64            `protobuf:"fixed64,3,opt,name=total_distance_meters,json=totalDistanceMeters,proto3" json:"total_distance_meters,omitempty"`
+	TotalDrivingSeconds float64            `protobuf:"fixed64,4,opt,name=total_driving_seconds,json=totalDrivingSeconds,proto3" json:"total_driving_seconds,omitempty"`
+}
+
+func (x *PlanRoutesResponse) Reset() {
+	*x = PlanRoutesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_planning_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanRoutesResponse) ProtoMessage() {}
+
+func (x *PlanRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_planning_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanRoutesResponse.ProtoReflect.Descriptor instead.
+func (*PlanRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_planning_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PlanRoutesResponse) GetRoutes() []*PlannedRoute {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+func (x *PlanRoutesResponse) GetUnassigned() []*UnassignedOrder {
+	if x != nil {
+		return x.Unassigned
+	}
+	return nil
+}
+
+func (x *PlanRoutesResponse) GetTotalDistanceMeters() float64 {
+	if x != nil {
+		return x.TotalDistanceMeters
+	}
+	return 0
+}
+
+func (x *PlanRoutesResponse) GetTotalDrivingSeconds() float64 {
+	if x != nil {
+		return x.TotalDrivingSeconds
+	}
+	return 0
+}
+
+var File_planning_proto protoreflect.FileDescriptor
+
+var file_planning_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x08, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x1a, 0x09, 0x67, 0x65, 0x6f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe1, 0x01, 0x0a, 0x07, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x21, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x67, 0x65, 0x6f, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x65, 0x6f, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x03,
+	0x65, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12,
+	0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x69, 0x66, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x68, 0x69, 0x66, 0x74, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x68, 0x69, 0x66, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x68, 0x69, 0x66, 0x74, 0x45, 0x6e, 0x64, 0x12, 0x2a, 0x0a,
+	0x11, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x68, 0x69, 0x66, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x53, 0x68, 0x69,
+	0x66, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xd5, 0x01, 0x0a, 0x05, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x67, 0x65, 0x6f, 0x2e, 0x4c, 0x61, 0x74, 0x4c,
+	0x6e, 0x67, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x65, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x64, 0x65,
+	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a,
+	0x11, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x69, 0x6d,
+	0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x45, 0x6e,
+	0x64, 0x22, 0x8f, 0x01, 0x0a, 0x11, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x08, 0x76, 0x65, 0x68, 0x69, 0x63,
+	0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x6c, 0x61, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x56, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x52, 0x08, 0x76, 0x65,
+	0x68, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x22, 0x0a, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x6d, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x4d, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x53,
+	0x74, 0x6f, 0x70, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x61, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x61, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x70, 0x61, 0x72, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x64, 0x65, 0x70, 0x61, 0x72, 0x74, 0x75, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x88, 0x02,
+	0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x76, 0x65, 0x68, 0x69, 0x63, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x2b, 0x0a,
+	0x05, 0x73, 0x74, 0x6f, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70,
+	0x6c, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x53,
+	0x74, 0x6f, 0x70, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x70, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65,
+	0x70, 0x61, 0x72, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x64, 0x65, 0x70, 0x61, 0x72, 0x74, 0x75, 0x72, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x64, 0x69, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64,
+	0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x64, 0x72, 0x69, 0x76, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x44, 0x0a, 0x0f, 0x55, 0x6e, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xe7,
+	0x01, 0x0a, 0x12, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x06, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x6c, 0x61, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x52, 0x0a, 0x75, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x12, 0x32, 0x0a, 0x15, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x64, 0x72,
+	0x69, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x72, 0x69, 0x76, 0x69, 0x6e,
+	0x67, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x32, 0x5c, 0x0a, 0x0f, 0x50, 0x6c, 0x61, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x50,
+	0x6c, 0x61, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x6c, 0x61, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x6c, 0x61, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x78, 0x75, 0x73, 0x2d, 0x6c, 0x6f, 0x67, 0x69, 0x73,
+	0x74, 0x69, 0x63, 0x73, 0x2f, 0x69, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x2d, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_planning_proto_rawDescOnce sync.Once
+	file_planning_proto_rawDescData = file_planning_proto_rawDesc
+)
+
+func file_planning_proto_rawDescGZIP() []byte {
+	file_planning_proto_rawDescOnce.Do(func() {
+		file_planning_proto_rawDescData = protoimpl.X.CompressGZIP(file_planning_proto_rawDescData)
+	})
+	return file_planning_proto_rawDescData
+}
+
+var file_planning_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_planning_proto_goTypes = []interface{}{
+	(*Vehicle)(nil),            // 0: planning.Vehicle
+	(*Order)(nil),              // 1: planning.Order
+	(*PlanRoutesRequest)(nil),  // 2: planning.PlanRoutesRequest
+	(*PlannedStop)(nil),        // 3: planning.PlannedStop
+	(*PlannedRoute)(nil),       // 4: planning.PlannedRoute
+	(*UnassignedOrder)(nil),    // 5: planning.UnassignedOrder
+	(*PlanRoutesResponse)(nil), // 6: planning.PlanRoutesResponse
+	(*LatLng)(nil),             // 7: geo.LatLng
+}
+var file_planning_proto_depIdxs = []int32{
+	7, // 0: planning.Vehicle.start:type_name -> geo.LatLng
+	7, // 1: planning.Vehicle.end:type_name -> geo.LatLng
+	7, // 2: planning.Order.location:type_name -> geo.LatLng
+	0, // 3: planning.PlanRoutesRequest.vehicles:type_name -> planning.Vehicle
+	1, // 4: planning.PlanRoutesRequest.orders:type_name -> planning.Order
+	3, // 5: planning.PlannedRoute.stops:type_name -> planning.PlannedStop
+	4, // 6: planning.PlanRoutesResponse.routes:type_name -> planning.PlannedRoute
+	5, // 7: planning.PlanRoutesResponse.unassigned:type_name -> planning.UnassignedOrder
+	2, // 8: planning.PlanningService.PlanRoutes:input_type -> planning.PlanRoutesRequest
+	6, // 9: planning.PlanningService.PlanRoutes:output_type -> planning.PlanRoutesResponse
+	9, // [9:10] is the sub-list for method output_type
+	8, // [8:9] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_planning_proto_init() }
+func file_planning_proto_init() {
+	if File_planning_proto != nil {
+		return
+	}
+	file_geo_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_planning_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Vehicle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_planning_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Order); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_planning_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanRoutesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_planning_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlannedStop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_planning_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlannedRoute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_planning_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnassignedOrder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_planning_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanRoutesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_planning_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_planning_proto_goTypes,
+		DependencyIndexes: file_planning_proto_depIdxs,
+		MessageInfos:      file_planning_proto_msgTypes,
+	}.Build()
+	File_planning_proto = out.File
+	file_planning_proto_rawDesc = nil
+	file_planning_proto_goTypes = nil
+	file_planning_proto_depIdxs = nil
+}
@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: planning.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PlanningService_PlanRoutes_FullMethodName = "/planning.PlanningService/PlanRoutes"
+)
+
+// PlanningServiceClient is the client API for PlanningService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PlanningServiceClient interface {
+	// Assigns orders to vehicles and sequences their stops, minimizing total
+	// driving time. Orders that cannot be planned are returned with a reason.
+	PlanRoutes(ctx context.Context, in *PlanRoutesRequest, opts ...grpc.CallOption) (*PlanRoutesResponse, error)
+}
+
+type planningServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPlanningServiceClient(cc grpc.ClientConnInterface) PlanningServiceClient {
+	return &planningServiceClient{cc}
+}
+
+func (c *planningServiceClient) PlanRoutes(ctx context.Context, in *PlanRoutesRequest, opts ...grpc.CallOption) (*PlanRoutesResponse, error) {
+	out := new(PlanRoutesResponse)
+	err := c.cc.Invoke(ctx, PlanningService_PlanRoutes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlanningServiceServer is the server API for PlanningService service.
+// All implementations must embed UnimplementedPlanningServiceServer
+// for forward compatibility
+type PlanningServiceServer interface {
+	// Assigns orders to vehicles and sequences their stops, minimizing total
+	// driving time. Orders that cannot be planned are returned with a reason.
+	PlanRoutes(context.Context, *PlanRoutesRequest) (*PlanRoutesResponse, error)
+	mustEmbedUnimplementedPlanningServiceServer()
+}
+
+// UnimplementedPlanningServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPlanningServiceServer struct {
+}
+
+func (UnimplementedPlanningServiceServer) PlanRoutes(context.Context, *PlanRoutesRequest) (*PlanRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanRoutes not implemented")
+}
+func (UnimplementedPlanningServiceServer) mustEmbedUnimplementedPlanningServiceServer() {}
+
+// UnsafePlanningServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlanningServiceServer will
+// result in compilation errors.
+type UnsafePlanningServiceServer interface {
+	mustEmbedUnimplementedPlanningServiceServer()
+}
+
+func RegisterPlanningServiceServer(s grpc.ServiceRegistrar, srv PlanningServiceServer) {
+	s.RegisterService(&PlanningService_ServiceDesc, srv)
+}
+
+func _PlanningService_PlanRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlanningServiceServer).PlanRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlanningService_PlanRoutes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlanningServiceServer).PlanRoutes(ctx, req.(*PlanRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PlanningService_ServiceDesc is the grpc.ServiceDesc for PlanningService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PlanningService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "planning.PlanningService",
+	HandlerType: (*PlanningServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlanRoutes",
+			Handler:    _PlanningService_PlanRoutes_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "planning.proto",
+}
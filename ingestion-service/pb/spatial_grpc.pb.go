@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: spatial.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SpatialIndexService_FindNearest_FullMethodName       = "/spatial.SpatialIndexService/FindNearest"
+	SpatialIndexService_FindWithinRadius_FullMethodName  = "/spatial.SpatialIndexService/FindWithinRadius"
+	SpatialIndexService_FindInBoundingBox_FullMethodName = "/spatial.SpatialIndexService/FindInBoundingBox"
+)
+
+// SpatialIndexServiceClient is the client API for SpatialIndexService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SpatialIndexServiceClient interface {
+	// Returns the k vehicles nearest to a point, nearest first.
+	FindNearest(ctx context.Context, in *FindNearestRequest, opts ...grpc.CallOption) (*FindVehiclesResponse, error)
+	// Returns vehicles within a radius of a point, nearest first.
+	FindWithinRadius(ctx context.Context, in *FindWithinRadiusRequest, opts ...grpc.CallOption) (*FindVehiclesResponse, error)
+	// Returns vehicles inside a bounding box, ordered by vehicle ID.
+	FindInBoundingBox(ctx context.Context, in *FindInBoundingBoxRequest, opts ...grpc.CallOption) (*FindVehiclesResponse, error)
+}
+
+type spatialIndexServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpatialIndexServiceClient(cc grpc.ClientConnInterface) SpatialIndexServiceClient {
+	return &spatialIndexServiceClient{cc}
+}
+
+func (c *spatialIndexServiceClient) FindNearest(ctx context.Context, in *FindNearestRequest, opts ...grpc.CallOption) (*FindVehiclesResponse, error) {
+	out := new(FindVehiclesResponse)
+	err := c.cc.Invoke(ctx, SpatialIndexService_FindNearest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spatialIndexServiceClient) FindWithinRadius(ctx context.Context, in *FindWithinRadiusRequest, opts ...grpc.CallOption) (*FindVehiclesResponse, error) {
+	out := new(FindVehiclesResponse)
+	err := c.cc.Invoke(ctx, SpatialIndexService_FindWithinRadius_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spatialIndexServiceClient) FindInBoundingBox(ctx context.Context, in *FindInBoundingBoxRequest, opts ...grpc.CallOption) (*FindVehiclesResponse, error) {
+	out := new(FindVehiclesResponse)
+	err := c.cc.Invoke(ctx, SpatialIndexService_FindInBoundingBox_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SpatialIndexServiceServer is the server API for SpatialIndexService service.
+// All implementations must embed UnimplementedSpatialIndexServiceServer
+// for forward compatibility
+type SpatialIndexServiceServer interface {
+	// Returns the k vehicles nearest to a point, nearest first.
+	FindNearest(context.Context, *FindNearestRequest) (*FindVehiclesResponse, error)
+	// Returns vehicles within a radius of a point, nearest first.
+	FindWithinRadius(context.Context, *FindWithinRadiusRequest) (*FindVehiclesResponse, error)
+	// Returns vehicles inside a bounding box, ordered by vehicle ID.
+	FindInBoundingBox(context.Context, *FindInBoundingBoxRequest) (*FindVehiclesResponse, error)
+	mustEmbedUnimplementedSpatialIndexServiceServer()
+}
+
+// UnimplementedSpatialIndexServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSpatialIndexServiceServer struct {
+}
+
+func (UnimplementedSpatialIndexServiceServer) FindNearest(context.Context, *FindNearestRequest) (*FindVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindNearest not implemented")
+}
+func (UnimplementedSpatialIndexServiceServer) FindWithinRadius(context.Context, *FindWithinRadiusRequest) (*FindVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindWithinRadius not implemented")
+}
+func (UnimplementedSpatialIndexServiceServer) FindInBoundingBox(context.Context, *FindInBoundingBoxRequest) (*FindVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindInBoundingBox not implemented")
+}
+func (UnimplementedSpatialIndexServiceServer) mustEmbedUnimplementedSpatialIndexServiceServer() {}
+
+// UnsafeSpatialIndexServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpatialIndexServiceServer will
+// result in compilation errors.
+type UnsafeSpatialIndexServiceServer interface {
+	mustEmbedUnimplementedSpatialIndexServiceServer()
+}
+
+func RegisterSpatialIndexServiceServer(s grpc.ServiceRegistrar, srv SpatialIndexServiceServer) {
+	s.RegisterService(&SpatialIndexService_ServiceDesc, srv)
+}
+
+func _SpatialIndexService_FindNearest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNearestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpatialIndexServiceServer).FindNearest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpatialIndexService_FindNearest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpatialIndexServiceServer).FindNearest(ctx, req.(*FindNearestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpatialIndexService_FindWithinRadius_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindWithinRadiusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpatialIndexServiceServer).FindWithinRadius(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpatialIndexService_FindWithinRadius_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpatialIndexServiceServer).FindWithinRadius(ctx, req.(*FindWithinRadiusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpatialIndexService_FindInBoundingBox_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindInBoundingBoxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpatialIndexServiceServer).FindInBoundingBox(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpatialIndexService_FindInBoundingBox_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpatialIndexServiceServer).FindInBoundingBox(ctx, req.(*FindInBoundingBoxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SpatialIndexService_ServiceDesc is the grpc.ServiceDesc for SpatialIndexService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SpatialIndexService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spatial.SpatialIndexService",
+	HandlerType: (*SpatialIndexServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindNearest",
+			Handler:    _SpatialIndexService_FindNearest_Handler,
+		},
+		{
+			MethodName: "FindWithinRadius",
+			Handler:    _SpatialIndexService_FindWithinRadius_Handler,
+		},
+		{
+			MethodName: "FindInBoundingBox",
+			Handler:    _SpatialIndexService_FindInBoundingBox_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spatial.proto",
+}
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	TrackerService_SendPing_FullMethodName = "/tracker.TrackerService/SendPing"
+	TrackerService_SendPing_FullMethodName      = "/tracker.TrackerService/SendPing"
+	TrackerService_SendPingBatch_FullMethodName = "/tracker.TrackerService/SendPingBatch"
 )
 
 // TrackerServiceClient is the client API for TrackerService service.
@@ -28,6 +29,9 @@ const (
 type TrackerServiceClient interface {
 	// Receives a single location ping from a vehicle.
 	SendPing(ctx context.Context, in *LocationPing, opts ...grpc.CallOption) (*PingResponse, error)
+	// Receives a batch of pings. In transactional producer mode the batch is
+	// committed to Kafka atomically: either every ping is published or none.
+	SendPingBatch(ctx context.Context, in *LocationPingBatch, opts ...grpc.CallOption) (*PingResponse, error)
 }
 
 type trackerServiceClient struct {
@@ -47,12 +51,24 @@ func (c *trackerServiceClient) SendPing(ctx context.Context, in *LocationPing, o
 	return out, nil
 }
 
+func (c *trackerServiceClient) SendPingBatch(ctx context.Context, in *LocationPingBatch, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, TrackerService_SendPingBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TrackerServiceServer is the server API for TrackerService service.
 // All implementations must embed UnimplementedTrackerServiceServer
 // for forward compatibility
 type TrackerServiceServer interface {
 	// Receives a single location ping from a vehicle.
 	SendPing(context.Context, *LocationPing) (*PingResponse, error)
+	// Receives a batch of pings. In transactional producer mode the batch is
+	// committed to Kafka atomically: either every ping is published or none.
+	SendPingBatch(context.Context, *LocationPingBatch) (*PingResponse, error)
 	mustEmbedUnimplementedTrackerServiceServer()
 }
 
@@ -63,6 +79,9 @@ type UnimplementedTrackerServiceServer struct {
 func (UnimplementedTrackerServiceServer) SendPing(context.Context, *LocationPing) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendPing not implemented")
 }
+func (UnimplementedTrackerServiceServer) SendPingBatch(context.Context, *LocationPingBatch) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendPingBatch not implemented")
+}
 func (UnimplementedTrackerServiceServer) mustEmbedUnimplementedTrackerServiceServer() {}
 
 // UnsafeTrackerServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -94,6 +113,24 @@ func _TrackerService_SendPing_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TrackerService_SendPingBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocationPingBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TrackerServiceServer).SendPingBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TrackerService_SendPingBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TrackerServiceServer).SendPingBatch(ctx, req.(*LocationPingBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TrackerService_ServiceDesc is the grpc.ServiceDesc for TrackerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -105,6 +142,10 @@ var TrackerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendPing",
 			Handler:    _TrackerService_SendPing_Handler,
 		},
+		{
+			MethodName: "SendPingBatch",
+			Handler:    _TrackerService_SendPingBatch_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tracker.proto",
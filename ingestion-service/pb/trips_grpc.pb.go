@@ -0,0 +1,111 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: trips.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	TripService_ListRecentTrips_FullMethodName = "/trips.TripService/ListRecentTrips"
+)
+
+// TripServiceClient is the client API for TripService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TripServiceClient interface {
+	// Returns a vehicle's most recent trips, newest first.
+	ListRecentTrips(ctx context.Context, in *ListRecentTripsRequest, opts ...grpc.CallOption) (*ListRecentTripsResponse, error)
+}
+
+type tripServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTripServiceClient(cc grpc.ClientConnInterface) TripServiceClient {
+	return &tripServiceClient{cc}
+}
+
+func (c *tripServiceClient) ListRecentTrips(ctx context.Context, in *ListRecentTripsRequest, opts ...grpc.CallOption) (*ListRecentTripsResponse, error) {
+	out := new(ListRecentTripsResponse)
+	err := c.cc.Invoke(ctx, TripService_ListRecentTrips_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TripServiceServer is the server API for TripService service.
+// All implementations must embed UnimplementedTripServiceServer
+// for forward compatibility
+type TripServiceServer interface {
+	// Returns a vehicle's most recent trips, newest first.
+	ListRecentTrips(context.Context, *ListRecentTripsRequest) (*ListRecentTripsResponse, error)
+	mustEmbedUnimplementedTripServiceServer()
+}
+
+// UnimplementedTripServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTripServiceServer struct {
+}
+
+func (UnimplementedTripServiceServer) ListRecentTrips(context.Context, *ListRecentTripsRequest) (*ListRecentTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRecentTrips not implemented")
+}
+func (UnimplementedTripServiceServer) mustEmbedUnimplementedTripServiceServer() {}
+
+// UnsafeTripServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TripServiceServer will
+// result in compilation errors.
+type UnsafeTripServiceServer interface {
+	mustEmbedUnimplementedTripServiceServer()
+}
+
+func RegisterTripServiceServer(s grpc.ServiceRegistrar, srv TripServiceServer) {
+	s.RegisterService(&TripService_ServiceDesc, srv)
+}
+
+func _TripService_ListRecentTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRecentTripsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListRecentTrips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListRecentTrips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListRecentTrips(ctx, req.(*ListRecentTripsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TripService_ServiceDesc is the grpc.ServiceDesc for TripService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TripService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "trips.TripService",
+	HandlerType: (*TripServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRecentTrips",
+			Handler:    _TripService_ListRecentTrips_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "trips.proto",
+}
@@ -0,0 +1,140 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: watch.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	VehicleWatchService_WatchVehicles_FullMethodName = "/watch.VehicleWatchService/WatchVehicles"
+)
+
+// VehicleWatchServiceClient is the client API for VehicleWatchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VehicleWatchServiceClient interface {
+	// Streams an update for every ping matching the request until the client
+	// cancels. Filters combine: an update must match all that are set.
+	WatchVehicles(ctx context.Context, in *WatchVehiclesRequest, opts ...grpc.CallOption) (VehicleWatchService_WatchVehiclesClient, error)
+}
+
+type vehicleWatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVehicleWatchServiceClient(cc grpc.ClientConnInterface) VehicleWatchServiceClient {
+	return &vehicleWatchServiceClient{cc}
+}
+
+func (c *vehicleWatchServiceClient) WatchVehicles(ctx context.Context, in *WatchVehiclesRequest, opts ...grpc.CallOption) (VehicleWatchService_WatchVehiclesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VehicleWatchService_ServiceDesc.Streams[0], VehicleWatchService_WatchVehicles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vehicleWatchServiceWatchVehiclesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VehicleWatchService_WatchVehiclesClient interface {
+	Recv() (*VehicleUpdate, error)
+	grpc.ClientStream
+}
+
+type vehicleWatchServiceWatchVehiclesClient struct {
+	grpc.ClientStream
+}
+
+func (x *vehicleWatchServiceWatchVehiclesClient) Recv() (*VehicleUpdate, error) {
+	m := new(VehicleUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// VehicleWatchServiceServer is the server API for VehicleWatchService service.
+// All implementations must embed UnimplementedVehicleWatchServiceServer
+// for forward compatibility
+type VehicleWatchServiceServer interface {
+	// Streams an update for every ping matching the request until the client
+	// cancels. Filters combine: an update must match all that are set.
+	WatchVehicles(*WatchVehiclesRequest, VehicleWatchService_WatchVehiclesServer) error
+	mustEmbedUnimplementedVehicleWatchServiceServer()
+}
+
+// UnimplementedVehicleWatchServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedVehicleWatchServiceServer struct {
+}
+
+func (UnimplementedVehicleWatchServiceServer) WatchVehicles(*WatchVehiclesRequest, VehicleWatchService_WatchVehiclesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchVehicles not implemented")
+}
+func (UnimplementedVehicleWatchServiceServer) mustEmbedUnimplementedVehicleWatchServiceServer() {}
+
+// UnsafeVehicleWatchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VehicleWatchServiceServer will
+// result in compilation errors.
+type UnsafeVehicleWatchServiceServer interface {
+	mustEmbedUnimplementedVehicleWatchServiceServer()
+}
+
+func RegisterVehicleWatchServiceServer(s grpc.ServiceRegistrar, srv VehicleWatchServiceServer) {
+	s.RegisterService(&VehicleWatchService_ServiceDesc, srv)
+}
+
+func _VehicleWatchService_WatchVehicles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchVehiclesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VehicleWatchServiceServer).WatchVehicles(m, &vehicleWatchServiceWatchVehiclesServer{stream})
+}
+
+type VehicleWatchService_WatchVehiclesServer interface {
+	Send(*VehicleUpdate) error
+	grpc.ServerStream
+}
+
+type vehicleWatchServiceWatchVehiclesServer struct {
+	grpc.ServerStream
+}
+
+func (x *vehicleWatchServiceWatchVehiclesServer) Send(m *VehicleUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// VehicleWatchService_ServiceDesc is the grpc.ServiceDesc for VehicleWatchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VehicleWatchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "watch.VehicleWatchService",
+	HandlerType: (*VehicleWatchServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchVehicles",
+			Handler:       _VehicleWatchService_WatchVehicles_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "watch.proto",
+}
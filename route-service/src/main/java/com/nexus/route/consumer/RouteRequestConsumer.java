@@ -16,7 +16,8 @@ public class RouteRequestConsumer {
     private final RouteOptimizer routeOptimizer;
     private final ObjectMapper objectMapper;
 
-    @KafkaListener(topics = "route-requests", groupId = "route-group")
+    @KafkaListener(topics = "route-requests", groupId = "route-group",
+            autoStartup = "${route.consumer.enabled:true}")
     public void listen(String message) {
         try {
             // log.info("Received route request: {}", message);
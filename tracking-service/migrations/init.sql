@@ -8,3 +8,35 @@ CREATE TABLE IF NOT EXISTS vehicle_locations (
 );
 
 CREATE INDEX IF NOT EXISTS idx_vehicle_id_timestamp ON vehicle_locations(vehicle_id, timestamp DESC);
+
+-- Geofence history, appended to by the ingestion geofence service; the
+-- current geofence is its highest version.
+CREATE TABLE IF NOT EXISTS geofence_versions (
+    geofence_id VARCHAR(255) NOT NULL,
+    version BIGINT NOT NULL,
+    body JSONB NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (geofence_id, version)
+);
+
+-- Completed trips, written by the ingestion trips service.
+CREATE TABLE IF NOT EXISTS trips (
+    trip_id VARCHAR(255) PRIMARY KEY,
+    vehicle_id VARCHAR(255) NOT NULL,
+    tenant VARCHAR(255),
+    start_time BIGINT NOT NULL,
+    end_time BIGINT NOT NULL,
+    start_latitude DOUBLE PRECISION NOT NULL,
+    start_longitude DOUBLE PRECISION NOT NULL,
+    end_latitude DOUBLE PRECISION NOT NULL,
+    end_longitude DOUBLE PRECISION NOT NULL,
+    distance_meters DOUBLE PRECISION NOT NULL,
+    duration_seconds BIGINT NOT NULL,
+    max_speed_kph DOUBLE PRECISION NOT NULL,
+    avg_speed_kph DOUBLE PRECISION NOT NULL,
+    polyline TEXT NOT NULL,
+    end_reason VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_trips_vehicle_end ON trips(vehicle_id, end_time DESC);
@@ -12,6 +12,25 @@ import {
 const app = express();
 const port = process.env.PORT || 3000;
 
+// Sorted set of vehicle IDs scored by their latest ping timestamp,
+// maintained alongside vehicle:{id}:latest.
+const ACTIVE_VEHICLES_KEY = 'vehicles:active';
+const ACTIVE_WINDOW_SECONDS = 86400;
+
+// Latest positions of vehicles seen within the active window, read through
+// the index instead of scanning the keyspace with KEYS.
+const getActiveVehicles = async (): Promise<any[]> => {
+    const since = Math.floor(Date.now() / 1000) - ACTIVE_WINDOW_SECONDS;
+    const ids: string[] = await redis.zrevrangebyscore(ACTIVE_VEHICLES_KEY, '+inf', since);
+    if (ids.length === 0) {
+        return [];
+    }
+    const values = await redis.mget(ids.map(id => `vehicle:${id}:latest`));
+    return values
+        .filter((v): v is string => v !== null)
+        .map(v => JSON.parse(v));
+};
+
 // ============================================
 // SECURITY: Request Size Limits
 // ============================================
@@ -72,8 +91,9 @@ app.get('/tracking/:vehicleId', async (req, res) => {
 
         if (result.rows.length > 0) {
             const location = result.rows[0];
-            // Populate Cache for next time
-            await redis.set(`vehicle:${vehicleId}:latest`, JSON.stringify(location), 'EX', 86400);
+            // Populate Cache for next time, unless a newer ping landed
+            // meanwhile
+            await redis.set(`vehicle:${vehicleId}:latest`, JSON.stringify(location), 'EX', 86400, 'NX');
             res.json(location);
         } else {
             res.status(404).json({ error: 'Vehicle not found' });
@@ -88,24 +108,7 @@ app.get('/tracking/:vehicleId', async (req, res) => {
 // API Endpoint: Get All Active Vehicles
 app.get('/vehicles', async (req, res) => {
     try {
-        // Scan Redis for all vehicle keys
-        const keys = await redis.keys('vehicle:*:latest');
-
-        if (keys.length === 0) {
-            res.json([]);
-            return;
-        }
-
-        // Fetch all vehicle data
-        const vehicles = await Promise.all(
-            keys.map(async (key: string) => {
-                const data = await redis.get(key);
-                return data ? JSON.parse(data) : null;
-            })
-        );
-
-        // Filter out nulls and return
-        res.json(vehicles.filter(v => v !== null));
+        res.json(await getActiveVehicles());
     } catch (error) {
         console.error('Error fetching all vehicles:', error);
         res.status(500).json({ error: 'Internal Server Error' });
@@ -251,17 +254,7 @@ app.get('/live/buses', async (req, res) => {
 app.get('/live/all', async (req, res) => {
     try {
         // 1. Get trucks from Redis
-        const keys = await redis.keys('vehicle:*:latest');
-        const trucks = await Promise.all(
-            keys.map(async (key: string) => {
-                const data = await redis.get(key);
-                if (data) {
-                    const parsed = JSON.parse(data);
-                    return { ...parsed, type: 'truck' };
-                }
-                return null;
-            })
-        );
+        const trucks = (await getActiveVehicles()).map(v => ({ ...v, type: 'truck' }));
 
         let aircraft: any[] = [];
         try {
@@ -20,6 +20,12 @@ const kafka = new Kafka({
 
 const consumer = kafka.consumer({ groupId: 'tracking-group' });
 
+// History is written in bulk by the Go writer service when this is false.
+const persistLocations = process.env.PERSIST_LOCATIONS !== 'false';
+// Latest positions are written by the Go latest-writer service, which
+// never lets an older ping overwrite a newer one, when this is false.
+const updateLatest = process.env.UPDATE_LATEST !== 'false';
+
 interface LocationPing {
     vehicle_id: string;
     latitude: number;
@@ -43,19 +49,22 @@ export const startConsumer = async () => {
                 messagesConsumedCounter.inc();
 
                 // 1. Write-Through Cache (Redis) - O(1) Speed
-                // Store the latest state as a JSON string
-                await redis.set(
-                    `vehicle:${vehicle_id}:latest`,
-                    JSON.stringify(ping),
-                    'EX', // Optional: Set expiry if vehicles go offline for long periods (e.g., 24 hours)
-                    86400
-                );
+                // Store the latest state as a JSON string, indexed by timestamp
+                if (updateLatest) {
+                    await redis
+                        .multi()
+                        .set(`vehicle:${vehicle_id}:latest`, JSON.stringify(ping), 'EX', 86400)
+                        .zadd('vehicles:active', timestamp, vehicle_id)
+                        .exec();
+                }
 
                 // 2. Persist History (PostgreSQL) - Durability
-                await pool.query(
-                    'INSERT INTO vehicle_locations (vehicle_id, latitude, longitude, timestamp) VALUES ($1, $2, $3, $4)',
-                    [vehicle_id, latitude, longitude, timestamp]
-                );
+                if (persistLocations) {
+                    await pool.query(
+                        'INSERT INTO vehicle_locations (vehicle_id, latitude, longitude, timestamp) VALUES ($1, $2, $3, $4)',
+                        [vehicle_id, latitude, longitude, timestamp]
+                    );
+                }
 
                 // console.log(`Processed ping for ${vehicle_id}`);
 